@@ -4,15 +4,23 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
+	// Time zones of users are loaded without tzdata of the system.
+	_ "time/tzdata"
 
 	"github.com/Kin-dza-dzaa/flash_cards_api/config"
 	_ "github.com/Kin-dza-dzaa/flash_cards_api/docs"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/rest"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/server"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/cloudtrans"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/googletrans"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/localdict"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/postgresql"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/auth"
 	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/googletransclient"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/googletransclient/fake"
 	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
 	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
 	"github.com/go-chi/chi/v5"
@@ -64,7 +72,29 @@ func run(cfg config.Cfg) error {
 	otel.SetTracerProvider(tp)
 
 	// Clients.
-	client, err := googletransclient.New(cfg.GoogleAPI.URL)
+	if cfg.Dev.FakeTranslate {
+		srv, err := fake.New(fake.Options{
+			Addr:                cfg.Dev.FakeTranslateAddr,
+			Latency:             cfg.Dev.FakeLatency,
+			MalformedRate:       cfg.Dev.FakeMalformedRate,
+			TooManyRequestsRate: cfg.Dev.FakeTooManyRequestsRate,
+		})
+		if err != nil {
+			return fmt.Errorf("main - run - fake.New: %w", err)
+		}
+		defer srv.Close()
+		cfg.GoogleAPI.URL, cfg.GoogleAPI.CAFile = srv.URL(), srv.CAFile()
+		l.Warn("google translate is replaced by fake server", slog.String("url", srv.URL()))
+	}
+	client, err := googletransclient.New(cfg.GoogleAPI.URL, googletransclient.Options{
+		Timeout:          cfg.GoogleAPI.Timeout,
+		Retries:          cfg.GoogleAPI.Retries,
+		Backoff:          cfg.GoogleAPI.Backoff,
+		MaxBackoff:       cfg.GoogleAPI.MaxBackoff,
+		BreakerThreshold: cfg.GoogleAPI.BreakerThreshold,
+		BreakerCooldown:  cfg.GoogleAPI.BreakerCooldown,
+		CAFile:           cfg.GoogleAPI.CAFile,
+	})
 	if err != nil {
 		return fmt.Errorf("main - run - googletransclient.New: %w", err)
 	}
@@ -76,13 +106,53 @@ func run(cfg config.Cfg) error {
 
 	// Adapters/Repo layer.
 	r := postgresql.NewWordPostgre(pool)
-	g := googletrans.New(client, cfg.GoogleAPI.DefaultSrcLang, cfg.GoogleAPI.DefaultTrgtLang)
+	sr := postgresql.NewSettingsPostgre(pool)
+	str := postgresql.NewStatsPostgre(pool)
+	cr := postgresql.NewCollectionPostgre(pool)
+	rr := postgresql.NewRefreshPostgre(pool)
+	kr := postgresql.NewAPIKeyPostgre(pool)
+	ar := postgresql.NewAccountPostgre(pool)
+	adr := postgresql.NewAdminPostgre(pool)
+	providers, closeProviders, err := transProviders(cfg, client)
+	if err != nil {
+		return fmt.Errorf("main - run - transProviders: %w", err)
+	}
+	defer closeProviders()
 
 	// Usecase/business logic layer.
-	s := service.NewWordService(r, g)
+	schedulers := service.Schedulers{
+		entity.SchedulerSM2:  service.NewSM2(),
+		entity.SchedulerFSRS: service.NewFSRS(cfg.Scheduler.FSRSRequestRetention, cfg.Scheduler.FSRSMaximumInterval),
+	}
+	s := service.NewWordService(
+		r,
+		pool,
+		providers,
+		sr,
+		schedulers,
+		cfg.GoogleAPI.DefaultSrcLang,
+		cfg.GoogleAPI.DefaultTrgtLang,
+		cfg.Translation.BatchWorkers,
+		cfg.Translation.BatchBudget,
+	)
+	ss := service.NewSettingsService(sr)
+	sts := service.NewStatsService(str, sr)
+	cs := service.NewCollectionService(cr)
+	rs := service.NewRefresherService(rr, providers, cfg.Translation.RefreshMaxAge, cfg.Translation.RefreshBatch)
+	ks := service.NewAPIKeyService(kr)
+	as := service.NewAccountService(ar, ss, cfg.Account.DeleteBatch)
+	ads := service.NewAdminService(adr, providers)
+
+	// Background jobs.
+	go refreshTranslations(appCtx, rs, cfg.Translation.RefreshInterval, l)
+	go deleteAccounts(appCtx, as, cfg.Account.DeleteInterval, l)
 
 	// Port layer.
-	h := rest.NewWordHandler(s, l)
+	a, err := authenticator(appCtx, cfg)
+	if err != nil {
+		return fmt.Errorf("main - run - authenticator: %w", err)
+	}
+	h := rest.NewWordHandler(s, ss, sts, cs, rs, ks, as, ads, a, l)
 	c := chi.NewRouter()
 	h.Register(c, cfg)
 
@@ -94,6 +164,127 @@ func run(cfg config.Cfg) error {
 	return nil
 }
 
+// Translation providers in configured order, returned func releases their resources.
+func transProviders(
+	cfg config.Cfg,
+	client *googletransclient.TranlateClient,
+) (service.TransProviders, func(), error) {
+	var closers []func() error
+	closeAll := func() {
+		for _, c := range closers {
+			_ = c()
+		}
+	}
+
+	providers := make(service.TransProviders, 0, len(cfg.Translation.Providers))
+	for _, name := range cfg.Translation.Providers {
+		var (
+			repo    service.TransRepo
+			version int
+		)
+		switch name {
+		case "google":
+			repo, version = googletrans.New(client), googletrans.Version
+		case "cloud":
+			repo = cloudtrans.New(cfg.Translation.CloudURL, cfg.Translation.CloudKey, cfg.Translation.CloudTimeout)
+			version = cloudtrans.Version
+		case "dict":
+			d, err := localdict.Load(cfg.Translation.DictDir)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("localdict.Load: %w", err)
+			}
+			closers = append(closers, d.Close)
+			repo, version = d, localdict.Version
+		default:
+			closeAll()
+			return nil, nil, fmt.Errorf("unknown translation provider %q", name)
+		}
+		// Requests are counted for error rates of the admin API.
+		providers = append(providers, service.TransProvider{Name: name, Version: version, Repo: service.NewCountingRepo(repo)})
+	}
+	if len(providers) == 0 {
+		return nil, nil, fmt.Errorf("no translation providers configured")
+	}
+	return providers, closeAll, nil
+}
+
+// Verifier of bearer tokens selected by config.
+func authenticator(ctx context.Context, cfg config.Cfg) (auth.Authenticator, error) {
+	opts := auth.Options{
+		Issuer:   cfg.Auth.Issuer,
+		Audience: cfg.Auth.Audience,
+	}
+
+	switch cfg.Auth.Provider {
+	case "google":
+		return auth.NewGoogle(opts)
+	case "jwks":
+		switch {
+		case cfg.Auth.JWKSURL != "":
+			return auth.NewJWKSURL(ctx, cfg.Auth.JWKSURL, cfg.Auth.JWKSRefresh, opts)
+		case cfg.Auth.JWKSFile != "":
+			return auth.NewJWKSFile(cfg.Auth.JWKSFile, opts)
+		}
+		return nil, fmt.Errorf("jwks provider requires AUTH_JWKS_URL or AUTH_JWKS_FILE")
+	case "hs256":
+		return auth.NewHS256([]byte(cfg.Auth.HS256Secret), opts)
+	}
+	return nil, fmt.Errorf("unknown auth provider %q", cfg.Auth.Provider)
+}
+
+// Translates stale translations every interval until ctx is done, zero interval disables refreshing.
+func refreshTranslations(ctx context.Context, refresher *service.Refresher, interval time.Duration, l *slog.Logger) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := refresher.Refresh(ctx)
+		if err != nil {
+			l.Error("couldn't refresh translations", slog.String("error", err.Error()))
+			continue
+		}
+		if stats.Refreshed+stats.Failed > 0 {
+			l.Info("translations refreshed", slog.Int("refreshed", stats.Refreshed), slog.Int("failed", stats.Failed))
+		}
+	}
+}
+
+// Deletes data of users who requested it every interval until ctx is done.
+func deleteAccounts(ctx context.Context, accounts *service.Account, interval time.Duration, l *slog.Logger) {
+	if interval <= 0 {
+		l.Warn("deletion of user data is disabled, set ACCOUNT_DELETE_INTERVAL")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := accounts.DeletePending(ctx)
+		if deleted > 0 {
+			l.Info("user data deleted", slog.Int("accounts", deleted))
+		}
+		if err != nil {
+			l.Error("couldn't delete user data", slog.String("error", err.Error()))
+		}
+	}
+}
+
 // Get Jaeger tracer provider.
 func otelTP(serviceName, version, environment, url string) (*trace.TracerProvider, error) {
 	// Create the Jaeger exporter.
@@ -0,0 +1,102 @@
+// Importer seeds word_translation with a Wiktionary JSONL dump from https://kaikki.org.
+//
+//	go run cmd/importer/main.go -dump kaikki.org-dictionary-English.jsonl.gz -src en -trgt ru
+//
+// Import can be interrupted, the next run with the same dump and language pair resumes it.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/config"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/kaikki"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/postgresql"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	"golang.org/x/exp/slog"
+)
+
+func main() {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var (
+		dumpPath  = flag.String("dump", "", "path to the JSONL dump, can be gzipped")
+		srcLang   = flag.String("src", cfg.GoogleAPI.DefaultSrcLang, "language code of the dump words")
+		trgtLang  = flag.String("trgt", cfg.GoogleAPI.DefaultTrgtLang, "language code of the translations")
+		source    = flag.String("source", "", "name of the import progress, dump file name and language pair by default")
+		batchSize = flag.Int("batch", 1000, "words in one transaction")
+	)
+	flag.Parse()
+	if *dumpPath == "" || *batchSize <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *source == "" {
+		*source = fmt.Sprintf("%s:%s-%s", filepath.Base(*dumpPath), *srcLang, *trgtLang)
+	}
+
+	if err := run(cfg, *dumpPath, *srcLang, *trgtLang, *source, *batchSize); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(cfg config.Cfg, dumpPath, srcLang, trgtLang, source string, batchSize int) error {
+	// Interrupted import keeps already stored batches.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	l := logger.New(slog.Level(cfg.Logger.Level))
+
+	pool, err := postgres.New(ctx, cfg.PG.URL, cfg.PG.MaxPoolSize)
+	if err != nil {
+		return fmt.Errorf("main - run - postgres.New: %w", err)
+	}
+	defer pool.Close()
+
+	// Size of uncompressed dump is unknown, progress is reported without percents then.
+	var size int64
+	if info, err := os.Stat(dumpPath); err == nil && !strings.HasSuffix(dumpPath, ".gz") {
+		size = info.Size()
+	}
+
+	importer := service.NewImporterService(postgresql.NewImportPostgre(pool), batchSize)
+	dump := kaikki.New(dumpPath, srcLang, trgtLang)
+	start := time.Now()
+	err = importer.Import(ctx, source, dump, func(p entity.ImportProgress) {
+		attrs := []any{
+			slog.String("source", p.Source),
+			slog.Int("words", p.Words),
+			slog.Int64("offset", p.Offset),
+			slog.Float64("words_per_sec", float64(p.Words)/time.Since(start).Seconds()),
+		}
+		if size > 0 {
+			attrs = append(attrs, slog.Float64("percent", 100*float64(p.Offset)/float64(size)))
+		}
+		l.Info("imported", attrs...)
+	})
+	if errors.Is(err, context.Canceled) {
+		l.Info("import interrupted, run it again to resume", slog.String("source", source))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("main - run - importer.Import: %w", err)
+	}
+
+	l.Info("import finished", slog.String("source", source), slog.Duration("took", time.Since(start)))
+	return nil
+}
@@ -28,7 +28,7 @@ type (
 		AllowCredentials bool          `env:"HTTP_ALLOW_CREDENTIALS" env-default:"true"`
 		AllowedOrigins   []string      `env:"HTTP_ALLOWED_ORIGINS" env-separator:" " env-default:"http://localhost http://localhost:3000"`
 		AllowedHeaders   []string      `env:"HTTP_ALLOWED_HEADERS" env-separator:" " env-default:"Content-Type Authorization"`
-		AllowedMethods   []string      `env:"HTTP_ALLOWED_METHODS" env-separator:" " env-default:"POST GET PUT DELETE OPTIONS"`
+		AllowedMethods   []string      `env:"HTTP_ALLOWED_METHODS" env-separator:" " env-default:"POST GET PUT PATCH DELETE OPTIONS"`
 		ShutdownTimeout  time.Duration `env:"HTTP_SHUT_DOWN_TIMEOUT" env-default:"10s"`
 		// In seconds
 		DefaultCorsDuration uint `env:"HTTP_DEFAULT_CORS_DURATION" env-default:"5"`
@@ -43,14 +43,97 @@ type (
 		URL             string `env:"GOOGLE_TRANSLATE_URL" env-default:"https://translate.google.com/_/TranslateWebserverUi/data/batchexecute"`
 		DefaultSrcLang  string `env:"GOOGLE_TRANSLATE_DEFAULT_SRC" env-default:"en"`
 		DefaultTrgtLang string `env:"GOOGLE_TRANSLATE_DEFAULT_TRGT" env-default:"ru"`
+		// Timeout of one attempt, failed attempts are retried with exponential backoff.
+		Timeout    time.Duration `env:"GOOGLE_TRANSLATE_TIMEOUT" env-default:"5s"`
+		Retries    int           `env:"GOOGLE_TRANSLATE_RETRIES" env-default:"2"`
+		Backoff    time.Duration `env:"GOOGLE_TRANSLATE_BACKOFF" env-default:"200ms"`
+		MaxBackoff time.Duration `env:"GOOGLE_TRANSLATE_MAX_BACKOFF" env-default:"2s"`
+		// Requests fail fast for BreakerCooldown after BreakerThreshold consecutive failures, zero threshold disables it.
+		BreakerThreshold int           `env:"GOOGLE_TRANSLATE_BREAKER_THRESHOLD" env-default:"5"`
+		BreakerCooldown  time.Duration `env:"GOOGLE_TRANSLATE_BREAKER_COOLDOWN" env-default:"30s"`
+		// PEM file with an additional trusted CA, e.g. of a proxy.
+		CAFile string `env:"GOOGLE_TRANSLATE_CA_FILE"`
+	}
+
+	Translation struct {
+		// Providers in priority order, the next one is used when a provider fails or has no translation.
+		// Supported providers: google, cloud, dict.
+		Providers []string `env:"TRANSLATION_PROVIDERS" env-separator:" " env-default:"google"`
+		// Local StarDict and DSL dictionaries in subdirectories named by language pair, e.g. en-ru.
+		DictDir string `env:"DICTIONARY_DIR" env-default:"./dicts"`
+		// Official Google Cloud Translation API.
+		CloudURL     string        `env:"CLOUD_TRANSLATE_URL" env-default:"https://translation.googleapis.com/language/translate/v2"`
+		CloudKey     string        `env:"CLOUD_TRANSLATE_KEY"`
+		CloudTimeout time.Duration `env:"CLOUD_TRANSLATE_TIMEOUT" env-default:"5s"`
+		// Stored translations older than RefreshMaxAge or made by an older provider version are translated again,
+		// RefreshBatch translations every RefreshInterval. Zero age refreshes only older versions, zero interval disables refreshing.
+		RefreshMaxAge   time.Duration `env:"TRANSLATION_REFRESH_MAX_AGE" env-default:"720h"`
+		RefreshInterval time.Duration `env:"TRANSLATION_REFRESH_INTERVAL" env-default:"1m"`
+		RefreshBatch    int           `env:"TRANSLATION_REFRESH_BATCH" env-default:"20"`
+		// Words of a bulk add are translated by BatchWorkers (at least one) concurrent workers, words not added within
+		// BatchBudget are reported as failed. Budget should be less than HTTP_WRITE_TIMEOUT.
+		BatchWorkers int           `env:"TRANSLATION_BATCH_WORKERS" env-default:"4"`
+		BatchBudget  time.Duration `env:"TRANSLATION_BATCH_BUDGET" env-default:"4s"`
+	}
+
+	Dev struct {
+		// Translate requests are served by a local fake server with recorded responses, so the app runs offline.
+		FakeTranslate     bool          `env:"DEV_FAKE_TRANSLATE" env-default:"false"`
+		FakeTranslateAddr string        `env:"DEV_FAKE_TRANSLATE_ADDR" env-default:"127.0.0.1:8443"`
+		FakeLatency       time.Duration `env:"DEV_FAKE_TRANSLATE_LATENCY" env-default:"0s"`
+		// Shares of requests answered with a malformed body and with 429, from 0 to 1.
+		FakeMalformedRate       float64 `env:"DEV_FAKE_TRANSLATE_MALFORMED_RATE" env-default:"0"`
+		FakeTooManyRequestsRate float64 `env:"DEV_FAKE_TRANSLATE_429_RATE" env-default:"0"`
+	}
+
+	Auth struct {
+		// Verifier of bearer tokens: google (Google ID tokens), jwks (tokens of an OIDC provider) or hs256 (shared secret).
+		Provider string `env:"AUTH_PROVIDER" env-default:"google"`
+		// Expected iss and aud claims, empty issuer isn't checked. Audience is required by google and jwks providers.
+		Issuer   string `env:"AUTH_ISSUER"`
+		Audience string `env:"AUTH_AUDIENCE"`
+		// JWK set of jwks provider, URL of the set (jwks_uri of OIDC provider) or path of a local file.
+		JWKSURL     string        `env:"AUTH_JWKS_URL"`
+		JWKSFile    string        `env:"AUTH_JWKS_FILE"`
+		JWKSRefresh time.Duration `env:"AUTH_JWKS_REFRESH" env-default:"15m"`
+		// Secret of hs256 provider.
+		HS256Secret string `env:"AUTH_HS256_SECRET"`
+	}
+
+	Admin struct {
+		// IDs of users allowed to use admin endpoints.
+		Users []string `env:"ADMIN_USERS" env-separator:" "`
+		// Users whose token has Role in RoleClaim (e.g. roles) are admins too, empty claim disables it.
+		// Claim is a list of roles or a string with roles separated by spaces.
+		RoleClaim string `env:"ADMIN_ROLE_CLAIM"`
+		Role      string `env:"ADMIN_ROLE" env-default:"admin"`
+	}
+
+	Account struct {
+		// Requested deletions of user data are processed in the background, DeleteBatch requests every DeleteInterval.
+		DeleteInterval time.Duration `env:"ACCOUNT_DELETE_INTERVAL" env-default:"30s"`
+		DeleteBatch    int           `env:"ACCOUNT_DELETE_BATCH" env-default:"10"`
+	}
+
+	Scheduler struct {
+		// Probability of recall at the moment of the next review.
+		FSRSRequestRetention float64 `env:"FSRS_REQUEST_RETENTION" env-default:"0.9"`
+		// In days.
+		FSRSMaximumInterval int `env:"FSRS_MAXIMUM_INTERVAL" env-default:"36500"`
 	}
 
 	Cfg struct {
 		OpenTelemetry OpenTelemetry
 		GoogleAPI     DictionaryAPI
+		Translation   Translation
 		PG            Postgres
 		Logger        Logger
 		HTTP          HTTP
+		Scheduler     Scheduler
+		Auth          Auth
+		Admin         Admin
+		Account       Account
+		Dev           Dev
 	}
 )
 
@@ -18,21 +18,80 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/words": {
+        "/admin/providers": {
             "get": {
-                "description": "Gets user words that put together in collections.",
+                "description": "Gets numbers of translate requests and failures of each provider since the start of the instance, unsupported words aren't failures. Available only to admins.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "words"
+                    "admin"
                 ],
-                "summary": "Get user words.",
+                "summary": "Get error rates of translation providers.",
                 "responses": {
                     "200": {
-                        "description": "User words",
+                        "description": "Stats of providers",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ProvidersStats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/translations": {
+            "get": {
+                "description": "Gets shared translations of the word with their refresh state and number of cards, empty language matches any language. Available only to admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get stored translations of a word.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Word",
+                        "name": "word",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source language",
+                        "name": "src_lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target language",
+                        "name": "trgt_lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stored translations",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.StoredTranslations"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong query params",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.UserWords"
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
                     },
                     "401": {
@@ -41,6 +100,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal error",
                         "schema": {
@@ -50,6 +115,7 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "description": "Replaces data of the shared translation, the edit is seen by all users and isn't refreshed until the word is translated again with /admin/translations/refresh. Available only to admins.",
                 "consumes": [
                     "application/json"
                 ],
@@ -57,25 +123,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "words"
+                    "admin"
                 ],
-                "summary": "Updates learn interval for a given word.",
+                "summary": "Replaces a stored translation.",
                 "parameters": [
                     {
-                        "description": "Word, collection name with learn intervals",
-                        "name": "WordInfo",
+                        "description": "Word, its language pair and new data",
+                        "name": "Translation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_v1_rest.UpdateLearnIntervalRequest"
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.EditSharedTranslationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Interval was updated",
+                        "description": "Edited translation",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.StoredTranslation"
                         }
                     },
                     "400": {
@@ -90,6 +156,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal error",
                         "schema": {
@@ -98,7 +176,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
+                "description": "Deletes shared translation which isn't used by cards, the word is translated again when it's added next time. Available only to admins.",
                 "consumes": [
                     "application/json"
                 ],
@@ -106,23 +185,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "words"
+                    "admin"
                 ],
-                "summary": "Adds a word to a given collection.",
+                "summary": "Deletes a stored translation.",
                 "parameters": [
                     {
-                        "description": "Word, collection name with learn intervals",
-                        "name": "WordInfo",
+                        "description": "Word and its language pair",
+                        "name": "Translation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_v1_rest.AddWordRequest"
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.DeleteSharedTranslationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Word was added to collection",
+                    "200": {
+                        "description": "Translation was deleted",
                         "schema": {
                             "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
@@ -140,7 +219,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Word not supported",
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Translation is used by cards",
                         "schema": {
                             "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
@@ -152,8 +243,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/translations/refresh": {
+            "post": {
+                "description": "Replaces stored translation of the word regardless of its age, available only to admins.",
                 "consumes": [
                     "application/json"
                 ],
@@ -161,25 +255,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "words"
+                    "admin"
                 ],
-                "summary": "Deletes given word from a collection.",
+                "summary": "Translates a word again.",
                 "parameters": [
                     {
-                        "description": "Word and collection name",
-                        "name": "WordInfo",
+                        "description": "Word and its language pair",
+                        "name": "Word",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_v1_rest.DeleteWordRequest"
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.RefreshTranslationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Word was deleted",
+                        "description": "New translation",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordTrans"
                         }
                     },
                     "400": {
@@ -194,95 +288,2528 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin or word not supported",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Translator temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.UserWords": {
-            "type": "object",
-            "properties": {
-                "words": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordData"
+        },
+        "/admin/users": {
+            "get": {
+                "description": "Gets a page of users ordered by ID, next page is requested with next_cursor of the previous one. Available only to admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get users with numbers of their data.",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.UsersPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong query params",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
                     }
                 }
             }
         },
-        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordData": {
-            "type": "object",
-            "properties": {
-                "definitions_with_examples": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+        "/api-keys": {
+            "get": {
+                "description": "Gets API keys without their values, values are shown only on creation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Get API keys of the user.",
+                "responses": {
+                    "200": {
+                        "description": "User API keys",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKeys"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Requested with API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
                     }
-                },
-                "examples": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                }
+            },
+            "post": {
+                "description": "Key is sent as a bearer token like JWT. Value of the key is returned only once, it can't be shown again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Creates an API key.",
+                "parameters": [
+                    {
+                        "description": "Name, scopes and expiry of a key",
+                        "name": "Key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.CreateAPIKeyRequest"
+                        }
                     }
-                },
-                "last_repeat": {
-                    "type": "string"
-                },
-                "main_translation": {
-                    "type": "string"
-                },
-                "source_language": {
-                    "type": "string"
-                },
-                "target_language": {
-                    "type": "string"
-                },
-                "time_diff": {
-                    "$ref": "#/definitions/time.Duration"
-                },
-                "transltions": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created key with its value",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.NewAPIKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Requested with API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/api-keys/{id}": {
+            "delete": {
+                "description": "Requests with the key are rejected right after revoking.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Revokes an API key.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Key id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Key was revoked",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong params",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Requested with API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Key not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections": {
+            "get": {
+                "description": "Gets user collections with number of words in each, empty collections included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Get user collections.",
+                "responses": {
+                    "200": {
+                        "description": "User collections",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.CollectionsInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Creates an empty collection.",
+                "parameters": [
+                    {
+                        "description": "Name, description and languages of a collection",
+                        "name": "Collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.CreateCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created collection",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.CollectionInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Collection already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}": {
+            "put": {
+                "description": "Words of a collection and their review history are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Renames a collection and sets its description.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New name and description",
+                        "name": "Collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.UpdateCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Collection was updated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Collection already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an empty collection, a collection with words is deleted with its words only if force is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Deletes a collection.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete words of the collection too",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Collection was deleted",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong params",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Collection not empty",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "description": "User is created with default settings on the first request.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get the user and settings.",
+                "responses": {
+                    "200": {
+                        "description": "User with settings",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Data is deleted in the background, returned record of the request is kept after the deletion. Repeated requests return the pending one.\nA request after the deletion creates a new user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Deletes all data of the user.",
+                "responses": {
+                    "202": {
+                        "description": "Deletion is requested",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.AccountDeletion"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Requested with API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes only fields set in the request. Languages are used for words added to collections without a language pair, daily new words limit and time zone are used for review queues.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Updates settings of the user.",
+                "parameters": [
+                    {
+                        "description": "Changed settings",
+                        "name": "Settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.UpdateMeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User with updated settings",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format or unknown time zone",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/export": {
+            "get": {
+                "description": "Gets a JSON archive of the user with settings, collections, cards with learning progress, edited translations, review history and API keys.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get all data of the user.",
+                "responses": {
+                    "200": {
+                        "description": "User data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Export"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Requested with API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/due": {
+            "get": {
+                "description": "Gets words which are due for review ordered by overdue-ness with a capped number of new words per day mixed in.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get words to review.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name, all collections if empty",
+                        "name": "collection",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Max number of words",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Max number of new words a day, daily limit of user settings if empty",
+                        "name": "new_limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Words to review",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ReviewQueue"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong query params",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings": {
+            "get": {
+                "description": "Deprecated, use GET /me.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "settings"
+                ],
+                "summary": "Get user settings.",
+                "deprecated": true,
+                "responses": {
+                    "200": {
+                        "description": "User with settings",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Deprecated, use PATCH /me.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "settings"
+                ],
+                "summary": "Updates user settings.",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "Changed settings",
+                        "name": "Settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.UpdateMeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User with updated settings",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format or unknown time zone",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Gets retention per collection, reviews per day, cards due per day for the next 30 days and cards by maturity.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get learning statistics.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection name, all collections if empty",
+                        "name": "collection",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 3660,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 365,
+                        "description": "Number of days in retention and heatmap",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Learning statistics",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Stats"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong query params",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/words": {
+            "get": {
+                "description": "Gets a page of user words, next page is requested with next_cursor of the previous one.\nWith grouped=true gets all user words that put together in collections as entity.UserWords, other params are ignored then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Get user words.",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "All words grouped by collections",
+                        "name": "grouped",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection name",
+                        "name": "collection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of speech",
+                        "name": "pos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Substring of a word",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only due or only not due words",
+                        "name": "due",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "added",
+                            "last_repeat",
+                            "word"
+                        ],
+                        "type": "string",
+                        "default": "added",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User words",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordsPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong query params",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Deprecated, use POST /words/review so the server schedules the word. Ease factor, repetitions and FSRS memory state of the word are reset to match the interval.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Updates learn interval for a given word.",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "Word, collection name with learn intervals",
+                        "name": "WordInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.UpdateLearnIntervalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Interval was updated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Adds a word to a given collection.",
+                "parameters": [
+                    {
+                        "description": "Word, collection name with learn intervals",
+                        "name": "WordInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.AddWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Word was added to collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Word not supported, it can be added as a custom word",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Word already in collection or language pair doesn't match collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Translator temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Deletes given word from a collection.",
+                "parameters": [
+                    {
+                        "description": "Word and collection name",
+                        "name": "WordInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.DeleteWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Word was deleted",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/words/batch": {
+            "post": {
+                "description": "Adds distinct words of the list, missing translations are fetched concurrently within a time budget. Result of each word is returned, failed words can be sent again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Adds words to a given collection.",
+                "parameters": [
+                    {
+                        "description": "Words, collection name and learn intervals",
+                        "name": "Words",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.AddWordsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result of each word",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.AddWordsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Language pair doesn't match collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/words/copy": {
+            "post": {
+                "description": "Copies word with its learning progress and review history, target collection is created if it doesn't exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Copies word to another collection.",
+                "parameters": [
+                    {
+                        "description": "Word, its collection and target collection",
+                        "name": "Transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.TransferWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Word was copied",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Word not in collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Word already in target collection or language pair doesn't match",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/words/custom": {
+            "post": {
+                "description": "Adds a custom card, the word isn't translated so any word or phrase can be added.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Adds a word with a user translation to a given collection.",
+                "parameters": [
+                    {
+                        "description": "Word, collection name, learn intervals and translation",
+                        "name": "WordInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.AddCustomWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Word was added to collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Word already in collection or language pair doesn't match collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/words/move": {
+            "post": {
+                "description": "Moves word with its learning progress and review history, target collection is created if it doesn't exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Moves word to another collection.",
+                "parameters": [
+                    {
+                        "description": "Word, its collection and target collection",
+                        "name": "Transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.TransferWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Word was moved",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Word not in collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Word already in target collection or language pair doesn't match",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/words/review": {
+            "post": {
+                "description": "Grades an answer and schedules next repeat of a word on the server side.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Reviews a word.",
+                "parameters": [
+                    {
+                        "description": "Word, collection name and grade",
+                        "name": "Review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Next repeat of the word",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Word not in collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/words/translation": {
+            "put": {
+                "description": "Stores user edit of the translation, the edit applies to the word in all user collections. Omitted fields keep the current values.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Edits translation of a word.",
+                "parameters": [
+                    {
+                        "description": "Word, its collection and edited fields",
+                        "name": "Translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.EditTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation was edited",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Word not in collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/words/{word}/history": {
+            "get": {
+                "description": "Gets all past reviews of a word ordered by review time, of all collections if collection is empty. Reviews of deleted words are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "words"
+                ],
+                "summary": "Get review history of a word.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Word",
+                        "name": "word",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection name",
+                        "name": "collection",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews of the word",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong params",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Word not in collection",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_v1_rest.httpResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Key never expires when ExpiresAt is nil.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "First characters of the key to tell keys apart.",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Key without scopes grants access to everything but API keys and admin endpoints.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKeyScope": {
+            "type": "string",
+            "enum": [
+                "read-only",
+                "words:write"
+            ],
+            "x-enum-varnames": [
+                "ScopeReadOnly",
+                "ScopeWordsWrite"
+            ]
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKeys": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKey"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.AccountDeletion": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "description": "Nil until the data is deleted.",
+                    "type": "string"
+                },
+                "deleted_rows": {
+                    "description": "Number of rows deleted across all tables.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "requested_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.AddStatus": {
+            "type": "string",
+            "enum": [
+                "added",
+                "already_present",
+                "not_supported",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "AddStatusAdded",
+                "AddStatusAlreadyPresent",
+                "AddStatusNotSupported",
+                "AddStatusFailed"
+            ]
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Card": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "collection_name": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "number"
+                },
+                "ease_factor": {
+                    "type": "number"
+                },
+                "introduced_at": {
+                    "description": "Nil until the first review.",
+                    "type": "string"
+                },
+                "last_repeat": {
+                    "type": "string"
+                },
+                "repetitions": {
+                    "type": "integer"
+                },
+                "src_lang": {
+                    "type": "string"
+                },
+                "stability": {
+                    "type": "number"
+                },
+                "time_diff": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "translation": {
+                    "description": "Shared translation with user edits on top of it.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordTrans"
+                        }
+                    ]
+                },
+                "trgt_lang": {
+                    "type": "string"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.CollectionInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "src_lang": {
+                    "description": "Empty language means the default language of the deployment.",
+                    "type": "string"
+                },
+                "trgt_lang": {
+                    "type": "string"
+                },
+                "words": {
+                    "description": "Number of words in the collection.",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.CollectionRetention": {
+            "type": "object",
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "passed": {
+                    "type": "integer"
+                },
+                "retention": {
+                    "type": "number"
+                },
+                "reviews": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.CollectionsInfo": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.CollectionInfo"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.DayCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.DueWord": {
+            "type": "object",
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "definitions_with_examples": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+                        }
+                    }
+                },
+                "difficulty": {
+                    "type": "number"
+                },
+                "due": {
+                    "type": "string"
+                },
+                "ease_factor": {
+                    "type": "number"
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "last_repeat": {
+                    "type": "string"
+                },
+                "main_translation": {
+                    "type": "string"
+                },
+                "new": {
+                    "type": "boolean"
+                },
+                "provider": {
+                    "description": "Name of the provider which produced the translation.",
+                    "type": "string"
+                },
+                "provider_version": {
+                    "description": "Version of the provider adapter, translations of older versions are refreshed.",
+                    "type": "integer"
+                },
+                "repetitions": {
+                    "type": "integer"
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "stability": {
+                    "type": "number"
+                },
+                "target_language": {
+                    "type": "string"
+                },
+                "time_diff": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "transltions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Export": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKey"
+                    }
+                },
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Card"
+                    }
+                },
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.CollectionInfo"
+                    }
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "overrides": {
+                    "description": "User edits of shared translations and translations of custom cards.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.UserTrans"
+                    }
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ReviewLog"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.User"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Grade": {
+            "type": "string",
+            "enum": [
+                "again",
+                "hard",
+                "good",
+                "easy"
+            ],
+            "x-enum-varnames": [
+                "GradeAgain",
+                "GradeHard",
+                "GradeGood",
+                "GradeEasy"
+            ]
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ListedWord": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "collection_name": {
+                    "type": "string"
+                },
+                "definitions_with_examples": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+                        }
+                    }
+                },
+                "difficulty": {
+                    "type": "number"
+                },
+                "ease_factor": {
+                    "type": "number"
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "last_repeat": {
+                    "type": "string"
+                },
+                "main_translation": {
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Name of the provider which produced the translation.",
+                    "type": "string"
+                },
+                "provider_version": {
+                    "description": "Version of the provider adapter, translations of older versions are refreshed.",
+                    "type": "integer"
+                },
+                "repetitions": {
+                    "type": "integer"
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "stability": {
+                    "type": "number"
+                },
+                "target_language": {
+                    "type": "string"
+                },
+                "time_diff": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "transltions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Maturity": {
+            "type": "object",
+            "properties": {
+                "learning": {
+                    "description": "Failed on the last review or interval under a day.",
+                    "type": "integer"
+                },
+                "mature": {
+                    "description": "Interval of three weeks or longer.",
+                    "type": "integer"
+                },
+                "new": {
+                    "description": "Never reviewed.",
+                    "type": "integer"
+                },
+                "young": {
+                    "description": "Interval under three weeks.",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.NewAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Key never expires when ExpiresAt is nil.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "First characters of the key to tell keys apart.",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Key without scopes grants access to everything but API keys and admin endpoints.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ProviderStats": {
+            "type": "object",
+            "properties": {
+                "error_rate": {
+                    "type": "number"
+                },
+                "errors": {
+                    "description": "Failed requests, unsupported words aren't failures and canceled requests aren't counted.",
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_error_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ProvidersStats": {
+            "type": "object",
+            "properties": {
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ProviderStats"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ReviewLog": {
+            "type": "object",
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "elapsed": {
+                    "description": "Time since the previous review or since the word was added.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "grade": {
+                    "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Grade"
+                },
+                "next_time_diff": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "prev_time_diff": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "scheduler": {
+                    "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.SchedulerName"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ReviewQueue": {
+            "type": "object",
+            "properties": {
+                "words": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.DueWord"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.SchedulerName": {
+            "type": "string",
+            "enum": [
+                "sm2",
+                "fsrs"
+            ],
+            "x-enum-varnames": [
+                "SchedulerSM2",
+                "SchedulerFSRS"
+            ]
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Stats": {
+            "type": "object",
+            "properties": {
+                "forecast": {
+                    "description": "Cards due per day, overdue cards are due today.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.DayCount"
+                    }
+                },
+                "heatmap": {
+                    "description": "Reviews per day, days without reviews are omitted.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.DayCount"
+                    }
+                },
+                "maturity": {
+                    "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Maturity"
+                },
+                "retention": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.CollectionRetention"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.StoredTranslation": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "description": "Number of user cards with the translation.",
+                    "type": "integer"
+                },
+                "definitions_with_examples": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+                        }
+                    }
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "main_translation": {
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Name of the provider which produced the translation.",
+                    "type": "string"
+                },
+                "provider_version": {
+                    "description": "Version of the provider adapter, translations of older versions are refreshed.",
+                    "type": "integer"
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "target_language": {
+                    "type": "string"
+                },
+                "transltions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.StoredTranslations": {
+            "type": "object",
+            "properties": {
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.StoredTranslation"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_new_limit": {
+                    "description": "Max number of new words introduced per day.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "scheduler": {
+                    "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.SchedulerName"
+                },
+                "src_lang": {
+                    "description": "Language pair of words added to collections without a pair, defaults of the deployment are used when empty.",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA time zone, user's day starts at midnight in it.",
+                    "type": "string"
+                },
+                "trgt_lang": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.UserSummary": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "integer"
+                },
+                "collections": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_new_limit": {
+                    "description": "Max number of new words introduced per day.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reviews": {
+                    "type": "integer"
+                },
+                "scheduler": {
+                    "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.SchedulerName"
+                },
+                "src_lang": {
+                    "description": "Language pair of words added to collections without a pair, defaults of the deployment are used when empty.",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA time zone, user's day starts at midnight in it.",
+                    "type": "string"
+                },
+                "trgt_lang": {
+                    "type": "string"
+                },
+                "words": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.UserTrans": {
+            "type": "object",
+            "properties": {
+                "definitions_with_examples": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+                        }
+                    }
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "main_translation": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "target_language": {
+                    "type": "string"
+                },
+                "transltions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.UsersPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "Empty on the last page.",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Number of all users.",
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.UserSummary"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition": {
+            "type": "object",
+            "properties": {
+                "definition": {
+                    "type": "string"
+                },
+                "example": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordHistory": {
+            "type": "object",
+            "properties": {
+                "reviews": {
+                    "description": "Ordered by ReviewedAt.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ReviewLog"
+                    }
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordTrans": {
+            "type": "object",
+            "properties": {
+                "definitions_with_examples": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+                        }
+                    }
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "main_translation": {
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Name of the provider which produced the translation.",
+                    "type": "string"
+                },
+                "provider_version": {
+                    "description": "Version of the provider adapter, translations of older versions are refreshed.",
+                    "type": "integer"
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "target_language": {
+                    "type": "string"
+                },
+                "transltions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordsPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "Empty on the last page.",
+                    "type": "string"
+                },
+                "words": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.ListedWord"
+                    }
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.AddCustomWordRequest": {
+            "type": "object",
+            "required": [
+                "collection_name",
+                "last_repeat",
+                "main_translation",
+                "word"
+            ],
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "definitions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+                        }
+                    }
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "last_repeat": {
+                    "type": "string"
+                },
+                "main_translation": {
+                    "type": "string"
+                },
+                "src_lang": {
+                    "description": "Language pair of the word, collection pair or the default one is used when empty.",
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "time_diff": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "trgt_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
                 "word": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition": {
+        "internal_controller_http_v1_rest.AddWordRequest": {
             "type": "object",
+            "required": [
+                "collection_name",
+                "last_repeat",
+                "word"
+            ],
             "properties": {
-                "definition": {
+                "collection_name": {
                     "type": "string"
                 },
-                "example": {
+                "last_repeat": {
+                    "type": "string"
+                },
+                "src_lang": {
+                    "description": "Language pair of the word, collection pair or the default one is used when empty.",
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "time_diff": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "trgt_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "word": {
                     "type": "string"
                 }
             }
         },
-        "internal_controller_http_v1_rest.AddWordRequest": {
+        "internal_controller_http_v1_rest.AddWordResult": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Reason of the failure.",
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "added",
+                        "already_present",
+                        "not_supported",
+                        "failed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.AddStatus"
+                        }
+                    ]
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.AddWordsRequest": {
             "type": "object",
             "required": [
                 "collection_name",
                 "last_repeat",
-                "word"
+                "words"
             ],
             "properties": {
                 "collection_name": {
@@ -291,9 +2818,105 @@ const docTemplate = `{
                 "last_repeat": {
                     "type": "string"
                 },
+                "src_lang": {
+                    "description": "Language pair of the words, collection pair or the default one is used when empty.",
+                    "type": "string",
+                    "maxLength": 16
+                },
                 "time_diff": {
                     "$ref": "#/definitions/time.Duration"
                 },
+                "trgt_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "words": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.AddWordsResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_http_v1_rest.AddWordResult"
+                    }
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "Key never expires when it is empty.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "description": "Key without scopes has access to everything but API keys and admin endpoints.",
+                    "type": "array",
+                    "maxItems": 2,
+                    "items": {
+                        "enum": [
+                            "read-only",
+                            "words:write"
+                        ],
+                        "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.CreateCollectionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "src_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "trgt_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.DeleteSharedTranslationRequest": {
+            "type": "object",
+            "required": [
+                "src_lang",
+                "trgt_lang",
+                "word"
+            ],
+            "properties": {
+                "src_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "trgt_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
                 "word": {
                     "type": "string"
                 }
@@ -314,6 +2937,203 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controller_http_v1_rest.EditSharedTranslationRequest": {
+            "type": "object",
+            "required": [
+                "src_lang",
+                "trgt_lang",
+                "word"
+            ],
+            "properties": {
+                "definitions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+                        }
+                    }
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "main_translation": {
+                    "type": "string"
+                },
+                "src_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "trgt_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.EditTranslationRequest": {
+            "type": "object",
+            "required": [
+                "collection_name",
+                "word"
+            ],
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "definitions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.WordDefinition"
+                        }
+                    }
+                },
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "main_translation": {
+                    "description": "Empty fields keep the current values, at least one field must be edited.",
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.RefreshTranslationRequest": {
+            "type": "object",
+            "required": [
+                "src_lang",
+                "trgt_lang",
+                "word"
+            ],
+            "properties": {
+                "src_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "trgt_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.ReviewRequest": {
+            "type": "object",
+            "required": [
+                "collection_name",
+                "grade",
+                "word"
+            ],
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "grade": {
+                    "enum": [
+                        "again",
+                        "hard",
+                        "good",
+                        "easy"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.Grade"
+                        }
+                    ]
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "ease_factor": {
+                    "type": "number"
+                },
+                "last_repeat": {
+                    "type": "string"
+                },
+                "repetitions": {
+                    "type": "integer"
+                },
+                "time_diff": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.TransferWordRequest": {
+            "type": "object",
+            "required": [
+                "collection_name",
+                "target_collection_name",
+                "word"
+            ],
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "target_collection_name": {
+                    "type": "string"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_v1_rest.UpdateCollectionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_controller_http_v1_rest.UpdateLearnIntervalRequest": {
             "type": "object",
             "required": [
@@ -337,6 +3157,42 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controller_http_v1_rest.UpdateMeRequest": {
+            "type": "object",
+            "properties": {
+                "daily_new_limit": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "scheduler": {
+                    "enum": [
+                        "sm2",
+                        "fsrs"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Kin-dza-dzaa_flash_cards_api_internal_entity.SchedulerName"
+                        }
+                    ]
+                },
+                "src_lang": {
+                    "description": "Empty language clears the preference, defaults of the deployment are used then.",
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "timezone": {
+                    "description": "IANA time zone, e.g. Europe/Berlin.",
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
+                },
+                "trgt_lang": {
+                    "type": "string",
+                    "maxLength": 16
+                }
+            }
+        },
         "internal_controller_http_v1_rest.httpResponse": {
             "type": "object",
             "properties": {
@@ -384,6 +3240,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "REST API for word and collections of a user.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
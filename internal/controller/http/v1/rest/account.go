@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+type (
+	accountService interface {
+		Export(ctx context.Context, userID string) (entity.Export, error)
+		RequestDeletion(ctx context.Context, userID string) (entity.AccountDeletion, error)
+	}
+)
+
+const exportFileName = "flash_cards_export.json"
+
+// Export user data
+//
+//	@Summary		Get all data of the user.
+//	@Description	Gets a JSON archive of the user with settings, collections, cards with learning progress, edited translations, review history and API keys.
+//	@Tags			me
+//	@Produce		json
+//	@Success		200	{object}	entity.Export	"User data"
+//	@Failure		401	{object}	httpResponse	"Unauthorized"
+//	@Failure		403	{object}	httpResponse	"Requested with API key"
+//	@Failure		404	{object}	httpResponse	"User not found"
+//	@Failure		500	{object}	httpResponse	"Internal error"
+//	@Router			/me/export [get]
+func (h *WordHandler) exportMe(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	export, err := h.accountService.Export(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrUserNotFound.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - exportMe - h.accountService.Export: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - exportMe - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="`+exportFileName+`"`)
+	h.encode(
+		w,
+		http.StatusOK,
+		export,
+	)
+}
+
+// Delete user data
+//
+//	@Summary		Deletes all data of the user.
+//	@Description	Data is deleted in the background, returned record of the request is kept after the deletion. Repeated requests return the pending one.
+//	@Description	A request after the deletion creates a new user.
+//	@Tags			me
+//	@Produce		json
+//	@Success		202	{object}	entity.AccountDeletion	"Deletion is requested"
+//	@Failure		401	{object}	httpResponse			"Unauthorized"
+//	@Failure		403	{object}	httpResponse			"Requested with API key"
+//	@Failure		500	{object}	httpResponse			"Internal error"
+//	@Router			/me [delete]
+func (h *WordHandler) deleteMe(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	deletion, err := h.accountService.RequestDeletion(r.Context(), userID)
+	if err != nil {
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - deleteMe - h.accountService.RequestDeletion: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - deleteMe - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusAccepted,
+		deletion,
+	)
+}
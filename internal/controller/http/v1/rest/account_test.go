@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/exp/slog"
+)
+
+func setupAccountHandler(t *testing.T) (*WordHandler, *srvmock.AccountService) {
+	t.Helper()
+	srvMock := srvmock.NewAccountService(t)
+	h := &WordHandler{
+		accountService: srvMock,
+		logger:         logger.New(slog.LevelDebug),
+		v:              validator.New(),
+	}
+	return h, srvMock
+}
+
+func Test_exportMe(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		wantStatus int
+		wantFile   bool
+		setupMock  func(srvMock *srvmock.AccountService)
+	}{
+		{
+			name:       "Without user_id in ctx",
+			wantStatus: http.StatusUnauthorized,
+			setupMock:  func(srvMock *srvmock.AccountService) {},
+		},
+		{
+			name:       "Export",
+			userID:     "12345",
+			wantStatus: http.StatusOK,
+			wantFile:   true,
+			setupMock: func(srvMock *srvmock.AccountService) {
+				srvMock.On("Export", mock.Anything, "12345").Once().Return(entity.Export{User: entity.User{ID: "12345"}}, nil)
+			},
+		},
+		{
+			name:       "User not found",
+			userID:     "12345",
+			wantStatus: http.StatusNotFound,
+			setupMock: func(srvMock *srvmock.AccountService) {
+				srvMock.On("Export", mock.Anything, "12345").Once().Return(entity.Export{}, entity.ErrUserNotFound)
+			},
+		},
+		{
+			name:       "Internal error",
+			userID:     "12345",
+			wantStatus: http.StatusInternalServerError,
+			setupMock: func(srvMock *srvmock.AccountService) {
+				srvMock.On("Export", mock.Anything, "12345").Once().Return(entity.Export{}, errors.New("some internal error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAccountHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.exportMe(w, translationRequest(http.MethodGet, "/me/export", "", tt.userID))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Content-Disposition") != ""; got != tt.wantFile {
+				t.Fatalf("wanted attachment: %v got: %q", tt.wantFile, w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+func Test_deleteMe(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.AccountService)
+	}{
+		{
+			name:       "Without user_id in ctx",
+			wantStatus: http.StatusUnauthorized,
+			setupMock:  func(srvMock *srvmock.AccountService) {},
+		},
+		{
+			name:       "Deletion requested",
+			userID:     "12345",
+			wantStatus: http.StatusAccepted,
+			setupMock: func(srvMock *srvmock.AccountService) {
+				srvMock.On("RequestDeletion", mock.Anything, "12345").Once().Return(entity.AccountDeletion{ID: 1, UserID: "12345"}, nil)
+			},
+		},
+		{
+			name:       "Internal error",
+			userID:     "12345",
+			wantStatus: http.StatusInternalServerError,
+			setupMock: func(srvMock *srvmock.AccountService) {
+				srvMock.On("RequestDeletion", mock.Anything, "12345").Once().Return(entity.AccountDeletion{}, errors.New("some internal error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAccountHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.deleteMe(w, translationRequest(http.MethodDelete, "/me", "", tt.userID))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,507 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+type (
+	refreshService interface {
+		RefreshWord(ctx context.Context, word, srcLang, trgtLang string) (entity.WordTrans, error)
+	}
+
+	adminService interface {
+		Users(ctx context.Context, query entity.UsersQuery) (*entity.UsersPage, error)
+		Translations(ctx context.Context, word, srcLang, trgtLang string) ([]entity.StoredTranslation, error)
+		EditTranslation(ctx context.Context, wordTrans entity.WordTrans) (entity.StoredTranslation, error)
+		DeleteTranslation(ctx context.Context, word, srcLang, trgtLang string) error
+		ProviderStats(ctx context.Context) []entity.ProviderStats
+	}
+)
+
+const defaultUsersLimit = 50
+
+type UsersRequest struct {
+	Limit  int `validate:"min=1,max=500"`
+	Cursor string
+}
+
+type TranslationsRequest struct {
+	Word string `validate:"required"`
+	// Empty language matches any language.
+	SrcLang  string `validate:"max=16"`
+	TrgtLang string `validate:"max=16"`
+}
+
+// Stored data of the translation is replaced.
+type EditSharedTranslationRequest struct {
+	Word            string                                          `json:"word" validate:"required"`
+	SrcLang         string                                          `json:"src_lang" validate:"required,max=16"`
+	TrgtLang        string                                          `json:"trgt_lang" validate:"required,max=16"`
+	MainTranslation string                                          `json:"main_translation" validate:"required_without=Translations"`
+	Translations    map[entity.PartOfSpeech][]string                `json:"translations"`
+	Definitions     map[entity.PartOfSpeech][]entity.WordDefinition `json:"definitions"`
+	Examples        []string                                        `json:"examples"`
+}
+
+type DeleteSharedTranslationRequest struct {
+	Word     string `json:"word" validate:"required"`
+	SrcLang  string `json:"src_lang" validate:"required,max=16"`
+	TrgtLang string `json:"trgt_lang" validate:"required,max=16"`
+}
+
+type RefreshTranslationRequest struct {
+	Word     string `json:"word" validate:"required"`
+	SrcLang  string `json:"src_lang" validate:"required,max=16"`
+	TrgtLang string `json:"trgt_lang" validate:"required,max=16"`
+}
+
+// Refresh stored translation.
+//
+//	@Summary		Translates a word again.
+//	@Description	Replaces stored translation of the word regardless of its age, available only to admins.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			Word	body		RefreshTranslationRequest	true	"Word and its language pair"
+//	@Success		200		{object}	entity.WordTrans			"New translation"
+//	@Failure		400		{object}	httpResponse				"Wrong JSON format"
+//	@Failure		401		{object}	httpResponse				"Unauthorized"
+//	@Failure		403		{object}	httpResponse				"Not an admin or word not supported"
+//	@Failure		500		{object}	httpResponse				"Internal error"
+//	@Failure		503		{object}	httpResponse				"Translator temporarily unavailable"
+//	@Router			/admin/translations/refresh [post]
+func (h *WordHandler) refreshTranslation(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTranslationRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	wordTrans, err := h.refreshService.RefreshWord(r.Context(), req.Word, req.SrcLang, req.TrgtLang)
+	if err != nil {
+		if errors.Is(err, entity.ErrWordNotSupported) {
+			h.encode(
+				w,
+				http.StatusForbidden,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrWordNotSupported.Error(),
+				},
+			)
+			return
+		}
+		if errors.Is(err, entity.ErrTranslatorUnavailable) {
+			h.encode(
+				w,
+				http.StatusServiceUnavailable,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrTranslatorUnavailable.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - refreshTranslation - h.refreshService.RefreshWord: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - refreshTranslation - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		wordTrans,
+	)
+}
+
+// List users
+//
+//	@Summary		Get users with numbers of their data.
+//	@Description	Gets a page of users ordered by ID, next page is requested with next_cursor of the previous one. Available only to admins.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			limit	query		int					false	"Page size"	default(50)	minimum(1)	maximum(500)
+//	@Param			cursor	query		string				false	"Cursor of the next page"
+//	@Success		200		{object}	entity.UsersPage	"Users"
+//	@Failure		400		{object}	httpResponse		"Wrong query params"
+//	@Failure		401		{object}	httpResponse		"Unauthorized"
+//	@Failure		403		{object}	httpResponse		"Not an admin"
+//	@Failure		500		{object}	httpResponse		"Internal error"
+//	@Router			/admin/users [get]
+func (h *WordHandler) adminUsers(w http.ResponseWriter, r *http.Request) {
+	req := UsersRequest{
+		Limit:  defaultUsersLimit,
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+	var err error
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		req.Limit, err = strconv.Atoi(limit)
+	}
+	if err != nil || h.v.Struct(req) != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	page, err := h.adminService.Users(r.Context(), entity.UsersQuery{Limit: req.Limit, Cursor: req.Cursor})
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCursor) {
+			h.encode(
+				w,
+				http.StatusBadRequest,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrInvalidCursor.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - adminUsers - h.adminService.Users: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - adminUsers - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		page,
+	)
+}
+
+// List shared translations
+//
+//	@Summary		Get stored translations of a word.
+//	@Description	Gets shared translations of the word with their refresh state and number of cards, empty language matches any language. Available only to admins.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			word		query		string						true	"Word"
+//	@Param			src_lang	query		string						false	"Source language"
+//	@Param			trgt_lang	query		string						false	"Target language"
+//	@Success		200			{object}	entity.StoredTranslations	"Stored translations"
+//	@Failure		400			{object}	httpResponse				"Wrong query params"
+//	@Failure		401			{object}	httpResponse				"Unauthorized"
+//	@Failure		403			{object}	httpResponse				"Not an admin"
+//	@Failure		500			{object}	httpResponse				"Internal error"
+//	@Router			/admin/translations [get]
+func (h *WordHandler) adminTranslations(w http.ResponseWriter, r *http.Request) {
+	req := TranslationsRequest{
+		Word:     r.URL.Query().Get("word"),
+		SrcLang:  r.URL.Query().Get("src_lang"),
+		TrgtLang: r.URL.Query().Get("trgt_lang"),
+	}
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	translations, err := h.adminService.Translations(r.Context(), req.Word, req.SrcLang, req.TrgtLang)
+	if err != nil {
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - adminTranslations - h.adminService.Translations: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - adminTranslations - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		entity.StoredTranslations{Translations: translations},
+	)
+}
+
+// Edit shared translation
+//
+//	@Summary		Replaces a stored translation.
+//	@Description	Replaces data of the shared translation, the edit is seen by all users and isn't refreshed until the word is translated again with /admin/translations/refresh. Available only to admins.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			Translation	body		EditSharedTranslationRequest	true	"Word, its language pair and new data"
+//	@Success		200			{object}	entity.StoredTranslation		"Edited translation"
+//	@Failure		400			{object}	httpResponse					"Wrong JSON format"
+//	@Failure		401			{object}	httpResponse					"Unauthorized"
+//	@Failure		403			{object}	httpResponse					"Not an admin"
+//	@Failure		404			{object}	httpResponse					"Translation not found"
+//	@Failure		500			{object}	httpResponse					"Internal error"
+//	@Router			/admin/translations [put]
+func (h *WordHandler) adminEditTranslation(w http.ResponseWriter, r *http.Request) {
+	var req EditSharedTranslationRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	translation, err := h.adminService.EditTranslation(
+		r.Context(),
+		entity.WordTrans{
+			Word:            req.Word,
+			SrcLang:         req.SrcLang,
+			TrgtLang:        req.TrgtLang,
+			MainTranslation: req.MainTranslation,
+			Translations:    req.Translations,
+			Definitions:     req.Definitions,
+			Examples:        req.Examples,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrTranslationNotFound) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrTranslationNotFound.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - adminEditTranslation - h.adminService.EditTranslation: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - adminEditTranslation - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		translation,
+	)
+}
+
+// Delete shared translation
+//
+//	@Summary		Deletes a stored translation.
+//	@Description	Deletes shared translation which isn't used by cards, the word is translated again when it's added next time. Available only to admins.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			Translation	body		DeleteSharedTranslationRequest	true	"Word and its language pair"
+//	@Success		200			{object}	httpResponse					"Translation was deleted"
+//	@Failure		400			{object}	httpResponse					"Wrong JSON format"
+//	@Failure		401			{object}	httpResponse					"Unauthorized"
+//	@Failure		403			{object}	httpResponse					"Not an admin"
+//	@Failure		404			{object}	httpResponse					"Translation not found"
+//	@Failure		409			{object}	httpResponse					"Translation is used by cards"
+//	@Failure		500			{object}	httpResponse					"Internal error"
+//	@Router			/admin/translations [delete]
+func (h *WordHandler) adminDeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	var req DeleteSharedTranslationRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	err = h.adminService.DeleteTranslation(r.Context(), req.Word, req.SrcLang, req.TrgtLang)
+	if err != nil {
+		if errors.Is(err, entity.ErrTranslationNotFound) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrTranslationNotFound.Error(),
+				},
+			)
+			return
+		}
+		if errors.Is(err, entity.ErrTranslationInUse) {
+			h.encode(
+				w,
+				http.StatusConflict,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrTranslationInUse.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - adminDeleteTranslation - h.adminService.DeleteTranslation: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - adminDeleteTranslation - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		httpResponse{
+			Path:    r.URL.Path,
+			Message: http.StatusText(http.StatusOK),
+		})
+}
+
+// Provider error rates
+//
+//	@Summary		Get error rates of translation providers.
+//	@Description	Gets numbers of translate requests and failures of each provider since the start of the instance, unsupported words aren't failures. Available only to admins.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	entity.ProvidersStats	"Stats of providers"
+//	@Failure		401	{object}	httpResponse			"Unauthorized"
+//	@Failure		403	{object}	httpResponse			"Not an admin"
+//	@Router			/admin/providers [get]
+func (h *WordHandler) providerStats(w http.ResponseWriter, r *http.Request) {
+	h.encode(
+		w,
+		http.StatusOK,
+		entity.ProvidersStats{Providers: h.adminService.ProviderStats(r.Context())},
+	)
+}
@@ -0,0 +1,458 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/config"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/exp/slog"
+)
+
+func setupRefreshHandler(t *testing.T) (*WordHandler, *srvmock.RefreshService) {
+	t.Helper()
+	srvMock := srvmock.NewRefreshService(t)
+	h := &WordHandler{
+		refreshService: srvMock,
+		logger:         logger.New(slog.LevelDebug),
+		v:              validator.New(),
+	}
+	return h, srvMock
+}
+
+func Test_authorizer(t *testing.T) {
+	cfg := config.Admin{Users: []string{"admin"}, RoleClaim: "roles", Role: "admin"}
+	tests := []struct {
+		name     string
+		userID   string
+		claims   map[string]interface{}
+		cfg      config.Admin
+		wantRole string
+	}{
+		{
+			name:     "Admin of allowlist",
+			userID:   "admin",
+			cfg:      cfg,
+			wantRole: roleAdmin,
+		},
+		{
+			name:     "Admin role in list claim",
+			userID:   "12345",
+			claims:   map[string]interface{}{"roles": []interface{}{"editor", "admin"}},
+			cfg:      cfg,
+			wantRole: roleAdmin,
+		},
+		{
+			name:     "Admin role in string claim",
+			userID:   "12345",
+			claims:   map[string]interface{}{"roles": "editor admin"},
+			cfg:      cfg,
+			wantRole: roleAdmin,
+		},
+		{
+			name:   "Another role",
+			userID: "12345",
+			claims: map[string]interface{}{"roles": []interface{}{"editor"}},
+			cfg:    cfg,
+		},
+		{
+			name:   "Role claim disabled",
+			userID: "12345",
+			claims: map[string]interface{}{"roles": []interface{}{"admin"}},
+			cfg:    config.Admin{Users: []string{"admin"}, Role: "admin"},
+		},
+		{
+			name:   "Without user_id in ctx",
+			claims: map[string]interface{}{"roles": []interface{}{"admin"}},
+			cfg:    cfg,
+		},
+	}
+
+	for _, tt := range tests {
+		h, _ := setupRefreshHandler(t)
+
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRole string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRole = fromCtx(r.Context(), roleCtxKey)
+			})
+			r := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			ctx := inCtx(r.Context(), claimsCtxKey, tt.claims)
+			if tt.userID != "" {
+				ctx = inCtx(ctx, userIDCtxKey, tt.userID)
+			}
+			h.authorizer(tt.cfg)(next).ServeHTTP(httptest.NewRecorder(), r.WithContext(ctx))
+			if gotRole != tt.wantRole {
+				t.Fatalf("wanted role: %q got: %q", tt.wantRole, gotRole)
+			}
+		})
+	}
+}
+
+func Test_requireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		wantStatus int
+	}{
+		{
+			name:       "Admin",
+			role:       roleAdmin,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Without role",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		h, _ := setupRefreshHandler(t)
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		t.Run(tt.name, func(t *testing.T) {
+			w, r := httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/admin", nil)
+			if tt.role != "" {
+				r = r.WithContext(inCtx(r.Context(), roleCtxKey, tt.role))
+			}
+			h.requireRole(roleAdmin)(next).ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_refreshTranslation(t *testing.T) {
+	const validBody = `{"word":"gift","src_lang":"en","trgt_lang":"ru"}`
+	gift := entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "подарок", Provider: "google"}
+
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		want       entity.WordTrans
+		setupMock  func(srvMock *srvmock.RefreshService, args args)
+	}{
+		{
+			name: "Without language pair",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(`{"word":"gift"}`)),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/refresh",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.RefreshService, args args) {},
+		},
+		{
+			name: "Word not supported",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(validBody)),
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes: httpResponse{
+				Path:    "/refresh",
+				Message: entity.ErrWordNotSupported.Error(),
+			},
+			setupMock: func(srvMock *srvmock.RefreshService, args args) {
+				srvMock.On("RefreshWord", args.r.Context(), "gift", "en", "ru").Once().Return(entity.WordTrans{}, entity.ErrWordNotSupported)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(validBody)),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/refresh",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.RefreshService, args args) {
+				srvMock.On("RefreshWord", args.r.Context(), "gift", "en", "ru").Once().Return(entity.WordTrans{}, errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(validBody)),
+			},
+			wantStatus: http.StatusOK,
+			want:       gift,
+			setupMock: func(srvMock *srvmock.RefreshService, args args) {
+				srvMock.On("RefreshWord", args.r.Context(), "gift", "en", "ru").Once().Return(gift, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupRefreshHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.refreshTranslation(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			if tt.wantStatus == http.StatusOK {
+				var got entity.WordTrans
+				if err := json.Unmarshal(tt.args.w.Body.Bytes(), &got); err != nil {
+					t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+				}
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Fatalf("translation mismatch (-want +got):\n%s", diff)
+				}
+				return
+			}
+			var gotResponse httpResponse
+			if err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse); err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
+
+func setupAdminHandler(t *testing.T) (*WordHandler, *srvmock.AdminService) {
+	t.Helper()
+	srvMock := srvmock.NewAdminService(t)
+	h := &WordHandler{
+		adminService: srvMock,
+		logger:       logger.New(slog.LevelDebug),
+		v:            validator.New(),
+	}
+	return h, srvMock
+}
+
+func Test_adminUsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.AdminService)
+	}{
+		{
+			name:       "Default page",
+			target:     "/admin/users",
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("Users", mock.Anything, entity.UsersQuery{Limit: 50}).Once().Return(&entity.UsersPage{Total: 0}, nil)
+			},
+		},
+		{
+			name:       "Next page",
+			target:     "/admin/users?limit=10&cursor=MTIzNDU",
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("Users", mock.Anything, entity.UsersQuery{Limit: 10, Cursor: "MTIzNDU"}).Once().Return(&entity.UsersPage{Total: 11}, nil)
+			},
+		},
+		{
+			name:       "Wrong limit",
+			target:     "/admin/users?limit=0",
+			wantStatus: http.StatusBadRequest,
+			setupMock:  func(srvMock *srvmock.AdminService) {},
+		},
+		{
+			name:       "Invalid cursor",
+			target:     "/admin/users?cursor=!",
+			wantStatus: http.StatusBadRequest,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("Users", mock.Anything, entity.UsersQuery{Limit: 50, Cursor: "!"}).Once().Return(nil, entity.ErrInvalidCursor)
+			},
+		},
+		{
+			name:       "Internal error",
+			target:     "/admin/users",
+			wantStatus: http.StatusInternalServerError,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("Users", mock.Anything, entity.UsersQuery{Limit: 50}).Once().Return(nil, errors.New("some internal error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAdminHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.adminUsers(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_adminTranslations(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.AdminService)
+	}{
+		{
+			name:       "Translations of any pair",
+			target:     "/admin/translations?word=gift",
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("Translations", mock.Anything, "gift", "", "").Once().Return([]entity.StoredTranslation{}, nil)
+			},
+		},
+		{
+			name:       "Translations of a pair",
+			target:     "/admin/translations?word=gift&src_lang=en&trgt_lang=ru",
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("Translations", mock.Anything, "gift", "en", "ru").Once().Return([]entity.StoredTranslation{}, nil)
+			},
+		},
+		{
+			name:       "Without word",
+			target:     "/admin/translations",
+			wantStatus: http.StatusBadRequest,
+			setupMock:  func(srvMock *srvmock.AdminService) {},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAdminHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.adminTranslations(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_adminEditTranslation(t *testing.T) {
+	const validBody = `{"word":"gift","src_lang":"en","trgt_lang":"ru","main_translation":"подарок"}`
+	gift := entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "подарок"}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.AdminService)
+	}{
+		{
+			name:       "Without translation",
+			body:       `{"word":"gift","src_lang":"en","trgt_lang":"ru"}`,
+			wantStatus: http.StatusBadRequest,
+			setupMock:  func(srvMock *srvmock.AdminService) {},
+		},
+		{
+			name:       "Translation not found",
+			body:       validBody,
+			wantStatus: http.StatusNotFound,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("EditTranslation", mock.Anything, gift).Once().Return(entity.StoredTranslation{}, entity.ErrTranslationNotFound)
+			},
+		},
+		{
+			name:       "Translation edited",
+			body:       validBody,
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("EditTranslation", mock.Anything, gift).Once().Return(entity.StoredTranslation{WordTrans: gift}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAdminHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.adminEditTranslation(w, httptest.NewRequest(http.MethodPut, "/admin/translations", bytes.NewBufferString(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_adminDeleteTranslation(t *testing.T) {
+	const validBody = `{"word":"gift","src_lang":"en","trgt_lang":"ru"}`
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.AdminService)
+	}{
+		{
+			name:       "Without language pair",
+			body:       `{"word":"gift"}`,
+			wantStatus: http.StatusBadRequest,
+			setupMock:  func(srvMock *srvmock.AdminService) {},
+		},
+		{
+			name:       "Translation not found",
+			body:       validBody,
+			wantStatus: http.StatusNotFound,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("DeleteTranslation", mock.Anything, "gift", "en", "ru").Once().Return(entity.ErrTranslationNotFound)
+			},
+		},
+		{
+			name:       "Translation in use",
+			body:       validBody,
+			wantStatus: http.StatusConflict,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("DeleteTranslation", mock.Anything, "gift", "en", "ru").Once().Return(entity.ErrTranslationInUse)
+			},
+		},
+		{
+			name:       "Translation deleted",
+			body:       validBody,
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.AdminService) {
+				srvMock.On("DeleteTranslation", mock.Anything, "gift", "en", "ru").Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAdminHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.adminDeleteTranslation(w, httptest.NewRequest(http.MethodDelete, "/admin/translations", bytes.NewBufferString(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
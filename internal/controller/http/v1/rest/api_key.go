@@ -0,0 +1,291 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+type (
+	apiKeyService interface {
+		CreateAPIKey(ctx context.Context, key entity.APIKey) (entity.NewAPIKey, error)
+		APIKeys(ctx context.Context, userID string) (*entity.APIKeys, error)
+		RevokeAPIKey(ctx context.Context, userID string, id int64) error
+		AuthenticateAPIKey(ctx context.Context, plain string) (entity.APIKey, error)
+	}
+)
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	// Key without scopes has access to everything but API keys and admin endpoints.
+	Scopes []entity.APIKeyScope `json:"scopes" validate:"max=2,dive,oneof=read-only words:write" enums:"read-only,words:write"`
+	// Key never expires when it is empty.
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty,gt"`
+}
+
+// Paths which can't be requested with API keys, so a leaked key can't make new keys or export user data.
+var apiKeyDeniedPaths = []string{"/v1/api-keys", "/v1/admin", "/v1/me/export"}
+
+// Reports whether key scopes allow the request.
+func apiKeyAllows(key entity.APIKey, r *http.Request) bool {
+	for _, path := range apiKeyDeniedPaths {
+		if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path+"/") {
+			return false
+		}
+	}
+	// Account is deleted only with a token.
+	if r.Method == http.MethodDelete && strings.TrimSuffix(r.URL.Path, "/") == "/v1/me" {
+		return false
+	}
+	if len(key.Scopes) == 0 {
+		return true
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+
+	for _, scope := range key.Scopes {
+		if scope == entity.ScopeWordsWrite && (r.URL.Path == "/v1/words" || strings.HasPrefix(r.URL.Path, "/v1/words/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// List API keys
+//
+//	@Summary		Get API keys of the user.
+//	@Description	Gets API keys without their values, values are shown only on creation.
+//	@Tags			api-keys
+//	@Produce		json
+//	@Success		200	{object}	entity.APIKeys	"User API keys"
+//	@Failure		401	{object}	httpResponse	"Unauthorized"
+//	@Failure		403	{object}	httpResponse	"Requested with API key"
+//	@Failure		500	{object}	httpResponse	"Internal error"
+//	@Router			/api-keys [get]
+func (h *WordHandler) apiKeys(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	keys, err := h.apiKeyService.APIKeys(r.Context(), userID)
+	if err != nil {
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - apiKeys - h.apiKeyService.APIKeys: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - apiKeys - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		keys,
+	)
+}
+
+// Create API key
+//
+//	@Summary		Creates an API key.
+//	@Description	Key is sent as a bearer token like JWT. Value of the key is returned only once, it can't be shown again.
+//	@Tags			api-keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			Key	body		CreateAPIKeyRequest	true	"Name, scopes and expiry of a key"
+//	@Success		201	{object}	entity.NewAPIKey	"Created key with its value"
+//	@Failure		400	{object}	httpResponse		"Wrong JSON format"
+//	@Failure		401	{object}	httpResponse		"Unauthorized"
+//	@Failure		403	{object}	httpResponse		"Requested with API key"
+//	@Failure		500	{object}	httpResponse		"Internal error"
+//	@Router			/api-keys [post]
+func (h *WordHandler) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	key, err := h.apiKeyService.CreateAPIKey(
+		r.Context(),
+		entity.APIKey{
+			UserID:    userID,
+			Name:      req.Name,
+			Scopes:    req.Scopes,
+			ExpiresAt: req.ExpiresAt,
+		},
+	)
+	if err != nil {
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - createAPIKey - h.apiKeyService.CreateAPIKey: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - createAPIKey - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusCreated,
+		key,
+	)
+}
+
+// Revoke API key
+//
+//	@Summary		Revokes an API key.
+//	@Description	Requests with the key are rejected right after revoking.
+//	@Tags			api-keys
+//	@Produce		json
+//	@Param			id	path		int				true	"Key id"
+//	@Success		200	{object}	httpResponse	"Key was revoked"
+//	@Failure		400	{object}	httpResponse	"Wrong params"
+//	@Failure		401	{object}	httpResponse	"Unauthorized"
+//	@Failure		403	{object}	httpResponse	"Requested with API key"
+//	@Failure		404	{object}	httpResponse	"Key not found"
+//	@Failure		500	{object}	httpResponse	"Internal error"
+//	@Router			/api-keys/{id} [delete]
+func (h *WordHandler) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	err = h.apiKeyService.RevokeAPIKey(r.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrAPIKeyNotFound) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrAPIKeyNotFound.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - revokeAPIKey - h.apiKeyService.RevokeAPIKey: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - revokeAPIKey - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		httpResponse{
+			Path:    r.URL.Path,
+			Message: http.StatusText(http.StatusOK),
+		})
+}
@@ -0,0 +1,282 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/exp/slog"
+)
+
+func setupAPIKeyHandler(t *testing.T) (*WordHandler, *srvmock.ApiKeyService) {
+	t.Helper()
+	srvMock := srvmock.NewApiKeyService(t)
+	h := &WordHandler{
+		apiKeyService: srvMock,
+		logger:        logger.New(slog.LevelDebug),
+		v:             validator.New(),
+	}
+	return h, srvMock
+}
+
+func Test_apiKeyAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []entity.APIKeyScope
+		method string
+		path   string
+		want   bool
+	}{
+		{
+			name:   "Key without scopes",
+			method: http.MethodDelete,
+			path:   "/v1/collections/1",
+			want:   true,
+		},
+		{
+			name:   "Key without scopes makes keys",
+			method: http.MethodPost,
+			path:   "/v1/api-keys",
+		},
+		{
+			name:   "Key lists keys",
+			scopes: []entity.APIKeyScope{entity.ScopeReadOnly},
+			method: http.MethodGet,
+			path:   "/v1/api-keys/",
+		},
+		{
+			name:   "Key requests admin endpoint",
+			method: http.MethodPost,
+			path:   "/v1/admin/translations/refresh",
+		},
+		{
+			name:   "Key exports user data",
+			scopes: []entity.APIKeyScope{entity.ScopeReadOnly},
+			method: http.MethodGet,
+			path:   "/v1/me/export",
+		},
+		{
+			name:   "Key without scopes deletes account",
+			method: http.MethodDelete,
+			path:   "/v1/me/",
+		},
+		{
+			name:   "Key without scopes reads user",
+			method: http.MethodGet,
+			path:   "/v1/me",
+			want:   true,
+		},
+		{
+			name:   "Read-only key reads",
+			scopes: []entity.APIKeyScope{entity.ScopeReadOnly},
+			method: http.MethodGet,
+			path:   "/v1/words",
+			want:   true,
+		},
+		{
+			name:   "Read-only key adds words",
+			scopes: []entity.APIKeyScope{entity.ScopeReadOnly},
+			method: http.MethodPost,
+			path:   "/v1/words/batch",
+		},
+		{
+			name:   "Words key adds words",
+			scopes: []entity.APIKeyScope{entity.ScopeWordsWrite},
+			method: http.MethodPost,
+			path:   "/v1/words/batch",
+			want:   true,
+		},
+		{
+			name:   "Words key deletes collection",
+			scopes: []entity.APIKeyScope{entity.ScopeWordsWrite},
+			method: http.MethodDelete,
+			path:   "/v1/collections/1",
+		},
+		{
+			name:   "Words key requests path with words prefix",
+			scopes: []entity.APIKeyScope{entity.ScopeWordsWrite},
+			method: http.MethodPut,
+			path:   "/v1/wordsettings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if got := apiKeyAllows(entity.APIKey{Scopes: tt.scopes}, r); got != tt.want {
+				t.Fatalf("wanted: %v got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_createAPIKey(t *testing.T) {
+	expiresAt := time.Date(2100, 1, 2, 15, 4, 5, 0, time.UTC)
+	key := entity.APIKey{
+		UserID:    "12345",
+		Name:      "import",
+		Scopes:    []entity.APIKeyScope{entity.ScopeWordsWrite},
+		ExpiresAt: &expiresAt,
+	}
+	const validBody = `{"name":"import","scopes":["words:write"],"expires_at":"2100-01-02T15:04:05Z"}`
+
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    interface{}
+		setupMock  func(srvMock *srvmock.ApiKeyService)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/api-keys", validBody, ""),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/api-keys",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {},
+		},
+		{
+			name: "Unknown scope",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/api-keys", `{"name":"import","scopes":["admin"]}`, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/api-keys",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {},
+		},
+		{
+			name: "Expiry in the past",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/api-keys", `{"name":"import","expires_at":"2000-01-02T15:04:05Z"}`, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/api-keys",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/api-keys", validBody, "12345"),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/api-keys",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {
+				srvMock.On("CreateAPIKey", mock.Anything, key).Once().Return(entity.NewAPIKey{}, errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Key created",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/api-keys", validBody, "12345"),
+			},
+			wantStatus: http.StatusCreated,
+			wantRes:    entity.NewAPIKey{APIKey: entity.APIKey{ID: 1, Name: "import", Prefix: "fc_abcde"}, Key: "fc_abcdefgh"},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {
+				srvMock.On("CreateAPIKey", mock.Anything, key).Once().
+					Return(entity.NewAPIKey{APIKey: entity.APIKey{ID: 1, Name: "import", Prefix: "fc_abcde"}, Key: "fc_abcdefgh"}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAPIKeyHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.createAPIKey(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			want, err := json.Marshal(tt.wantRes)
+			if err != nil {
+				t.Fatalf("%v - json.Marshal: %v", tt.name, err)
+			}
+			var gotResponse, wantResponse interface{}
+			if err := json.Unmarshal(want, &wantResponse); err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse); err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(wantResponse, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", wantResponse, gotResponse, diff)
+			}
+		})
+	}
+}
+
+func Test_revokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.ApiKeyService)
+	}{
+		{
+			name:       "Wrong id",
+			id:         "abc",
+			wantStatus: http.StatusBadRequest,
+			setupMock:  func(srvMock *srvmock.ApiKeyService) {},
+		},
+		{
+			name:       "Key not found",
+			id:         "1",
+			wantStatus: http.StatusNotFound,
+			setupMock: func(srvMock *srvmock.ApiKeyService) {
+				srvMock.On("RevokeAPIKey", mock.Anything, "12345", int64(1)).Once().Return(entity.ErrAPIKeyNotFound)
+			},
+		},
+		{
+			name:       "Key revoked",
+			id:         "1",
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.ApiKeyService) {
+				srvMock.On("RevokeAPIKey", mock.Anything, "12345", int64(1)).Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAPIKeyHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.revokeAPIKey(w, collectionRequest(http.MethodDelete, "/api-keys/"+tt.id, tt.id, nil, "12345"))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+type AddWordsRequest struct {
+	Words          []string      `json:"words" validate:"required,min=1,max=500,dive,required"`
+	CollectionName string        `json:"collection_name" validate:"required"`
+	LastRepeat     time.Time     `json:"last_repeat" validate:"required"`
+	TimeDiff       time.Duration `json:"time_diff"`
+	// Language pair of the words, collection pair or the default one is used when empty.
+	SrcLang  string `json:"src_lang" validate:"omitempty,max=16"`
+	TrgtLang string `json:"trgt_lang" validate:"omitempty,max=16"`
+}
+
+type AddWordResult struct {
+	Word   string           `json:"word"`
+	Status entity.AddStatus `json:"status" enums:"added,already_present,not_supported,failed"`
+	// Reason of the failure.
+	Reason string `json:"reason,omitempty"`
+}
+
+type AddWordsResponse struct {
+	Results []AddWordResult `json:"results"`
+}
+
+// Add words to collection.
+//
+//	@Summary		Adds words to a given collection.
+//	@Description	Adds distinct words of the list, missing translations are fetched concurrently within a time budget. Result of each word is returned, failed words can be sent again.
+//	@Tags			words
+//	@Accept			json
+//	@Produce		json
+//	@Param			Words	body		AddWordsRequest		true	"Words, collection name and learn intervals"
+//	@Success		200		{object}	AddWordsResponse	"Result of each word"
+//	@Failure		400		{object}	httpResponse		"Wrong JSON format"
+//	@Failure		401		{object}	httpResponse		"Unauthorized"
+//	@Failure		409		{object}	httpResponse		"Language pair doesn't match collection"
+//	@Failure		500		{object}	httpResponse		"Internal error"
+//	@Router			/words/batch [post]
+func (h *WordHandler) addWords(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	var req AddWordsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	results, err := h.wordService.AddWords(
+		r.Context(),
+		entity.Collection{
+			UserID:     userID,
+			Name:       req.CollectionName,
+			SrcLang:    req.SrcLang,
+			TrgtLang:   req.TrgtLang,
+			LastRepeat: req.LastRepeat,
+			TimeDiff:   req.TimeDiff,
+		},
+		req.Words,
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrLanguagePairMismatch) {
+			h.encode(
+				w,
+				http.StatusConflict,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrLanguagePairMismatch.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - addWords - h.service.AddWords: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - addWords - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	response := AddWordsResponse{Results: make([]AddWordResult, 0, len(results))}
+	for _, result := range results {
+		response.Results = append(response.Results, AddWordResult{
+			Word:   result.Word,
+			Status: result.Status,
+			Reason: h.addFailureReason(r, result),
+		})
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		response,
+	)
+}
+
+// Domain errors are shown to the user, internal ones are logged.
+func (h *WordHandler) addFailureReason(r *http.Request, result entity.AddResult) string {
+	switch {
+	case result.Err == nil:
+		return ""
+	case errors.Is(result.Err, entity.ErrBudgetExceeded):
+		return entity.ErrBudgetExceeded.Error()
+	case errors.Is(result.Err, entity.ErrTranslatorUnavailable):
+		return entity.ErrTranslatorUnavailable.Error()
+	}
+
+	h.logger.ErrorCtx(
+		r.Context(),
+		"Internal error",
+		slog.String("word", result.Word),
+		slog.String("error", fmt.Errorf("wordHandler - addWords - h.service.AddWords: %w", result.Err).Error()),
+	)
+	return http.StatusText(http.StatusInternalServerError)
+}
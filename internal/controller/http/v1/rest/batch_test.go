@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_addWords(t *testing.T) {
+	const validBody = `{"words":["lead","run","qwzx","gift","break a leg"],"collection_name":"coll","last_repeat":"2023-01-02T15:04:05Z"}`
+	coll := entity.Collection{
+		UserID:     "12345",
+		Name:       "coll",
+		LastRepeat: time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	words := []string{"lead", "run", "qwzx", "gift", "break a leg"}
+
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    interface{}
+		setupMock  func(srvMock *srvmock.WordService, args args)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/batch", validBody, ""),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/batch",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Empty word",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/batch", `{"words":["lead",""],"collection_name":"coll","last_repeat":"2023-01-02T15:04:05Z"}`, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/batch",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Language pair mismatch",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/batch", validBody, "12345"),
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: httpResponse{
+				Path:    "/batch",
+				Message: entity.ErrLanguagePairMismatch.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddWords", args.r.Context(), coll, words).Once().Return(nil, entity.ErrLanguagePairMismatch)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/batch", validBody, "12345"),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/batch",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddWords", args.r.Context(), coll, words).Once().Return(nil, errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Result of each word",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/batch", validBody, "12345"),
+			},
+			wantStatus: http.StatusOK,
+			wantRes: AddWordsResponse{Results: []AddWordResult{
+				{Word: "lead", Status: entity.AddStatusAdded},
+				{Word: "run", Status: entity.AddStatusAlreadyPresent},
+				{Word: "qwzx", Status: entity.AddStatusNotSupported},
+				{Word: "gift", Status: entity.AddStatusFailed, Reason: entity.ErrBudgetExceeded.Error()},
+				{Word: "break a leg", Status: entity.AddStatusFailed, Reason: http.StatusText(http.StatusInternalServerError)},
+			}},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddWords", args.r.Context(), coll, words).Once().Return([]entity.AddResult{
+					{Word: "lead", Status: entity.AddStatusAdded},
+					{Word: "run", Status: entity.AddStatusAlreadyPresent},
+					{Word: "qwzx", Status: entity.AddStatusNotSupported},
+					{Word: "gift", Status: entity.AddStatusFailed, Err: entity.ErrBudgetExceeded},
+					{Word: "break a leg", Status: entity.AddStatusFailed, Err: errors.New("some internal error")},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupWordHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.addWords(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			want, err := json.Marshal(tt.wantRes)
+			if err != nil {
+				t.Fatalf("%v - json.Marshal: %v", tt.name, err)
+			}
+			var gotResponse, wantResponse interface{}
+			if err := json.Unmarshal(want, &wantResponse); err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse); err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(wantResponse, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", wantResponse, gotResponse, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,433 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+type (
+	collectionService interface {
+		CreateCollection(ctx context.Context, collection entity.CollectionInfo) (entity.CollectionInfo, error)
+		Collections(ctx context.Context, userID string) (*entity.CollectionsInfo, error)
+		UpdateCollection(ctx context.Context, collection entity.CollectionInfo) error
+		DeleteCollection(ctx context.Context, collection entity.CollectionInfo, force bool) error
+	}
+)
+
+type CreateCollectionRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+	SrcLang     string `json:"src_lang" validate:"omitempty,max=16"`
+	TrgtLang    string `json:"trgt_lang" validate:"omitempty,max=16"`
+}
+
+type UpdateCollectionRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+}
+
+// Parses collection id from the URL path.
+func (h *WordHandler) collectionID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+}
+
+// Mapping of collection errors to status codes, other errors are internal.
+func (h *WordHandler) collectionErrStatus(err error) (int, error) {
+	switch {
+	case errors.Is(err, entity.ErrCollectionNotFound):
+		return http.StatusNotFound, entity.ErrCollectionNotFound
+	case errors.Is(err, entity.ErrCollectionExists):
+		return http.StatusConflict, entity.ErrCollectionExists
+	case errors.Is(err, entity.ErrCollectionNotEmpty):
+		return http.StatusConflict, entity.ErrCollectionNotEmpty
+	}
+	return http.StatusInternalServerError, nil
+}
+
+// List user collections
+//
+//	@Summary		Get user collections.
+//	@Description	Gets user collections with number of words in each, empty collections included.
+//	@Tags			collections
+//	@Produce		json
+//	@Success		200	{object}	entity.CollectionsInfo	"User collections"
+//	@Failure		401	{object}	httpResponse			"Unauthorized"
+//	@Failure		500	{object}	httpResponse			"Internal error"
+//	@Router			/collections [get]
+func (h *WordHandler) collections(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	collections, err := h.collectionService.Collections(r.Context(), userID)
+	if err != nil {
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - collections - h.collectionService.Collections: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - collections - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		collections,
+	)
+}
+
+// Create collection
+//
+//	@Summary	Creates an empty collection.
+//	@Tags		collections
+//	@Accept		json
+//	@Produce	json
+//	@Param		Collection	body		CreateCollectionRequest	true	"Name, description and languages of a collection"
+//	@Success	201			{object}	entity.CollectionInfo	"Created collection"
+//	@Failure	400			{object}	httpResponse			"Wrong JSON format"
+//	@Failure	401			{object}	httpResponse			"Unauthorized"
+//	@Failure	409			{object}	httpResponse			"Collection already exists"
+//	@Failure	500			{object}	httpResponse			"Internal error"
+//	@Router		/collections [post]
+func (h *WordHandler) createCollection(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	var req CreateCollectionRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	collection, err := h.collectionService.CreateCollection(
+		r.Context(),
+		entity.CollectionInfo{
+			UserID:      userID,
+			Name:        req.Name,
+			Description: req.Description,
+			SrcLang:     req.SrcLang,
+			TrgtLang:    req.TrgtLang,
+		},
+	)
+	if err != nil {
+		if status, domainErr := h.collectionErrStatus(err); domainErr != nil {
+			h.encode(
+				w,
+				status,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: domainErr.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - createCollection - h.collectionService.CreateCollection: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - createCollection - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusCreated,
+		collection,
+	)
+}
+
+// Rename collection
+//
+//	@Summary		Renames a collection and sets its description.
+//	@Description	Words of a collection and their review history are kept.
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int						true	"Collection id"
+//	@Param			Collection	body		UpdateCollectionRequest	true	"New name and description"
+//	@Success		200			{object}	httpResponse			"Collection was updated"
+//	@Failure		400			{object}	httpResponse			"Wrong JSON format"
+//	@Failure		401			{object}	httpResponse			"Unauthorized"
+//	@Failure		404			{object}	httpResponse			"Collection not found"
+//	@Failure		409			{object}	httpResponse			"Collection already exists"
+//	@Failure		500			{object}	httpResponse			"Internal error"
+//	@Router			/collections/{id} [put]
+func (h *WordHandler) updateCollection(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	id, err := h.collectionID(r)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	var req UpdateCollectionRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	err = h.collectionService.UpdateCollection(
+		r.Context(),
+		entity.CollectionInfo{
+			ID:          id,
+			UserID:      userID,
+			Name:        req.Name,
+			Description: req.Description,
+		},
+	)
+	if err != nil {
+		if status, domainErr := h.collectionErrStatus(err); domainErr != nil {
+			h.encode(
+				w,
+				status,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: domainErr.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - updateCollection - h.collectionService.UpdateCollection: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - updateCollection - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		httpResponse{
+			Path:    r.URL.Path,
+			Message: http.StatusText(http.StatusOK),
+		})
+}
+
+// Delete collection
+//
+//	@Summary		Deletes a collection.
+//	@Description	Deletes an empty collection, a collection with words is deleted with its words only if force is set.
+//	@Tags			collections
+//	@Produce		json
+//	@Param			id		path		int				true	"Collection id"
+//	@Param			force	query		bool			false	"Delete words of the collection too"
+//	@Success		200		{object}	httpResponse	"Collection was deleted"
+//	@Failure		400		{object}	httpResponse	"Wrong params"
+//	@Failure		401		{object}	httpResponse	"Unauthorized"
+//	@Failure		404		{object}	httpResponse	"Collection not found"
+//	@Failure		409		{object}	httpResponse	"Collection not empty"
+//	@Failure		500		{object}	httpResponse	"Internal error"
+//	@Router			/collections/{id} [delete]
+func (h *WordHandler) deleteCollection(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	id, err := h.collectionID(r)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	var force bool
+	if f := r.URL.Query().Get("force"); f != "" {
+		if force, err = strconv.ParseBool(f); err != nil {
+			h.encode(
+				w,
+				http.StatusBadRequest,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: http.StatusText(http.StatusBadRequest),
+				})
+			return
+		}
+	}
+
+	err = h.collectionService.DeleteCollection(
+		r.Context(),
+		entity.CollectionInfo{
+			ID:     id,
+			UserID: userID,
+		},
+		force,
+	)
+	if err != nil {
+		if status, domainErr := h.collectionErrStatus(err); domainErr != nil {
+			h.encode(
+				w,
+				status,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: domainErr.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - deleteCollection - h.collectionService.DeleteCollection: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - deleteCollection - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		httpResponse{
+			Path:    r.URL.Path,
+			Message: http.StatusText(http.StatusOK),
+		})
+}
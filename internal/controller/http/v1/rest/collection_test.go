@@ -0,0 +1,325 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slog"
+)
+
+func setupCollectionHandler(t *testing.T) (*WordHandler, *srvmock.CollectionService) {
+	t.Helper()
+	srvMock := srvmock.NewCollectionService(t)
+	h := &WordHandler{
+		collectionService: srvMock,
+		logger:            logger.New(slog.LevelDebug),
+		v:                 validator.New(),
+	}
+	return h, srvMock
+}
+
+// Request to a collection, id is set as chi URL param.
+func collectionRequest(method, target, id string, body []byte, userID string) *http.Request {
+	r := httptest.NewRequest(method, target, bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+	if userID != "" {
+		ctx = inCtx(ctx, userIDCtxKey, userID)
+	}
+	return r.WithContext(ctx)
+}
+
+func Test_createCollection(t *testing.T) {
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.CollectionService, args args)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPost, "/collections", "", []byte(`{"name":"coll"}`), ""),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/collections",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {},
+		},
+		{
+			name: "Wrong JSON format",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPost, "/collections", "", []byte(`{"name":`), "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/collections",
+				Message: wrongJSONFormat,
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {},
+		},
+		{
+			name: "Without name",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPost, "/collections", "", []byte(`{"description":"desc"}`), "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/collections",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {},
+		},
+		{
+			name: "Collection exists",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPost, "/collections", "", []byte(`{"name":"coll"}`), "12345"),
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: httpResponse{
+				Path:    "/collections",
+				Message: entity.ErrCollectionExists.Error(),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {
+				srvMock.On("CreateCollection", args.r.Context(), entity.CollectionInfo{UserID: "12345", Name: "coll"}).
+					Once().Return(entity.CollectionInfo{}, entity.ErrCollectionExists)
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPost, "/collections", "",
+					[]byte(`{"name":"coll","description":"desc","src_lang":"de","trgt_lang":"en"}`), "12345"),
+			},
+			wantStatus: http.StatusCreated,
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {
+				coll := entity.CollectionInfo{UserID: "12345", Name: "coll", Description: "desc", SrcLang: "de", TrgtLang: "en"}
+				srvMock.On("CreateCollection", args.r.Context(), coll).Once().Return(coll, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupCollectionHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.createCollection(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			if tt.wantStatus == http.StatusCreated {
+				return
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
+
+func Test_updateCollection(t *testing.T) {
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.CollectionService, args args)
+	}{
+		{
+			name: "Wrong id",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPut, "/collections/one", "one", []byte(`{"name":"coll"}`), "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/collections/one",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {},
+		},
+		{
+			name: "Collection not found",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPut, "/collections/1", "1", []byte(`{"name":"coll"}`), "12345"),
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes: httpResponse{
+				Path:    "/collections/1",
+				Message: entity.ErrCollectionNotFound.Error(),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {
+				srvMock.On("UpdateCollection", args.r.Context(), entity.CollectionInfo{ID: 1, UserID: "12345", Name: "coll"}).
+					Once().Return(entity.ErrCollectionNotFound)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPut, "/collections/1", "1", []byte(`{"name":"coll"}`), "12345"),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/collections/1",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {
+				srvMock.On("UpdateCollection", args.r.Context(), entity.CollectionInfo{ID: 1, UserID: "12345", Name: "coll"}).
+					Once().Return(errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodPut, "/collections/1", "1", []byte(`{"name":"coll","description":"desc"}`), "12345"),
+			},
+			wantStatus: http.StatusOK,
+			wantRes: httpResponse{
+				Path:    "/collections/1",
+				Message: http.StatusText(http.StatusOK),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {
+				srvMock.On("UpdateCollection", args.r.Context(),
+					entity.CollectionInfo{ID: 1, UserID: "12345", Name: "coll", Description: "desc"}).
+					Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupCollectionHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.updateCollection(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
+
+func Test_deleteCollection(t *testing.T) {
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.CollectionService, args args)
+	}{
+		{
+			name: "Wrong force param",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodDelete, "/collections/1?force=maybe", "1", nil, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/collections/1",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {},
+		},
+		{
+			name: "Collection not empty",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodDelete, "/collections/1", "1", nil, "12345"),
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: httpResponse{
+				Path:    "/collections/1",
+				Message: entity.ErrCollectionNotEmpty.Error(),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {
+				srvMock.On("DeleteCollection", args.r.Context(), entity.CollectionInfo{ID: 1, UserID: "12345"}, false).
+					Once().Return(entity.ErrCollectionNotEmpty)
+			},
+		},
+		{
+			name: "Force delete",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: collectionRequest(http.MethodDelete, "/collections/1?force=true", "1", nil, "12345"),
+			},
+			wantStatus: http.StatusOK,
+			wantRes: httpResponse{
+				Path:    "/collections/1",
+				Message: http.StatusText(http.StatusOK),
+			},
+			setupMock: func(srvMock *srvmock.CollectionService, args args) {
+				srvMock.On("DeleteCollection", args.r.Context(), entity.CollectionInfo{ID: 1, UserID: "12345"}, true).
+					Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupCollectionHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.deleteCollection(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
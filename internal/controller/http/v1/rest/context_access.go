@@ -23,3 +23,11 @@ func fromCtx(ctx context.Context, ctxKey string) string {
 	}
 	return val
 }
+
+func claimsFromCtx(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	claims, _ := ctx.Value(key(claimsCtxKey)).(map[string]interface{})
+	return claims
+}
@@ -0,0 +1,223 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+// Only set fields are changed.
+type UpdateMeRequest struct {
+	Scheduler *entity.SchedulerName `json:"scheduler" validate:"omitempty,oneof=sm2 fsrs" enums:"sm2,fsrs"`
+	// Empty language clears the preference, defaults of the deployment are used then.
+	SrcLang       *string `json:"src_lang" validate:"omitempty,max=16"`
+	TrgtLang      *string `json:"trgt_lang" validate:"omitempty,max=16"`
+	DailyNewLimit *int    `json:"daily_new_limit" validate:"omitempty,min=0,max=1000"`
+	// IANA time zone, e.g. Europe/Berlin.
+	Timezone *string `json:"timezone" validate:"omitempty,min=1,max=64"`
+}
+
+// Creates user on the first authenticated request.
+func (h *WordHandler) registerUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := fromCtx(r.Context(), userIDCtxKey)
+		if userID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := h.settingsService.RegisterUser(r.Context(), userID); err != nil {
+			h.logger.ErrorCtx(
+				r.Context(),
+				"Internal error",
+				slog.String("error", fmt.Errorf("wordHandler - registerUser - h.settingsService.RegisterUser: %w", err).Error()),
+			)
+			h.encode(
+				w,
+				http.StatusInternalServerError,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: http.StatusText(http.StatusInternalServerError),
+				},
+			)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Get user profile
+//
+//	@Summary		Get the user and settings.
+//	@Description	User is created with default settings on the first request.
+//	@Tags			me
+//	@Produce		json
+//	@Success		200	{object}	entity.User		"User with settings"
+//	@Failure		401	{object}	httpResponse	"Unauthorized"
+//	@Failure		404	{object}	httpResponse	"User not found"
+//	@Failure		500	{object}	httpResponse	"Internal error"
+//	@Router			/me [get]
+func (h *WordHandler) me(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	user, err := h.settingsService.User(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrUserNotFound.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - me - h.settingsService.User: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - me - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		user,
+	)
+}
+
+// Update user settings
+//
+//	@Summary		Updates settings of the user.
+//	@Description	Changes only fields set in the request. Languages are used for words added to collections without a language pair, daily new words limit and time zone are used for review queues.
+//	@Tags			me
+//	@Accept			json
+//	@Produce		json
+//	@Param			Settings	body		UpdateMeRequest	true	"Changed settings"
+//	@Success		200			{object}	entity.User		"User with updated settings"
+//	@Failure		400			{object}	httpResponse	"Wrong JSON format or unknown time zone"
+//	@Failure		401			{object}	httpResponse	"Unauthorized"
+//	@Failure		500			{object}	httpResponse	"Internal error"
+//	@Router			/me [patch]
+func (h *WordHandler) updateMe(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	var req UpdateMeRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	user, err := h.settingsService.PatchSettings(
+		r.Context(),
+		entity.SettingsPatch{
+			UserID:        userID,
+			Scheduler:     req.Scheduler,
+			SrcLang:       req.SrcLang,
+			TrgtLang:      req.TrgtLang,
+			DailyNewLimit: req.DailyNewLimit,
+			Timezone:      req.Timezone,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrUnknownTimezone) {
+			h.encode(
+				w,
+				http.StatusBadRequest,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrUnknownTimezone.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - updateMe - h.settingsService.PatchSettings: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - updateMe - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		user,
+	)
+}
@@ -0,0 +1,217 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_registerUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.SettingsService)
+	}{
+		{
+			name:       "User registered",
+			userID:     "12345",
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.SettingsService) {
+				srvMock.On("RegisterUser", mock.Anything, "12345").Once().Return(nil)
+			},
+		},
+		{
+			name:       "Without user_id in ctx",
+			wantStatus: http.StatusOK,
+			setupMock:  func(srvMock *srvmock.SettingsService) {},
+		},
+		{
+			name:       "Internal error",
+			userID:     "12345",
+			wantStatus: http.StatusInternalServerError,
+			setupMock: func(srvMock *srvmock.SettingsService) {
+				srvMock.On("RegisterUser", mock.Anything, "12345").Once().Return(errors.New("some internal error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupSettingsHandler(t)
+		tt.setupMock(srvMock)
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.registerUser(next).ServeHTTP(w, translationRequest(http.MethodGet, "/me", "", tt.userID))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_updateMe(t *testing.T) {
+	berlin, limit := "Europe/Berlin", 5
+	user := entity.User{ID: "12345", Settings: entity.Settings{
+		Scheduler:     entity.SchedulerSM2,
+		DailyNewLimit: 5,
+		Timezone:      "Europe/Berlin",
+	}}
+
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    interface{}
+		setupMock  func(srvMock *srvmock.SettingsService)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPatch, "/me", `{"timezone":"Europe/Berlin"}`, ""),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/me",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.SettingsService) {},
+		},
+		{
+			name: "Empty scheduler",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPatch, "/me", `{"scheduler":""}`, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/me",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.SettingsService) {},
+		},
+		{
+			name: "Unknown time zone",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPatch, "/me", `{"timezone":"Mars/Olympus"}`, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/me",
+				Message: entity.ErrUnknownTimezone.Error(),
+			},
+			setupMock: func(srvMock *srvmock.SettingsService) {
+				tz := "Mars/Olympus"
+				srvMock.On("PatchSettings", mock.Anything, entity.SettingsPatch{UserID: "12345", Timezone: &tz}).Once().
+					Return(entity.User{}, entity.ErrUnknownTimezone)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPatch, "/me", `{"daily_new_limit":5}`, "12345"),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/me",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.SettingsService) {
+				srvMock.On("PatchSettings", mock.Anything, entity.SettingsPatch{UserID: "12345", DailyNewLimit: &limit}).Once().
+					Return(entity.User{}, errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Settings updated",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPatch, "/me", `{"daily_new_limit":5,"timezone":"Europe/Berlin"}`, "12345"),
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    user,
+			setupMock: func(srvMock *srvmock.SettingsService) {
+				srvMock.On("PatchSettings", mock.Anything, entity.SettingsPatch{UserID: "12345", DailyNewLimit: &limit, Timezone: &berlin}).Once().
+					Return(user, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupSettingsHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.updateMe(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			want, err := json.Marshal(tt.wantRes)
+			if err != nil {
+				t.Fatalf("%v - json.Marshal: %v", tt.name, err)
+			}
+			var gotResponse, wantResponse interface{}
+			if err := json.Unmarshal(want, &wantResponse); err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse); err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(wantResponse, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", wantResponse, gotResponse, diff)
+			}
+		})
+	}
+}
+
+func Test_me(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.SettingsService)
+	}{
+		{
+			name:       "User",
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.SettingsService) {
+				srvMock.On("User", mock.Anything, "12345").Once().Return(entity.User{ID: "12345"}, nil)
+			},
+		},
+		{
+			name:       "User not found",
+			wantStatus: http.StatusNotFound,
+			setupMock: func(srvMock *srvmock.SettingsService) {
+				srvMock.On("User", mock.Anything, "12345").Once().Return(entity.User{}, entity.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupSettingsHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.me(w, translationRequest(http.MethodGet, "/me", "", "12345"))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
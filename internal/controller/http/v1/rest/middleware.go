@@ -1,14 +1,19 @@
 package rest
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/Kin-dza-dzaa/flash_cards_api/config"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	"github.com/go-chi/jwtauth"
 	"golang.org/x/exp/slog"
-	"google.golang.org/api/idtoken"
 )
 
+// Authenticates requests with JWTs and API keys, API keys are told apart by entity.APIKeyPrefix.
 func (h *WordHandler) jwtAuthenticator(next http.Handler) http.Handler {
 	respond := func(w http.ResponseWriter, r *http.Request) {
 		h.encode(w,
@@ -27,14 +32,135 @@ func (h *WordHandler) jwtAuthenticator(next http.Handler) http.Handler {
 			return
 		}
 
-		// Validate token, makes HTTP request to get PK from google.
-		p, err := idtoken.Validate(r.Context(), token, "")
+		if strings.HasPrefix(token, entity.APIKeyPrefix) {
+			h.apiKeyAuthenticator(next, token).ServeHTTP(w, r)
+			return
+		}
+
+		// Validate token, keys of the issuer may be fetched over HTTP.
+		id, err := h.authenticator.Authenticate(r.Context(), token)
 		if err != nil {
+			h.logger.DebugCtx(r.Context(), "Unauthorized", slog.String("error", err.Error()))
 			respond(w, r)
 			return
 		}
 
-		next.ServeHTTP(w, r.WithContext(inCtx(r.Context(), "user_id", p.Subject)))
+		ctx := inCtx(r.Context(), userIDCtxKey, id.Subject)
+		next.ServeHTTP(w, r.WithContext(inCtx(ctx, claimsCtxKey, id.Claims)))
+	})
+}
+
+// Sets role of the authenticated user, admins are users of the allowlist
+// and users whose token has the admin role in the role claim.
+func (h *WordHandler) authorizer(cfg config.Admin) func(http.Handler) http.Handler {
+	admins := make(map[string]bool, len(cfg.Users))
+	for _, userID := range cfg.Users {
+		admins[userID] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := fromCtx(r.Context(), userIDCtxKey)
+			if userID != "" && (admins[userID] || hasRole(claimsFromCtx(r.Context()), cfg.RoleClaim, cfg.Role)) {
+				r = r.WithContext(inCtx(r.Context(), roleCtxKey, roleAdmin))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Allows requests only of users with the role.
+func (h *WordHandler) requireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if fromCtx(r.Context(), roleCtxKey) != role {
+				h.encode(
+					w,
+					http.StatusForbidden,
+					httpResponse{
+						Path:    r.URL.Path,
+						Message: http.StatusText(http.StatusForbidden),
+					})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Reports whether the claim is the role or a list with it, roles in a string claim are separated by spaces.
+func hasRole(claims map[string]interface{}, claim, role string) bool {
+	if claim == "" || role == "" {
+		return false
+	}
+
+	switch value := claims[claim].(type) {
+	case string:
+		for _, r := range strings.Fields(value) {
+			if r == role {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, r := range value {
+			if r, ok := r.(string); ok && r == role {
+				return true
+			}
+		}
+	case []string:
+		for _, r := range value {
+			if r == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authenticates request with API key and checks that key scopes allow it.
+func (h *WordHandler) apiKeyAuthenticator(next http.Handler, plain string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, err := h.apiKeyService.AuthenticateAPIKey(r.Context(), plain)
+		if err != nil {
+			if errors.Is(err, entity.ErrAPIKeyNotFound) {
+				h.encode(w,
+					http.StatusUnauthorized,
+					httpResponse{
+						Path:    r.URL.Path,
+						Message: http.StatusText(http.StatusUnauthorized),
+					})
+				return
+			}
+
+			h.logger.ErrorCtx(
+				r.Context(),
+				"Internal error",
+				slog.String("error", fmt.Errorf("wordHandler - apiKeyAuthenticator - h.apiKeyService.AuthenticateAPIKey: %w", err).Error()),
+			)
+			h.encode(
+				w,
+				http.StatusInternalServerError,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: http.StatusText(http.StatusInternalServerError),
+				},
+			)
+			return
+		}
+
+		if !apiKeyAllows(key, r) {
+			h.encode(w,
+				http.StatusForbidden,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: http.StatusText(http.StatusForbidden),
+				})
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(inCtx(r.Context(), userIDCtxKey, key.UserID)))
 	})
 }
 
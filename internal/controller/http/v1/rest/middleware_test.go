@@ -2,15 +2,37 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	"github.com/google/go-cmp/cmp"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_jwtAuthenticator(t *testing.T) {
-	dmyHand := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	// Responds with user_id of the request.
+	dmyHand := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(httpResponse{Path: r.URL.Path, Message: fromCtx(r.Context(), userIDCtxKey)})
+	})
+	withToken := func(secret []byte, exp time.Time) *http.Request {
+		tok := jwt.New()
+		_ = tok.Set(jwt.SubjectKey, "12345")
+		_ = tok.Set(jwt.ExpirationKey, exp)
+		signed, err := jwt.Sign(tok, jwa.HS256, secret)
+		if err != nil {
+			t.Fatalf("jwt.Sign: %v", err)
+		}
+		r := httptest.NewRequest(http.MethodGet, "/jwt", nil)
+		r.Header.Add("Authorization", "BEARER "+string(signed))
+		return r
+	}
 
 	type args struct {
 		w *httptest.ResponseRecorder
@@ -47,6 +69,39 @@ func Test_jwtAuthenticator(t *testing.T) {
 				}(),
 			},
 		},
+		{
+			name: "With jwt of unknown key",
+			wantRes: httpResponse{
+				Path:    "/jwt",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withToken([]byte("other secret"), time.Now().Add(time.Hour)),
+			},
+		},
+		{
+			name: "With expired jwt",
+			wantRes: httpResponse{
+				Path:    "/jwt",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withToken(testSecret, time.Now().Add(-time.Hour)),
+			},
+		},
+		{
+			name: "With valid jwt",
+			wantRes: httpResponse{
+				Path:    "/jwt",
+				Message: "12345",
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withToken(testSecret, time.Now().Add(time.Hour)),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -65,3 +120,80 @@ func Test_jwtAuthenticator(t *testing.T) {
 		})
 	}
 }
+
+func Test_apiKeyAuthenticator(t *testing.T) {
+	const plain = "fc_secret"
+	dmyHand := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(httpResponse{Path: r.URL.Path, Message: fromCtx(r.Context(), userIDCtxKey)})
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.ApiKeyService)
+	}{
+		{
+			name:       "Valid key",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantRes:    httpResponse{Path: "/v1/words", Message: "12345"},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {
+				srvMock.On("AuthenticateAPIKey", mock.Anything, plain).Once().
+					Return(entity.APIKey{UserID: "12345", Scopes: []entity.APIKeyScope{entity.ScopeReadOnly}}, nil)
+			},
+		},
+		{
+			name:       "Scope doesn't allow request",
+			method:     http.MethodPost,
+			wantStatus: http.StatusForbidden,
+			wantRes:    httpResponse{Path: "/v1/words", Message: http.StatusText(http.StatusForbidden)},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {
+				srvMock.On("AuthenticateAPIKey", mock.Anything, plain).Once().
+					Return(entity.APIKey{UserID: "12345", Scopes: []entity.APIKeyScope{entity.ScopeReadOnly}}, nil)
+			},
+		},
+		{
+			name:       "Unknown or expired key",
+			method:     http.MethodGet,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    httpResponse{Path: "/v1/words", Message: http.StatusText(http.StatusUnauthorized)},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {
+				srvMock.On("AuthenticateAPIKey", mock.Anything, plain).Once().
+					Return(entity.APIKey{}, entity.ErrAPIKeyNotFound)
+			},
+		},
+		{
+			name:       "Internal error",
+			method:     http.MethodGet,
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    httpResponse{Path: "/v1/words", Message: http.StatusText(http.StatusInternalServerError)},
+			setupMock: func(srvMock *srvmock.ApiKeyService) {
+				srvMock.On("AuthenticateAPIKey", mock.Anything, plain).Once().
+					Return(entity.APIKey{}, errors.New("some internal error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupAPIKeyHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w, r := httptest.NewRecorder(), httptest.NewRequest(tt.method, "/v1/words", nil)
+			r.Header.Add("Authorization", "BEARER "+plain)
+			h.jwtAuthenticator(dmyHand).ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+			var gotResponse httpResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResponse); err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
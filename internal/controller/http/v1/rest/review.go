@@ -0,0 +1,218 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	defaultDueLimit = 100
+)
+
+type DueWordsRequest struct {
+	Collection string `validate:"omitempty"`
+	Limit      int    `validate:"min=1,max=1000"`
+	// Daily limit of user settings is used when it is nil.
+	NewLimit *int `validate:"omitempty,min=0,max=1000"`
+}
+
+// Parses query params, missing params get default values.
+func (h *WordHandler) dueWordsRequest(r *http.Request) (DueWordsRequest, error) {
+	req := DueWordsRequest{
+		Collection: r.URL.Query().Get("collection"),
+		Limit:      defaultDueLimit,
+	}
+
+	var err error
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return req, err
+		}
+	}
+	if newLimit := r.URL.Query().Get("new_limit"); newLimit != "" {
+		n, err := strconv.Atoi(newLimit)
+		if err != nil {
+			return req, err
+		}
+		req.NewLimit = &n
+	}
+
+	return req, h.v.Struct(req)
+}
+
+// List due words
+//
+//	@Summary		Get words to review.
+//	@Description	Gets words which are due for review ordered by overdue-ness with a capped number of new words per day mixed in.
+//	@Tags			reviews
+//	@Produce		json
+//	@Param			collection	query		string				false	"Collection name, all collections if empty"
+//	@Param			limit		query		int					false	"Max number of words"			default(100)	minimum(1)	maximum(1000)
+//	@Param			new_limit	query		int					false	"Max number of new words a day, daily limit of user settings if empty"	minimum(0)	maximum(1000)
+//	@Success		200			{object}	entity.ReviewQueue	"Words to review"
+//	@Failure		400			{object}	httpResponse		"Wrong query params"
+//	@Failure		401			{object}	httpResponse		"Unauthorized"
+//	@Failure		500			{object}	httpResponse		"Internal error"
+//	@Router			/reviews/due [get]
+func (h *WordHandler) dueWords(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	req, err := h.dueWordsRequest(r)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	newLimit := entity.UserNewLimit
+	if req.NewLimit != nil {
+		newLimit = *req.NewLimit
+	}
+	queue, err := h.wordService.DueWords(
+		r.Context(),
+		entity.DueQuery{
+			UserID:     userID,
+			Collection: req.Collection,
+			Limit:      req.Limit,
+			NewLimit:   newLimit,
+		},
+	)
+	if err != nil {
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - dueWords - h.service.DueWords: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - dueWords - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		queue,
+	)
+}
+
+// Review history of a word
+//
+//	@Summary		Get review history of a word.
+//	@Description	Gets all past reviews of a word ordered by review time, of all collections if collection is empty. Reviews of deleted words are kept.
+//	@Tags			words
+//	@Produce		json
+//	@Param			word		path		string				true	"Word"
+//	@Param			collection	query		string				false	"Collection name"
+//	@Success		200			{object}	entity.WordHistory	"Reviews of the word"
+//	@Failure		400			{object}	httpResponse		"Wrong params"
+//	@Failure		401			{object}	httpResponse		"Unauthorized"
+//	@Failure		404			{object}	httpResponse		"Word not in collection"
+//	@Failure		500			{object}	httpResponse		"Internal error"
+//	@Router			/words/{word}/history [get]
+func (h *WordHandler) wordHistory(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	word := chi.URLParam(r, "word")
+	if word == "" {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	history, err := h.wordService.WordHistory(
+		r.Context(),
+		entity.Collection{
+			UserID: userID,
+			Word:   word,
+			Name:   r.URL.Query().Get("collection"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrWordNotInCollection) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrWordNotInCollection.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - wordHistory - h.service.WordHistory: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - wordHistory - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		history,
+	)
+}
@@ -0,0 +1,255 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_dueWords(t *testing.T) {
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.WordService, args args)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/due", nil),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/due",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Invalid limit",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/due?limit=many", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/due",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Limit out of range",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/due?limit=0", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/due",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/due", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/due",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("DueWords", args.r.Context(), entity.DueQuery{
+					UserID:   "12345",
+					Limit:    defaultDueLimit,
+					NewLimit: entity.UserNewLimit,
+				}).Once().Return(nil, errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/due?collection=coll&limit=10&new_limit=0", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("DueWords", args.r.Context(), entity.DueQuery{
+					UserID:     "12345",
+					Collection: "coll",
+					Limit:      10,
+				}).Once().Return(new(entity.ReviewQueue), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupWordHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.dueWords(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
+
+func Test_wordHistory(t *testing.T) {
+	historyRequest := func(target, word, userID string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("word", word)
+		ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+		if userID != "" {
+			ctx = inCtx(ctx, userIDCtxKey, userID)
+		}
+		return r.WithContext(ctx)
+	}
+
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.WordService, args args)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: historyRequest("/history", "word", ""),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/history",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Word not in collection",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: historyRequest("/history?collection=coll", "word", "12345"),
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes: httpResponse{
+				Path:    "/history",
+				Message: entity.ErrWordNotInCollection.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("WordHistory", args.r.Context(), entity.Collection{
+					UserID: "12345",
+					Word:   "word",
+					Name:   "coll",
+				}).Once().Return(nil, entity.ErrWordNotInCollection)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: historyRequest("/history", "word", "12345"),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/history",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("WordHistory", args.r.Context(), entity.Collection{
+					UserID: "12345",
+					Word:   "word",
+				}).Once().Return(nil, errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: historyRequest("/history", "word", "12345"),
+			},
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("WordHistory", args.r.Context(), entity.Collection{
+					UserID: "12345",
+					Word:   "word",
+				}).Once().Return(new(entity.WordHistory), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupWordHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.wordHistory(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+)
+
+type (
+	settingsService interface {
+		RegisterUser(ctx context.Context, userID string) error
+		User(ctx context.Context, userID string) (entity.User, error)
+		PatchSettings(ctx context.Context, patch entity.SettingsPatch) (entity.User, error)
+	}
+)
+
+// Get user settings, alias of GET /me.
+//
+//	@Summary		Get user settings.
+//	@Description	Deprecated, use GET /me.
+//	@Tags			settings
+//	@Produce		json
+//	@Success		200	{object}	entity.User		"User with settings"
+//	@Failure		401	{object}	httpResponse	"Unauthorized"
+//	@Failure		404	{object}	httpResponse	"User not found"
+//	@Failure		500	{object}	httpResponse	"Internal error"
+//	@Deprecated
+//	@Router			/settings [get]
+func (h *WordHandler) settings(w http.ResponseWriter, r *http.Request) {
+	h.me(w, r)
+}
+
+// Update user settings, alias of PATCH /me.
+//
+//	@Summary		Updates user settings.
+//	@Description	Deprecated, use PATCH /me.
+//	@Tags			settings
+//	@Accept			json
+//	@Produce		json
+//	@Param			Settings	body		UpdateMeRequest	true	"Changed settings"
+//	@Success		200			{object}	entity.User		"User with updated settings"
+//	@Failure		400			{object}	httpResponse	"Wrong JSON format or unknown time zone"
+//	@Failure		401			{object}	httpResponse	"Unauthorized"
+//	@Failure		500			{object}	httpResponse	"Internal error"
+//	@Deprecated
+//	@Router			/settings [put]
+func (h *WordHandler) updateSettings(w http.ResponseWriter, r *http.Request) {
+	h.updateMe(w, r)
+}
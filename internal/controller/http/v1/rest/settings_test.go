@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/exp/slog"
+)
+
+func setupSettingsHandler(t *testing.T) (*WordHandler, *srvmock.SettingsService) {
+	t.Helper()
+	srvMock := srvmock.NewSettingsService(t)
+	h := &WordHandler{
+		settingsService: srvMock,
+		logger:          logger.New(slog.LevelDebug),
+		v:               validator.New(),
+	}
+	return h, srvMock
+}
+
+func Test_settings(t *testing.T) {
+	user := entity.User{ID: "12345", Settings: entity.Settings{Scheduler: entity.SchedulerFSRS}}
+	h, srvMock := setupSettingsHandler(t)
+	srvMock.On("User", mock.Anything, "12345").Once().Return(user, nil)
+
+	w := httptest.NewRecorder()
+	h.settings(w, translationRequest(http.MethodGet, "/settings", "", "12345"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("wanted status: %v got: %v", http.StatusOK, w.Code)
+	}
+	wantRes, _ := json.Marshal(user)
+	if diff := cmp.Diff(string(wantRes)+"\n", w.Body.String()); diff != "" {
+		t.Fatalf("wanted: %s got: %v dif: %v", wantRes, w.Body.String(), diff)
+	}
+}
+
+func Test_updateSettings(t *testing.T) {
+	fsrs := entity.SchedulerFSRS
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		setupMock  func(srvMock *srvmock.SettingsService)
+	}{
+		{
+			name:       "Unknown scheduler",
+			body:       `{"scheduler": "leitner"}`,
+			wantStatus: http.StatusBadRequest,
+			setupMock:  func(srvMock *srvmock.SettingsService) {},
+		},
+		{
+			name:       "Valid request",
+			body:       `{"scheduler": "fsrs"}`,
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.SettingsService) {
+				srvMock.On("PatchSettings", mock.Anything, entity.SettingsPatch{UserID: "12345", Scheduler: &fsrs}).Once().
+					Return(entity.User{ID: "12345", Settings: entity.Settings{Scheduler: fsrs}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupSettingsHandler(t)
+		tt.setupMock(srvMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.updateSettings(w, translationRequest(http.MethodPut, "/settings", tt.body, "12345"))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
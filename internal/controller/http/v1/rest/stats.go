@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+const defaultHeatmapDays = 365
+
+type (
+	statsService interface {
+		Stats(ctx context.Context, userID, collection string, heatmapDays int) (*entity.Stats, error)
+	}
+)
+
+type StatsRequest struct {
+	Collection string `validate:"omitempty"`
+	Days       int    `validate:"min=1,max=3660"`
+}
+
+// Parses query params, missing params get default values.
+func (h *WordHandler) statsRequest(r *http.Request) (StatsRequest, error) {
+	req := StatsRequest{
+		Collection: r.URL.Query().Get("collection"),
+		Days:       defaultHeatmapDays,
+	}
+
+	if days := r.URL.Query().Get("days"); days != "" {
+		var err error
+		if req.Days, err = strconv.Atoi(days); err != nil {
+			return req, err
+		}
+	}
+
+	return req, h.v.Struct(req)
+}
+
+// Learning statistics
+//
+//	@Summary		Get learning statistics.
+//	@Description	Gets retention per collection, reviews per day, cards due per day for the next 30 days and cards by maturity.
+//	@Tags			stats
+//	@Produce		json
+//	@Param			collection	query		string			false	"Collection name, all collections if empty"
+//	@Param			days		query		int				false	"Number of days in retention and heatmap"	default(365)	minimum(1)	maximum(3660)
+//	@Success		200			{object}	entity.Stats	"Learning statistics"
+//	@Failure		400			{object}	httpResponse	"Wrong query params"
+//	@Failure		401			{object}	httpResponse	"Unauthorized"
+//	@Failure		500			{object}	httpResponse	"Internal error"
+//	@Router			/stats [get]
+func (h *WordHandler) stats(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	req, err := h.statsRequest(r)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	stats, err := h.statsService.Stats(r.Context(), userID, req.Collection, req.Days)
+	if err != nil {
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - stats - h.statsService.Stats: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - stats - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		stats,
+	)
+}
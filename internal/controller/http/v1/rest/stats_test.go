@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slog"
+)
+
+func setupStatsHandler(t *testing.T) (*WordHandler, *srvmock.StatsService) {
+	t.Helper()
+	srvMock := srvmock.NewStatsService(t)
+	h := &WordHandler{
+		statsService: srvMock,
+		logger:       logger.New(slog.LevelDebug),
+		v:            validator.New(),
+	}
+	return h, srvMock
+}
+
+func Test_stats(t *testing.T) {
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.StatsService, args args)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/stats", nil),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/stats",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.StatsService, args args) {},
+		},
+		{
+			name: "Days out of range",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/stats?days=0", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/stats",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.StatsService, args args) {},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/stats", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/stats",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.StatsService, args args) {
+				srvMock.On("Stats", args.r.Context(), "12345", "", defaultHeatmapDays).Once().
+					Return(nil, errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/stats?collection=coll&days=30", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.StatsService, args args) {
+				srvMock.On("Stats", args.r.Context(), "12345", "coll", 30).Once().Return(new(entity.Stats), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupStatsHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.stats(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+// Move word to another collection.
+//
+//	@Summary		Moves word to another collection.
+//	@Description	Moves word with its learning progress and review history, target collection is created if it doesn't exist.
+//	@Tags			words
+//	@Accept			json
+//	@Produce		json
+//	@Param			Transfer	body		TransferWordRequest	true	"Word, its collection and target collection"
+//	@Success		200			{object}	httpResponse		"Word was moved"
+//	@Failure		400			{object}	httpResponse		"Wrong JSON format"
+//	@Failure		401			{object}	httpResponse		"Unauthorized"
+//	@Failure		404			{object}	httpResponse		"Word not in collection"
+//	@Failure		409			{object}	httpResponse		"Word already in target collection or language pair doesn't match"
+//	@Failure		500			{object}	httpResponse		"Internal error"
+//	@Router			/words/move [post]
+func (h *WordHandler) moveWord(w http.ResponseWriter, r *http.Request) {
+	h.transferWord(w, r, "moveWord", h.wordService.MoveWord)
+}
+
+// Copy word to another collection.
+//
+//	@Summary		Copies word to another collection.
+//	@Description	Copies word with its learning progress and review history, target collection is created if it doesn't exist.
+//	@Tags			words
+//	@Accept			json
+//	@Produce		json
+//	@Param			Transfer	body		TransferWordRequest	true	"Word, its collection and target collection"
+//	@Success		200			{object}	httpResponse		"Word was copied"
+//	@Failure		400			{object}	httpResponse		"Wrong JSON format"
+//	@Failure		401			{object}	httpResponse		"Unauthorized"
+//	@Failure		404			{object}	httpResponse		"Word not in collection"
+//	@Failure		409			{object}	httpResponse		"Word already in target collection or language pair doesn't match"
+//	@Failure		500			{object}	httpResponse		"Internal error"
+//	@Router			/words/copy [post]
+func (h *WordHandler) copyWord(w http.ResponseWriter, r *http.Request) {
+	h.transferWord(w, r, "copyWord", h.wordService.CopyWord)
+}
+
+// Moving and copying only differ in the service call.
+func (h *WordHandler) transferWord(
+	w http.ResponseWriter,
+	r *http.Request,
+	name string,
+	transfer func(ctx context.Context, collection entity.Collection, target string) error,
+) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	var req TransferWordRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	err = transfer(
+		r.Context(),
+		entity.Collection{
+			UserID: userID,
+			Word:   req.Word,
+			Name:   req.CollectionName,
+		},
+		req.TargetCollectionName,
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrWordNotInCollection) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrWordNotInCollection.Error(),
+				},
+			)
+			return
+		}
+		if errors.Is(err, entity.ErrWordAlreadyInCollection) {
+			h.encode(
+				w,
+				http.StatusConflict,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrWordAlreadyInCollection.Error(),
+				},
+			)
+			return
+		}
+		if errors.Is(err, entity.ErrLanguagePairMismatch) {
+			h.encode(
+				w,
+				http.StatusConflict,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrLanguagePairMismatch.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - %s: %w", name, err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - "+name+" - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		httpResponse{
+			Path:    r.URL.Path,
+			Message: http.StatusText(http.StatusOK),
+		})
+}
@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_moveWord(t *testing.T) {
+	transferRequest := func(body string, userID string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/move", bytes.NewBufferString(body))
+		if userID != "" {
+			return r.WithContext(inCtx(r.Context(), userIDCtxKey, userID))
+		}
+		return r
+	}
+	const validBody = `{"word":"word","collection_name":"coll","target_collection_name":"other"}`
+	coll := entity.Collection{UserID: "12345", Word: "word", Name: "coll"}
+
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.WordService, args args)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: transferRequest(validBody, ""),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/move",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Same target collection",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: transferRequest(`{"word":"word","collection_name":"coll","target_collection_name":"coll"}`, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/move",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Word not in collection",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: transferRequest(validBody, "12345"),
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes: httpResponse{
+				Path:    "/move",
+				Message: entity.ErrWordNotInCollection.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("MoveWord", args.r.Context(), coll, "other").Once().Return(entity.ErrWordNotInCollection)
+			},
+		},
+		{
+			name: "Word already in target collection",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: transferRequest(validBody, "12345"),
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: httpResponse{
+				Path:    "/move",
+				Message: entity.ErrWordAlreadyInCollection.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("MoveWord", args.r.Context(), coll, "other").Once().Return(entity.ErrWordAlreadyInCollection)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: transferRequest(validBody, "12345"),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/move",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("MoveWord", args.r.Context(), coll, "other").Once().Return(errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: transferRequest(validBody, "12345"),
+			},
+			wantStatus: http.StatusOK,
+			wantRes: httpResponse{
+				Path:    "/move",
+				Message: http.StatusText(http.StatusOK),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("MoveWord", args.r.Context(), coll, "other").Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupWordHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.moveWord(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
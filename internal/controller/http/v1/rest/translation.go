@@ -0,0 +1,271 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+type EditTranslationRequest struct {
+	Word           string `json:"word" validate:"required"`
+	CollectionName string `json:"collection_name" validate:"required"`
+	// Empty fields keep the current values, at least one field must be edited.
+	MainTranslation string                                          `json:"main_translation" validate:"required_without_all=Translations Definitions Examples"`
+	Translations    map[entity.PartOfSpeech][]string                `json:"translations"`
+	Definitions     map[entity.PartOfSpeech][]entity.WordDefinition `json:"definitions"`
+	Examples        []string                                        `json:"examples"`
+}
+
+type AddCustomWordRequest struct {
+	Word           string        `json:"word" validate:"required"`
+	CollectionName string        `json:"collection_name" validate:"required"`
+	LastRepeat     time.Time     `json:"last_repeat" validate:"required"`
+	TimeDiff       time.Duration `json:"time_diff"`
+	// Language pair of the word, collection pair or the default one is used when empty.
+	SrcLang         string                                          `json:"src_lang" validate:"omitempty,max=16"`
+	TrgtLang        string                                          `json:"trgt_lang" validate:"omitempty,max=16"`
+	MainTranslation string                                          `json:"main_translation" validate:"required"`
+	Translations    map[entity.PartOfSpeech][]string                `json:"translations"`
+	Definitions     map[entity.PartOfSpeech][]entity.WordDefinition `json:"definitions"`
+	Examples        []string                                        `json:"examples"`
+}
+
+// Edit translation of a word.
+//
+//	@Summary		Edits translation of a word.
+//	@Description	Stores user edit of the translation, the edit applies to the word in all user collections. Omitted fields keep the current values.
+//	@Tags			words
+//	@Accept			json
+//	@Produce		json
+//	@Param			Translation	body		EditTranslationRequest	true	"Word, its collection and edited fields"
+//	@Success		200			{object}	httpResponse			"Translation was edited"
+//	@Failure		400			{object}	httpResponse			"Wrong JSON format"
+//	@Failure		401			{object}	httpResponse			"Unauthorized"
+//	@Failure		404			{object}	httpResponse			"Word not in collection"
+//	@Failure		500			{object}	httpResponse			"Internal error"
+//	@Router			/words/translation [put]
+func (h *WordHandler) editTranslation(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	var req EditTranslationRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	err = h.wordService.EditTranslation(
+		r.Context(),
+		entity.Collection{
+			UserID: userID,
+			Word:   req.Word,
+			Name:   req.CollectionName,
+		},
+		entity.UserTrans{
+			MainTranslation: req.MainTranslation,
+			Translations:    req.Translations,
+			Definitions:     req.Definitions,
+			Examples:        req.Examples,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrWordNotInCollection) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrWordNotInCollection.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - editTranslation - h.service.EditTranslation: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - editTranslation - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		httpResponse{
+			Path:    r.URL.Path,
+			Message: http.StatusText(http.StatusOK),
+		})
+}
+
+// Add custom word to collection.
+//
+//	@Summary		Adds a word with a user translation to a given collection.
+//	@Description	Adds a custom card, the word isn't translated so any word or phrase can be added.
+//	@Tags			words
+//	@Accept			json
+//	@Produce		json
+//	@Param			WordInfo	body		AddCustomWordRequest	true	"Word, collection name, learn intervals and translation"
+//	@Success		201			{object}	httpResponse			"Word was added to collection"
+//	@Failure		400			{object}	httpResponse			"Wrong JSON format"
+//	@Failure		401			{object}	httpResponse			"Unauthorized"
+//	@Failure		409			{object}	httpResponse			"Word already in collection or language pair doesn't match collection"
+//	@Failure		500			{object}	httpResponse			"Internal error"
+//	@Router			/words/custom [post]
+func (h *WordHandler) addCustomWord(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	var req AddCustomWordRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	err = h.wordService.AddCustomWord(
+		r.Context(),
+		entity.Collection{
+			UserID:     userID,
+			Name:       req.CollectionName,
+			Word:       req.Word,
+			SrcLang:    req.SrcLang,
+			TrgtLang:   req.TrgtLang,
+			LastRepeat: req.LastRepeat,
+			TimeDiff:   req.TimeDiff,
+		},
+		entity.UserTrans{
+			MainTranslation: req.MainTranslation,
+			Translations:    req.Translations,
+			Definitions:     req.Definitions,
+			Examples:        req.Examples,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrWordAlreadyInCollection) {
+			h.encode(
+				w,
+				http.StatusConflict,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrWordAlreadyInCollection.Error(),
+				},
+			)
+			return
+		}
+		if errors.Is(err, entity.ErrLanguagePairMismatch) {
+			h.encode(
+				w,
+				http.StatusConflict,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrLanguagePairMismatch.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - addCustomWord - h.service.AddCustomWord: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - addCustomWord - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusCreated,
+		httpResponse{
+			Path:    r.URL.Path,
+			Message: http.StatusText(http.StatusCreated),
+		})
+}
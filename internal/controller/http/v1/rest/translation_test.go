@@ -0,0 +1,249 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func translationRequest(method, path, body, userID string) *http.Request {
+	r := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	if userID != "" {
+		return r.WithContext(inCtx(r.Context(), userIDCtxKey, userID))
+	}
+	return r
+}
+
+func Test_editTranslation(t *testing.T) {
+	const validBody = `{"word":"gift","collection_name":"coll","main_translation":"дар","examples":["a gift for music"]}`
+	coll := entity.Collection{UserID: "12345", Word: "gift", Name: "coll"}
+	edit := entity.UserTrans{MainTranslation: "дар", Examples: []string{"a gift for music"}}
+
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.WordService, args args)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPut, "/translation", validBody, ""),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/translation",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Nothing edited",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPut, "/translation", `{"word":"gift","collection_name":"coll"}`, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/translation",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Word not in collection",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPut, "/translation", validBody, "12345"),
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes: httpResponse{
+				Path:    "/translation",
+				Message: entity.ErrWordNotInCollection.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("EditTranslation", args.r.Context(), coll, edit).Once().Return(entity.ErrWordNotInCollection)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPut, "/translation", validBody, "12345"),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/translation",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("EditTranslation", args.r.Context(), coll, edit).Once().Return(errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPut, "/translation", validBody, "12345"),
+			},
+			wantStatus: http.StatusOK,
+			wantRes: httpResponse{
+				Path:    "/translation",
+				Message: http.StatusText(http.StatusOK),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("EditTranslation", args.r.Context(), coll, edit).Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupWordHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.editTranslation(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
+
+func Test_addCustomWord(t *testing.T) {
+	const validBody = `{"word":"break a leg","collection_name":"idioms","last_repeat":"2023-01-02T15:04:05Z","main_translation":"ни пуха ни пера"}`
+	coll := entity.Collection{
+		UserID:     "12345",
+		Word:       "break a leg",
+		Name:       "idioms",
+		LastRepeat: time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	custom := entity.UserTrans{MainTranslation: "ни пуха ни пера"}
+
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.WordService, args args)
+	}{
+		{
+			name: "Without user_id in ctx",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/custom", validBody, ""),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/custom",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Without translation",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/custom",
+					`{"word":"break a leg","collection_name":"idioms","last_repeat":"2023-01-02T15:04:05Z"}`, "12345"),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/custom",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Word already in collection",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/custom", validBody, "12345"),
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: httpResponse{
+				Path:    "/custom",
+				Message: entity.ErrWordAlreadyInCollection.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddCustomWord", args.r.Context(), coll, custom).Once().Return(entity.ErrWordAlreadyInCollection)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/custom", validBody, "12345"),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/custom",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddCustomWord", args.r.Context(), coll, custom).Once().Return(errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: translationRequest(http.MethodPost, "/custom", validBody, "12345"),
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: httpResponse{
+				Path:    "/custom",
+				Message: http.StatusText(http.StatusCreated),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddCustomWord", args.r.Context(), coll, custom).Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupWordHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.addCustomWord(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
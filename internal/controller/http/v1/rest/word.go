@@ -7,10 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Kin-dza-dzaa/flash_cards_api/config"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/auth"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -26,21 +28,42 @@ import (
 const (
 	otelName     = "github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/rest"
 	userIDCtxKey = "user_id"
+	// Claims of the token, requests with API keys have none.
+	claimsCtxKey = "claims"
+	roleCtxKey   = "role"
+	roleAdmin    = "admin"
 )
 
 type (
 	wordService interface {
 		AddWord(ctx context.Context, collection entity.Collection) error
+		AddWords(ctx context.Context, collection entity.Collection, words []string) ([]entity.AddResult, error)
 		DeleteWord(ctx context.Context, collection entity.Collection) error
 		UserWords(ctx context.Context, collection entity.Collection) (*entity.UserWords, error)
+		Words(ctx context.Context, query entity.WordsQuery) (*entity.WordsPage, error)
 		UpdateLearnInterval(ctx context.Context, collection entity.Collection) error
+		Review(ctx context.Context, collection entity.Collection, grade entity.Grade) (entity.Collection, error)
+		DueWords(ctx context.Context, query entity.DueQuery) (*entity.ReviewQueue, error)
+		WordHistory(ctx context.Context, collection entity.Collection) (*entity.WordHistory, error)
+		MoveWord(ctx context.Context, collection entity.Collection, target string) error
+		CopyWord(ctx context.Context, collection entity.Collection, target string) error
+		EditTranslation(ctx context.Context, collection entity.Collection, userTrans entity.UserTrans) error
+		AddCustomWord(ctx context.Context, collection entity.Collection, userTrans entity.UserTrans) error
 	}
 )
 
 type WordHandler struct {
-	wordService wordService
-	logger      *slog.Logger
-	v           *validator.Validate
+	wordService       wordService
+	settingsService   settingsService
+	statsService      statsService
+	collectionService collectionService
+	refreshService    refreshService
+	apiKeyService     apiKeyService
+	accountService    accountService
+	adminService      adminService
+	authenticator     auth.Authenticator
+	logger            *slog.Logger
+	v                 *validator.Validate
 }
 
 type UpdateLearnIntervalRequest struct {
@@ -55,6 +78,24 @@ type AddWordRequest struct {
 	CollectionName string        `json:"collection_name" validate:"required"`
 	LastRepeat     time.Time     `json:"last_repeat" validate:"required"`
 	TimeDiff       time.Duration `json:"time_diff"`
+	// Language pair of the word, collection pair or the default one is used when empty.
+	SrcLang  string `json:"src_lang" validate:"omitempty,max=16"`
+	TrgtLang string `json:"trgt_lang" validate:"omitempty,max=16"`
+}
+
+type ReviewRequest struct {
+	Word           string       `json:"word" validate:"required"`
+	CollectionName string       `json:"collection_name" validate:"required"`
+	Grade          entity.Grade `json:"grade" validate:"required,oneof=again hard good easy" enums:"again,hard,good,easy"`
+}
+
+type ReviewResponse struct {
+	Word           string        `json:"word"`
+	CollectionName string        `json:"collection_name"`
+	LastRepeat     time.Time     `json:"last_repeat"`
+	TimeDiff       time.Duration `json:"time_diff"`
+	EaseFactor     float64       `json:"ease_factor"`
+	Repetitions    int           `json:"repetitions"`
 }
 
 type DeleteWordRequest struct {
@@ -62,6 +103,12 @@ type DeleteWordRequest struct {
 	CollectionName string `json:"collection_name" validate:"required"`
 }
 
+type TransferWordRequest struct {
+	Word                 string `json:"word" validate:"required"`
+	CollectionName       string `json:"collection_name" validate:"required"`
+	TargetCollectionName string `json:"target_collection_name" validate:"required,nefield=CollectionName"`
+}
+
 //	@title			Flash cards API
 //	@version		0.3.4
 //	@description	REST API for word and collections of a user.
@@ -97,13 +144,60 @@ func (h *WordHandler) Register(c *chi.Mux, cfg config.Cfg) {
 
 	c.Route("/v1", func(r chi.Router) {
 		r.Use(h.jwtAuthenticator)
+		r.Use(h.authorizer(cfg.Admin))
+		r.Use(h.registerUser)
 		r.Use(otelchi.Middleware("flash-cards-api-server"))
 		r.Use(middleware.SetHeader("Content-Type", "application/json"))
 		r.Route("/words", func(r chi.Router) {
 			r.Delete("/", h.deleteWord)
+			// Deprecated, intervals are scheduled by the server on review.
 			r.Put("/", h.updateLearnInterval)
 			r.Get("/", h.userWords)
 			r.Post("/", h.addWord)
+			r.Post("/batch", h.addWords)
+			r.Post("/review", h.reviewWord)
+			r.Post("/move", h.moveWord)
+			r.Post("/copy", h.copyWord)
+			r.Put("/translation", h.editTranslation)
+			r.Post("/custom", h.addCustomWord)
+			r.Get("/{word}/history", h.wordHistory)
+		})
+		r.Route("/collections", func(r chi.Router) {
+			r.Get("/", h.collections)
+			r.Post("/", h.createCollection)
+			r.Put("/{id}", h.updateCollection)
+			r.Delete("/{id}", h.deleteCollection)
+		})
+		r.Route("/reviews", func(r chi.Router) {
+			r.Get("/due", h.dueWords)
+		})
+		r.Route("/stats", func(r chi.Router) {
+			r.Get("/", h.stats)
+		})
+		r.Route("/me", func(r chi.Router) {
+			r.Get("/", h.me)
+			r.Patch("/", h.updateMe)
+			r.Delete("/", h.deleteMe)
+			r.Get("/export", h.exportMe)
+		})
+		// Deprecated, aliases of /me.
+		r.Route("/settings", func(r chi.Router) {
+			r.Get("/", h.settings)
+			r.Put("/", h.updateSettings)
+		})
+		r.Route("/api-keys", func(r chi.Router) {
+			r.Get("/", h.apiKeys)
+			r.Post("/", h.createAPIKey)
+			r.Delete("/{id}", h.revokeAPIKey)
+		})
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(h.requireRole(roleAdmin))
+			r.Get("/users", h.adminUsers)
+			r.Get("/translations", h.adminTranslations)
+			r.Put("/translations", h.adminEditTranslation)
+			r.Delete("/translations", h.adminDeleteTranslation)
+			r.Post("/translations/refresh", h.refreshTranslation)
+			r.Get("/providers", h.providerStats)
 		})
 	})
 }
@@ -111,12 +205,23 @@ func (h *WordHandler) Register(c *chi.Mux, cfg config.Cfg) {
 // List user words
 //
 //	@Summary		Get user words.
-//	@Description	Gets user words that put together in collections.
+//	@Description	Gets a page of user words, next page is requested with next_cursor of the previous one.
+//	@Description	With grouped=true gets all user words that put together in collections as entity.UserWords, other params are ignored then.
 //	@Tags			words
 //	@Produce		json
-//	@Success		200	{object}	entity.UserWords	"User words"
-//	@Failure		401	{object}	httpResponse		"Unauthorized"
-//	@Failure		500	{object}	httpResponse		"Internal error"
+//	@Param			grouped		query		bool			false	"All words grouped by collections"
+//	@Param			collection	query		string			false	"Collection name"
+//	@Param			pos			query		string			false	"Part of speech"
+//	@Param			q			query		string			false	"Substring of a word"
+//	@Param			due			query		bool			false	"Only due or only not due words"
+//	@Param			sort		query		string			false	"Sort key"		Enums(added, last_repeat, word)	default(added)
+//	@Param			order		query		string			false	"Sort order"	Enums(asc, desc)				default(desc)
+//	@Param			limit		query		int				false	"Page size"		default(50)						minimum(1)	maximum(500)
+//	@Param			cursor		query		string			false	"Cursor of the next page"
+//	@Success		200			{object}	entity.WordsPage	"User words"
+//	@Failure		400			{object}	httpResponse		"Wrong query params"
+//	@Failure		401			{object}	httpResponse		"Unauthorized"
+//	@Failure		500			{object}	httpResponse		"Internal error"
 //	@Router			/words [get]
 func (h *WordHandler) userWords(w http.ResponseWriter, r *http.Request) {
 	userID := fromCtx(r.Context(), userIDCtxKey)
@@ -130,6 +235,10 @@ func (h *WordHandler) userWords(w http.ResponseWriter, r *http.Request) {
 			})
 		return
 	}
+	if grouped, _ := strconv.ParseBool(r.URL.Query().Get("grouped")); !grouped {
+		h.wordsPage(w, r, userID)
+		return
+	}
 	collection := entity.Collection{
 		UserID: userID,
 	}
@@ -166,16 +275,18 @@ func (h *WordHandler) userWords(w http.ResponseWriter, r *http.Request) {
 
 // Update learn internal of a word.
 //
-//	@Summary	Updates learn interval for a given word.
-//	@Tags		words
-//	@Accept		json
-//	@Produce	json
-//	@Param		WordInfo	body		UpdateLearnIntervalRequest	true	"Word, collection name with learn intervals"
-//	@Success	200			{object}	httpResponse				"Interval was updated"
-//	@Failure	400			{object}	httpResponse				"Wrong JSON format"
-//	@Failure	401			{object}	httpResponse				"Unauthorized"
-//	@Failure	500			{object}	httpResponse				"Internal error"
-//	@Router		/words [put]
+//	@Summary		Updates learn interval for a given word.
+//	@Description	Deprecated, use POST /words/review so the server schedules the word. Ease factor, repetitions and FSRS memory state of the word are reset to match the interval.
+//	@Tags			words
+//	@Accept			json
+//	@Produce		json
+//	@Param			WordInfo	body		UpdateLearnIntervalRequest	true	"Word, collection name with learn intervals"
+//	@Success		200			{object}	httpResponse				"Interval was updated"
+//	@Failure		400			{object}	httpResponse				"Wrong JSON format"
+//	@Failure		401			{object}	httpResponse				"Unauthorized"
+//	@Failure		500			{object}	httpResponse				"Internal error"
+//	@Deprecated
+//	@Router			/words [put]
 func (h *WordHandler) updateLearnInterval(w http.ResponseWriter, r *http.Request) {
 	userID := fromCtx(r.Context(), userIDCtxKey)
 	if userID == "" {
@@ -250,6 +361,114 @@ func (h *WordHandler) updateLearnInterval(w http.ResponseWriter, r *http.Request
 		})
 }
 
+// Review word.
+//
+//	@Summary		Reviews a word.
+//	@Description	Grades an answer and schedules next repeat of a word on the server side.
+//	@Tags			words
+//	@Accept			json
+//	@Produce		json
+//	@Param			Review	body		ReviewRequest	true	"Word, collection name and grade"
+//	@Success		200		{object}	ReviewResponse	"Next repeat of the word"
+//	@Failure		400		{object}	httpResponse	"Wrong JSON format"
+//	@Failure		401		{object}	httpResponse	"Unauthorized"
+//	@Failure		404		{object}	httpResponse	"Word not in collection"
+//	@Failure		500		{object}	httpResponse	"Internal error"
+//	@Router			/words/review [post]
+func (h *WordHandler) reviewWord(w http.ResponseWriter, r *http.Request) {
+	userID := fromCtx(r.Context(), userIDCtxKey)
+	if userID == "" {
+		h.encode(
+			w,
+			http.StatusUnauthorized,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusUnauthorized),
+			})
+		return
+	}
+
+	var req ReviewRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: wrongJSONFormat,
+			},
+		)
+		return
+	}
+
+	if err := h.v.Struct(req); err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	card, err := h.wordService.Review(
+		r.Context(),
+		entity.Collection{
+			UserID: userID,
+			Name:   req.CollectionName,
+			Word:   req.Word,
+		},
+		req.Grade,
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrWordNotInCollection) {
+			h.encode(
+				w,
+				http.StatusNotFound,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrWordNotInCollection.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - reviewWord - h.service.Review: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - reviewWord - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		ReviewResponse{
+			Word:           card.Word,
+			CollectionName: card.Name,
+			LastRepeat:     card.LastRepeat,
+			TimeDiff:       card.TimeDiff,
+			EaseFactor:     card.EaseFactor,
+			Repetitions:    card.Repetitions,
+		})
+}
+
 // Delete word from collection.
 //
 //	@Summary	Deletes given word from a collection.
@@ -349,8 +568,10 @@ func (h *WordHandler) deleteWord(w http.ResponseWriter, r *http.Request) {
 //	@Success	201			{object}	httpResponse	"Word was added to collection"
 //	@Failure	400			{object}	httpResponse	"Wrong JSON format"
 //	@Failure	401			{object}	httpResponse	"Unauthorized"
-//	@Failure	403			{object}	httpResponse	"Word not supported"
+//	@Failure	403			{object}	httpResponse	"Word not supported, it can be added as a custom word"
+//	@Failure	409			{object}	httpResponse	"Word already in collection or language pair doesn't match collection"
 //	@Failure	500			{object}	httpResponse	"Internal error"
+//	@Failure	503			{object}	httpResponse	"Translator temporarily unavailable"
 //	@Router		/words [post]
 func (h *WordHandler) addWord(w http.ResponseWriter, r *http.Request) {
 	userID := fromCtx(r.Context(), userIDCtxKey)
@@ -396,6 +617,8 @@ func (h *WordHandler) addWord(w http.ResponseWriter, r *http.Request) {
 			UserID:     userID,
 			Name:       req.CollectionName,
 			Word:       req.Word,
+			SrcLang:    req.SrcLang,
+			TrgtLang:   req.TrgtLang,
 			LastRepeat: req.LastRepeat,
 			TimeDiff:   req.TimeDiff,
 		},
@@ -412,6 +635,39 @@ func (h *WordHandler) addWord(w http.ResponseWriter, r *http.Request) {
 			)
 			return
 		}
+		if errors.Is(err, entity.ErrWordAlreadyInCollection) {
+			h.encode(
+				w,
+				http.StatusConflict,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrWordAlreadyInCollection.Error(),
+				},
+			)
+			return
+		}
+		if errors.Is(err, entity.ErrLanguagePairMismatch) {
+			h.encode(
+				w,
+				http.StatusConflict,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrLanguagePairMismatch.Error(),
+				},
+			)
+			return
+		}
+		if errors.Is(err, entity.ErrTranslatorUnavailable) {
+			h.encode(
+				w,
+				http.StatusServiceUnavailable,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrTranslatorUnavailable.Error(),
+				},
+			)
+			return
+		}
 
 		h.logger.ErrorCtx(
 			r.Context(),
@@ -443,11 +699,30 @@ func (h *WordHandler) addWord(w http.ResponseWriter, r *http.Request) {
 		})
 }
 
-func NewWordHandler(wordService wordService, l *slog.Logger) *WordHandler {
+func NewWordHandler(
+	wordService wordService,
+	settingsService settingsService,
+	statsService statsService,
+	collectionService collectionService,
+	refreshService refreshService,
+	apiKeyService apiKeyService,
+	accountService accountService,
+	adminService adminService,
+	authenticator auth.Authenticator,
+	l *slog.Logger,
+) *WordHandler {
 	h := &WordHandler{
-		wordService: wordService,
-		logger:      l,
-		v:           validator.New(),
+		wordService:       wordService,
+		settingsService:   settingsService,
+		statsService:      statsService,
+		collectionService: collectionService,
+		refreshService:    refreshService,
+		apiKeyService:     apiKeyService,
+		accountService:    accountService,
+		adminService:      adminService,
+		authenticator:     authenticator,
+		logger:            l,
+		v:                 validator.New(),
 	}
 
 	return h
@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/exp/slog"
+)
+
+const defaultWordsLimit = 50
+
+type WordsRequest struct {
+	Collection   string
+	PartOfSpeech string
+	Search       string
+	Due          *bool
+	Sort         string `validate:"oneof=added last_repeat word"`
+	Order        string `validate:"oneof=asc desc"`
+	Limit        int    `validate:"min=1,max=500"`
+	Cursor       string
+}
+
+// Parses query params, missing params get default values.
+func (h *WordHandler) wordsRequest(r *http.Request) (WordsRequest, error) {
+	query := r.URL.Query()
+	req := WordsRequest{
+		Collection:   query.Get("collection"),
+		PartOfSpeech: query.Get("pos"),
+		Search:       query.Get("q"),
+		Sort:         string(entity.WordsSortAdded),
+		Order:        "desc",
+		Limit:        defaultWordsLimit,
+		Cursor:       query.Get("cursor"),
+	}
+	if sort := query.Get("sort"); sort != "" {
+		req.Sort = sort
+	}
+	if order := query.Get("order"); order != "" {
+		req.Order = order
+	}
+
+	if due := query.Get("due"); due != "" {
+		d, err := strconv.ParseBool(due)
+		if err != nil {
+			return req, err
+		}
+		req.Due = &d
+	}
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return req, err
+		}
+	}
+
+	return req, h.v.Struct(req)
+}
+
+// Responds with a page of user words, see userWords.
+func (h *WordHandler) wordsPage(w http.ResponseWriter, r *http.Request, userID string) {
+	req, err := h.wordsRequest(r)
+	if err != nil {
+		h.encode(
+			w,
+			http.StatusBadRequest,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusBadRequest),
+			})
+		return
+	}
+
+	page, err := h.wordService.Words(
+		r.Context(),
+		entity.WordsQuery{
+			UserID:       userID,
+			Collection:   req.Collection,
+			PartOfSpeech: entity.PartOfSpeech(req.PartOfSpeech),
+			Search:       req.Search,
+			Due:          req.Due,
+			Sort:         entity.WordsSort(req.Sort),
+			Desc:         req.Order == "desc",
+			Limit:        req.Limit,
+			Cursor:       req.Cursor,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCursor) {
+			h.encode(
+				w,
+				http.StatusBadRequest,
+				httpResponse{
+					Path:    r.URL.Path,
+					Message: entity.ErrInvalidCursor.Error(),
+				},
+			)
+			return
+		}
+
+		h.logger.ErrorCtx(
+			r.Context(),
+			"Internal error",
+			slog.String("error", fmt.Errorf("wordHandler - wordsPage - h.service.Words: %w", err).Error()),
+		)
+		h.encode(
+			w,
+			http.StatusInternalServerError,
+			httpResponse{
+				Path:    r.URL.Path,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		)
+
+		_, span := otel.Tracer(otelName).Start(r.Context(), "WordHandler - wordsPage - Error")
+		defer span.End()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	h.encode(
+		w,
+		http.StatusOK,
+		page,
+	)
+}
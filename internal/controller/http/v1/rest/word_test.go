@@ -10,6 +10,7 @@ import (
 
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/controller/http/v1/srvmock"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/auth"
 	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/logger"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/go-cmp/cmp"
@@ -17,13 +18,21 @@ import (
 	"golang.org/x/exp/slog"
 )
 
+// Secret of tokens accepted by the test handler.
+var testSecret = []byte("test secret")
+
 func setupWordHandler(t *testing.T) (*WordHandler, *srvmock.WordService) {
 	t.Helper()
 	srvMock := srvmock.NewWordService(t)
+	a, err := auth.NewHS256(testSecret, auth.Options{})
+	if err != nil {
+		t.Fatalf("setupWordHandler - auth.NewHS256: %v", err)
+	}
 	h := &WordHandler{
-		wordService: srvMock,
-		logger:      logger.New(slog.LevelDebug),
-		v:           validator.New(),
+		wordService:   srvMock,
+		authenticator: a,
+		logger:        logger.New(slog.LevelDebug),
+		v:             validator.New(),
 	}
 	return h, srvMock
 }
@@ -56,7 +65,7 @@ func Test_userWords(t *testing.T) {
 			args: args{
 				w: httptest.NewRecorder(),
 				r: func() *http.Request {
-					r := httptest.NewRequest(http.MethodGet, "/getWords", nil)
+					r := httptest.NewRequest(http.MethodGet, "/getWords?grouped=true", nil)
 					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
 					return r.WithContext(ctx)
 				}(),
@@ -71,6 +80,73 @@ func Test_userWords(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "Wrong sort",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/getWords?sort=random", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantRes: httpResponse{
+				Path:    "/getWords",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Invalid cursor",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/getWords?cursor=abc", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantRes: httpResponse{
+				Path:    "/getWords",
+				Message: entity.ErrInvalidCursor.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("Words", args.r.Context(), entity.WordsQuery{
+					UserID: "12345",
+					Sort:   entity.WordsSortAdded,
+					Desc:   true,
+					Limit:  defaultWordsLimit,
+					Cursor: "abc",
+				}).Once().Return(nil, entity.ErrInvalidCursor)
+			},
+		},
+		{
+			name: "Internal error of words page",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/getWords?collection=coll&pos=noun&q=ab&due=true&sort=word&order=asc&limit=10", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantRes: httpResponse{
+				Path:    "/getWords",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				due := true
+				srvMock.On("Words", args.r.Context(), entity.WordsQuery{
+					UserID:       "12345",
+					Collection:   "coll",
+					PartOfSpeech: "noun",
+					Search:       "ab",
+					Due:          &due,
+					Sort:         entity.WordsSortWord,
+					Limit:        10,
+				}).Once().Return(nil, errors.New("some internal error"))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -556,6 +632,39 @@ func Test_addWord(t *testing.T) {
 				srvMock.On("AddWord", args.r.Context(), mock.Anything).Once().Return(nil)
 			},
 		},
+		{
+			name: "Language pair of another collection",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/addWord",
+						bytes.NewReader(
+							[]byte(
+								`
+									{
+										"word": "regalo",
+										"collection_name": "deutsch",
+										"last_repeat": "2012-04-23T18:25:43.511Z",
+										"src_lang": "es",
+										"trgt_lang": "en"
+									}
+								`,
+							),
+						))
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantRes: httpResponse{
+				Path:    "/addWord",
+				Message: entity.ErrLanguagePairMismatch.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddWord", args.r.Context(), mock.MatchedBy(func(c entity.Collection) bool {
+					return c.SrcLang == "es" && c.TrgtLang == "en"
+				})).Once().Return(entity.ErrLanguagePairMismatch)
+			},
+		},
 		{
 			name: "Invalid json",
 			args: args{
@@ -686,6 +795,68 @@ func Test_addWord(t *testing.T) {
 					Return(entity.ErrWordNotSupported)
 			},
 		},
+		{
+			name: "Translator unavailable error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/addWord",
+						bytes.NewReader(
+							[]byte(
+								`
+									{
+										"word": "gift",
+										"collection_name": "valid_coll",
+										"last_repeat": "2012-04-23T18:25:43.511Z",
+										"time_diff": 12351213
+									}
+								`,
+							),
+						))
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantRes: httpResponse{
+				Path:    "/addWord",
+				Message: entity.ErrTranslatorUnavailable.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddWord", mock.Anything, mock.Anything).Once().
+					Return(entity.ErrTranslatorUnavailable)
+			},
+		},
+		{
+			name: "Word already in collection error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodGet, "/addWord",
+						bytes.NewReader(
+							[]byte(
+								`
+									{
+										"word": "gift",
+										"collection_name": "valid_coll",
+										"last_repeat": "2012-04-23T18:25:43.511Z",
+										"time_diff": 12351213
+									}
+								`,
+							),
+						))
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantRes: httpResponse{
+				Path:    "/addWord",
+				Message: entity.ErrWordAlreadyInCollection.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("AddWord", mock.Anything, mock.Anything).Once().
+					Return(entity.ErrWordAlreadyInCollection)
+			},
+		},
 		{
 			name: "Without user_id in ctx error",
 			args: args{
@@ -760,3 +931,199 @@ func Test_addWord(t *testing.T) {
 		})
 	}
 }
+
+func Test_reviewWord(t *testing.T) {
+	type args struct {
+		w *httptest.ResponseRecorder
+		r *http.Request
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantRes    httpResponse
+		setupMock  func(srvMock *srvmock.WordService, args args)
+	}{
+		{
+			name: "Invalid json",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodPost, "/review", nil)
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/review",
+				Message: "wrong json format",
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Unknown grade",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodPost, "/review",
+						bytes.NewReader(
+							[]byte(
+								`
+									{
+										"word": "some_word",
+										"collection_name": "valid_coll",
+										"grade": "perfect"
+									}
+								`,
+							),
+						))
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: httpResponse{
+				Path:    "/review",
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Without user_id in ctx error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/review",
+					bytes.NewReader(
+						[]byte(
+							`
+								{
+									"word": "some_word",
+									"collection_name": "valid_coll",
+									"grade": "good"
+								}
+							`,
+						),
+					)),
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes: httpResponse{
+				Path:    "/review",
+				Message: http.StatusText(http.StatusUnauthorized),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {},
+		},
+		{
+			name: "Word not in collection",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodPost, "/review",
+						bytes.NewReader(
+							[]byte(
+								`
+									{
+										"word": "some_word",
+										"collection_name": "valid_coll",
+										"grade": "good"
+									}
+								`,
+							),
+						))
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes: httpResponse{
+				Path:    "/review",
+				Message: entity.ErrWordNotInCollection.Error(),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("Review", args.r.Context(), mock.Anything, entity.GradeGood).Once().
+					Return(entity.Collection{}, entity.ErrWordNotInCollection)
+			},
+		},
+		{
+			name: "Internal error",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodPost, "/review",
+						bytes.NewReader(
+							[]byte(
+								`
+									{
+										"word": "some_word",
+										"collection_name": "valid_coll",
+										"grade": "again"
+									}
+								`,
+							),
+						))
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: httpResponse{
+				Path:    "/review",
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("Review", args.r.Context(), mock.Anything, entity.GradeAgain).Once().
+					Return(entity.Collection{}, errors.New("some internal error"))
+			},
+		},
+		{
+			name: "Valid request",
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					r := httptest.NewRequest(http.MethodPost, "/review",
+						bytes.NewReader(
+							[]byte(
+								`
+									{
+										"word": "some_word",
+										"collection_name": "valid_coll",
+										"grade": "easy"
+									}
+								`,
+							),
+						))
+					ctx := inCtx(r.Context(), userIDCtxKey, "12345")
+					return r.WithContext(ctx)
+				}(),
+			},
+			wantStatus: http.StatusOK,
+			setupMock: func(srvMock *srvmock.WordService, args args) {
+				srvMock.On("Review", args.r.Context(), mock.Anything, entity.GradeEasy).Once().
+					Return(entity.Collection{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		h, srvMock := setupWordHandler(t)
+		tt.setupMock(srvMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			h.reviewWord(tt.args.w, tt.args.r)
+			if tt.args.w.Code != tt.wantStatus {
+				t.Fatalf("wanted status: %v got: %v", tt.wantStatus, tt.args.w.Code)
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+			var gotResponse httpResponse
+			err := json.Unmarshal(tt.args.w.Body.Bytes(), &gotResponse)
+			if err != nil {
+				t.Fatalf("%v - json.Unmarshal: %v", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantRes, gotResponse); diff != "" {
+				t.Fatalf("wanted: %v got: %v dif: %v", tt.wantRes, gotResponse, diff)
+			}
+		})
+	}
+}
@@ -14,6 +14,20 @@ type WordService struct {
 	mock.Mock
 }
 
+// AddCustomWord provides a mock function with given fields: ctx, collection, userTrans
+func (_m *WordService) AddCustomWord(ctx context.Context, collection entity.Collection, userTrans entity.UserTrans) error {
+	ret := _m.Called(ctx, collection, userTrans)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, entity.UserTrans) error); ok {
+		r0 = rf(ctx, collection, userTrans)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddWord provides a mock function with given fields: ctx, collection
 func (_m *WordService) AddWord(ctx context.Context, collection entity.Collection) error {
 	ret := _m.Called(ctx, collection)
@@ -28,6 +42,46 @@ func (_m *WordService) AddWord(ctx context.Context, collection entity.Collection
 	return r0
 }
 
+// AddWords provides a mock function with given fields: ctx, collection, words
+func (_m *WordService) AddWords(ctx context.Context, collection entity.Collection, words []string) ([]entity.AddResult, error) {
+	ret := _m.Called(ctx, collection, words)
+
+	var r0 []entity.AddResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, []string) ([]entity.AddResult, error)); ok {
+		return rf(ctx, collection, words)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, []string) []entity.AddResult); ok {
+		r0 = rf(ctx, collection, words)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AddResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Collection, []string) error); ok {
+		r1 = rf(ctx, collection, words)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CopyWord provides a mock function with given fields: ctx, collection, target
+func (_m *WordService) CopyWord(ctx context.Context, collection entity.Collection, target string) error {
+	ret := _m.Called(ctx, collection, target)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, string) error); ok {
+		r0 = rf(ctx, collection, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteWord provides a mock function with given fields: ctx, collection
 func (_m *WordService) DeleteWord(ctx context.Context, collection entity.Collection) error {
 	ret := _m.Called(ctx, collection)
@@ -42,6 +96,84 @@ func (_m *WordService) DeleteWord(ctx context.Context, collection entity.Collect
 	return r0
 }
 
+// DueWords provides a mock function with given fields: ctx, query
+func (_m *WordService) DueWords(ctx context.Context, query entity.DueQuery) (*entity.ReviewQueue, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 *entity.ReviewQueue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DueQuery) (*entity.ReviewQueue, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DueQuery) *entity.ReviewQueue); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ReviewQueue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.DueQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EditTranslation provides a mock function with given fields: ctx, collection, userTrans
+func (_m *WordService) EditTranslation(ctx context.Context, collection entity.Collection, userTrans entity.UserTrans) error {
+	ret := _m.Called(ctx, collection, userTrans)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, entity.UserTrans) error); ok {
+		r0 = rf(ctx, collection, userTrans)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveWord provides a mock function with given fields: ctx, collection, target
+func (_m *WordService) MoveWord(ctx context.Context, collection entity.Collection, target string) error {
+	ret := _m.Called(ctx, collection, target)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, string) error); ok {
+		r0 = rf(ctx, collection, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Review provides a mock function with given fields: ctx, collection, grade
+func (_m *WordService) Review(ctx context.Context, collection entity.Collection, grade entity.Grade) (entity.Collection, error) {
+	ret := _m.Called(ctx, collection, grade)
+
+	var r0 entity.Collection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, entity.Grade) (entity.Collection, error)); ok {
+		return rf(ctx, collection, grade)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, entity.Grade) entity.Collection); ok {
+		r0 = rf(ctx, collection, grade)
+	} else {
+		r0 = ret.Get(0).(entity.Collection)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Collection, entity.Grade) error); ok {
+		r1 = rf(ctx, collection, grade)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateLearnInterval provides a mock function with given fields: ctx, collection
 func (_m *WordService) UpdateLearnInterval(ctx context.Context, collection entity.Collection) error {
 	ret := _m.Called(ctx, collection)
@@ -82,6 +214,58 @@ func (_m *WordService) UserWords(ctx context.Context, collection entity.Collecti
 	return r0, r1
 }
 
+// WordHistory provides a mock function with given fields: ctx, collection
+func (_m *WordService) WordHistory(ctx context.Context, collection entity.Collection) (*entity.WordHistory, error) {
+	ret := _m.Called(ctx, collection)
+
+	var r0 *entity.WordHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) (*entity.WordHistory, error)); ok {
+		return rf(ctx, collection)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) *entity.WordHistory); ok {
+		r0 = rf(ctx, collection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WordHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Collection) error); ok {
+		r1 = rf(ctx, collection)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Words provides a mock function with given fields: ctx, query
+func (_m *WordService) Words(ctx context.Context, query entity.WordsQuery) (*entity.WordsPage, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 *entity.WordsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WordsQuery) (*entity.WordsPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WordsQuery) *entity.WordsPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WordsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.WordsQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTnewWordService interface {
 	mock.TestingT
 	Cleanup(func())
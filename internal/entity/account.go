@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+type (
+	// Card is a word of a user collection with its learning progress.
+	Card struct {
+		Word           string `json:"word"`
+		CollectionName string `json:"collection_name"`
+		SrcLang        string `json:"src_lang"`
+		TrgtLang       string `json:"trgt_lang"`
+		// Shared translation with user edits on top of it.
+		Translation WordTrans `json:"translation"`
+		AddedAt     time.Time `json:"added_at"`
+		// Nil until the first review.
+		IntroducedAt *time.Time    `json:"introduced_at"`
+		LastRepeat   time.Time     `json:"last_repeat"`
+		TimeDiff     time.Duration `json:"time_diff"`
+		EaseFactor   float64       `json:"ease_factor"`
+		Repetitions  int           `json:"repetitions"`
+		Stability    float64       `json:"stability"`
+		Difficulty   float64       `json:"difficulty"`
+	}
+
+	// Export is a complete archive of user data.
+	Export struct {
+		User        User             `json:"user"`
+		Collections []CollectionInfo `json:"collections"`
+		Cards       []Card           `json:"cards"`
+		// User edits of shared translations and translations of custom cards.
+		Overrides  []UserTrans `json:"overrides"`
+		Reviews    []ReviewLog `json:"reviews"`
+		APIKeys    []APIKey    `json:"api_keys"`
+		ExportedAt time.Time   `json:"exported_at"`
+	}
+
+	// AccountDeletion is an audit record of a request to delete user data, the record is kept after the data is deleted.
+	AccountDeletion struct {
+		ID          int64     `json:"id"`
+		UserID      string    `json:"-"`
+		RequestedAt time.Time `json:"requested_at"`
+		// Nil until the data is deleted.
+		CompletedAt *time.Time `json:"completed_at"`
+		// Number of rows deleted across all tables.
+		DeletedRows int64 `json:"deleted_rows"`
+	}
+)
@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+type (
+	// UsersQuery selects a page of users ordered by ID.
+	UsersQuery struct {
+		Limit int
+		// Opaque cursor of the previous page, decoded into After by the service.
+		Cursor string
+		After  string
+	}
+
+	// UserSummary is a user with numbers of its data rows.
+	UserSummary struct {
+		User
+		Collections int `json:"collections"`
+		Words       int `json:"words"`
+		Reviews     int `json:"reviews"`
+		APIKeys     int `json:"api_keys"`
+	}
+
+	UsersPage struct {
+		Users []UserSummary `json:"users"`
+		// Number of all users.
+		Total int `json:"total"`
+		// Empty on the last page.
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+
+	// StoredTranslation is a shared translation with its refresh state.
+	StoredTranslation struct {
+		WordTrans
+		FetchedAt time.Time `json:"fetched_at"`
+		// Number of user cards with the translation.
+		Cards int `json:"cards"`
+	}
+
+	StoredTranslations struct {
+		Translations []StoredTranslation `json:"translations"`
+	}
+
+	// ProviderStats counts translate requests of a provider since the start of the app.
+	ProviderStats struct {
+		Name     string `json:"name"`
+		Requests int64  `json:"requests"`
+		// Failed requests, unsupported words aren't failures and canceled requests aren't counted.
+		Errors      int64      `json:"errors"`
+		ErrorRate   float64    `json:"error_rate"`
+		LastError   string     `json:"last_error,omitempty"`
+		LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	}
+
+	ProvidersStats struct {
+		Providers []ProviderStats `json:"providers"`
+	}
+)
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// Personal API keys start with APIKeyPrefix, so they can be told apart from JWTs.
+const APIKeyPrefix = "fc_"
+
+type APIKeyScope string
+
+const (
+	// Only requests which don't change data.
+	ScopeReadOnly APIKeyScope = "read-only"
+	// Read requests and changes of words.
+	ScopeWordsWrite APIKeyScope = "words:write"
+)
+
+// APIKey authenticates requests of user scripts, only hash of the key is stored.
+type APIKey struct {
+	ID     int64  `json:"id"`
+	UserID string `json:"-"`
+	Name   string `json:"name"`
+	// First characters of the key to tell keys apart.
+	Prefix string `json:"prefix"`
+	Hash   []byte `json:"-"`
+	// Key without scopes grants access to everything but API keys and admin endpoints.
+	Scopes []APIKeyScope `json:"scopes"`
+	// Key never expires when ExpiresAt is nil.
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// NewAPIKey is a created key with its plain value, the value can't be shown again.
+type NewAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeys struct {
+	Keys []APIKey `json:"keys"`
+}
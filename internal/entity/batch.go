@@ -0,0 +1,18 @@
+package entity
+
+// AddStatus is an outcome of adding a word of a batch.
+type AddStatus string
+
+const (
+	AddStatusAdded          AddStatus = "added"
+	AddStatusAlreadyPresent AddStatus = "already_present"
+	AddStatusNotSupported   AddStatus = "not_supported"
+	AddStatusFailed         AddStatus = "failed"
+)
+
+// AddResult is an outcome of adding a word of a batch, Err is set for failed words.
+type AddResult struct {
+	Word   string
+	Status AddStatus
+	Err    error
+}
@@ -5,11 +5,38 @@ import (
 )
 
 type Collection struct {
-	UserID     string
-	Word       string
-	Name       string
+	UserID string
+	Word   string
+	Name   string
+	// Language pair of the word translation.
+	SrcLang    string
+	TrgtLang   string
 	LastRepeat time.Time
 	// Duration which should be added to LastRepeat.
-	// Each time TimeDiff should be incrementet: 2*TimeDiff + 1
+	// Computed by the scheduler on each review.
 	TimeDiff time.Duration
+	// Scheduler state, see service.Scheduler.
+	EaseFactor  float64
+	Repetitions int
+	// FSRS memory state, zero when card wasn't scheduled by FSRS.
+	Stability  float64
+	Difficulty float64
+}
+
+// CollectionInfo describes a collection of user words, collection can be empty.
+type CollectionInfo struct {
+	ID          int64  `json:"id"`
+	UserID      string `json:"-"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Empty language means the default language of the deployment.
+	SrcLang   string    `json:"src_lang"`
+	TrgtLang  string    `json:"trgt_lang"`
+	CreatedAt time.Time `json:"created_at"`
+	// Number of words in the collection.
+	Words int `json:"words"`
+}
+
+type CollectionsInfo struct {
+	Collections []CollectionInfo `json:"collections"`
 }
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// UserNewLimit as DueQuery.NewLimit means the daily new words limit of user settings.
+const UserNewLimit = -1
+
+type (
+	// DueQuery selects words to review, words that were never reviewed are new.
+	DueQuery struct {
+		UserID string
+		// Optional, all collections of a user if empty.
+		Collection string
+		// Max number of words in a queue.
+		Limit int
+		// Max number of new words introduced per day.
+		NewLimit int
+		Now      time.Time
+		// Start of the user's day, new words reviewed since then count against NewLimit.
+		DayStart time.Time
+	}
+
+	DueWord struct {
+		WordData
+		CollectionName CollectionName `json:"collection_name"`
+		Due            time.Time      `json:"due"`
+		New            bool           `json:"new"`
+	}
+
+	DueWords struct {
+		// Ordered by overdue-ness, most overdue first.
+		Reviews []DueWord
+		New     []DueWord
+	}
+
+	ReviewQueue struct {
+		Words []DueWord `json:"words"`
+	}
+)
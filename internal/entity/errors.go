@@ -2,4 +2,21 @@ package entity
 
 import "errors"
 
-var ErrWordNotSupported = errors.New("word not supported")
+var (
+	ErrWordNotSupported        = errors.New("word not supported")
+	ErrWordNotInCollection     = errors.New("word not in collection")
+	ErrWordAlreadyInCollection = errors.New("word already in collection")
+	ErrCollectionNotFound      = errors.New("collection not found")
+	ErrCollectionExists        = errors.New("collection already exists")
+	ErrCollectionNotEmpty      = errors.New("collection not empty")
+	ErrInvalidCursor           = errors.New("invalid cursor")
+	ErrLanguagePairMismatch    = errors.New("language pair doesn't match collection")
+	ErrTranslatorUnavailable   = errors.New("translator temporarily unavailable")
+	ErrUpstreamFormatChanged   = errors.New("upstream response format changed")
+	ErrBudgetExceeded          = errors.New("time budget of the request exceeded")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrUnknownTimezone         = errors.New("unknown time zone")
+	ErrTranslationNotFound     = errors.New("translation not found")
+	ErrTranslationInUse        = errors.New("translation is used by cards")
+)
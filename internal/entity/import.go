@@ -0,0 +1,10 @@
+package entity
+
+// ImportProgress is a state of a dictionary dump import.
+type ImportProgress struct {
+	Source string
+	// Bytes of the dump read so far, import resumes from Offset.
+	Offset int64
+	// Words imported by the current run.
+	Words int
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// StaleQuery selects stored translations which should be translated again.
+type StaleQuery struct {
+	// Translations fetched before are stale, zero time disables the age check.
+	FetchedBefore time.Time
+	// Current versions of the providers, translations of other providers are never stale.
+	Versions map[string]int
+	Limit    int
+}
+
+// RefreshStats counts translations of one refresh run.
+type RefreshStats struct {
+	Refreshed int
+	Failed    int
+}
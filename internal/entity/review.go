@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// Grade is a user's answer to a flash card during a review.
+type Grade string
+
+const (
+	GradeAgain Grade = "again"
+	GradeHard  Grade = "hard"
+	GradeGood  Grade = "good"
+	GradeEasy  Grade = "easy"
+)
+
+// SchedulerName identifies spaced repetition algorithm.
+type SchedulerName string
+
+const (
+	SchedulerSM2  SchedulerName = "sm2"
+	SchedulerFSRS SchedulerName = "fsrs"
+)
+
+type (
+	// ReviewLog is a single answer to a flash card.
+	ReviewLog struct {
+		UserID         string        `json:"-"`
+		Word           string        `json:"word"`
+		CollectionName string        `json:"collection_name"`
+		Grade          Grade         `json:"grade"`
+		Scheduler      SchedulerName `json:"scheduler"`
+		// Time since the previous review or since the word was added.
+		Elapsed      time.Duration `json:"elapsed"`
+		PrevTimeDiff time.Duration `json:"prev_time_diff"`
+		NextTimeDiff time.Duration `json:"next_time_diff"`
+		ReviewedAt   time.Time     `json:"reviewed_at"`
+	}
+
+	WordHistory struct {
+		// Ordered by ReviewedAt.
+		Reviews []ReviewLog `json:"reviews"`
+	}
+)
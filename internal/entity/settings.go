@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Defaults of a user who hasn't changed settings, users table has the same defaults.
+const (
+	DefaultDailyNewLimit = 20
+	DefaultTimezone      = "UTC"
+)
+
+type Settings struct {
+	UserID    string        `json:"-"`
+	Scheduler SchedulerName `json:"scheduler"`
+	// Language pair of words added to collections without a pair, defaults of the deployment are used when empty.
+	SrcLang  string `json:"src_lang"`
+	TrgtLang string `json:"trgt_lang"`
+	// Max number of new words introduced per day.
+	DailyNewLimit int `json:"daily_new_limit"`
+	// IANA time zone, user's day starts at midnight in it.
+	Timezone string `json:"timezone"`
+}
+
+// SettingsPatch changes only set fields of user settings.
+type SettingsPatch struct {
+	UserID        string
+	Scheduler     *SchedulerName
+	SrcLang       *string
+	TrgtLang      *string
+	DailyNewLimit *int
+	Timezone      *string
+}
+
+// User is created on the first authenticated request.
+type User struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Settings
+}
+
+// DefaultSettings returns settings of a new user.
+func DefaultSettings(userID string) Settings {
+	return Settings{
+		UserID:        userID,
+		Scheduler:     SchedulerSM2,
+		DailyNewLimit: DefaultDailyNewLimit,
+		Timezone:      DefaultTimezone,
+	}
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+type (
+	// StatsQuery selects learning statistics of a user, days start at midnight of Timezone.
+	StatsQuery struct {
+		UserID string
+		// Optional, all collections of a user if empty.
+		Collection string
+		// Reviews since From are counted in retention and heatmap.
+		From     time.Time
+		DayStart time.Time
+		// IANA name of the user time zone.
+		Timezone string
+		// Number of days in the forecast starting from DayStart.
+		ForecastDays int
+	}
+
+	// CollectionRetention is a share of passed reviews of cards which had already left the learning phase.
+	CollectionRetention struct {
+		CollectionName CollectionName `json:"collection_name"`
+		Reviews        int            `json:"reviews"`
+		Passed         int            `json:"passed"`
+		Retention      float64        `json:"retention"`
+	}
+
+	DayCount struct {
+		Day   time.Time `json:"day"`
+		Count int       `json:"count"`
+	}
+
+	// Maturity counts cards by their current learn interval.
+	Maturity struct {
+		// Never reviewed.
+		New int `json:"new"`
+		// Failed on the last review or interval under a day.
+		Learning int `json:"learning"`
+		// Interval under three weeks.
+		Young int `json:"young"`
+		// Interval of three weeks or longer.
+		Mature int `json:"mature"`
+	}
+
+	Stats struct {
+		Retention []CollectionRetention `json:"retention"`
+		// Reviews per day, days without reviews are omitted.
+		Heatmap []DayCount `json:"heatmap"`
+		// Cards due per day, overdue cards are due today.
+		Forecast []DayCount `json:"forecast"`
+		Maturity Maturity   `json:"maturity"`
+	}
+)
@@ -0,0 +1,15 @@
+package entity
+
+// UserTrans is a user edit of a word translation, it applies to the word in all user collections.
+// Empty fields keep values of the shared translation, a custom card has only the user translation.
+type UserTrans struct {
+	UserID          string                            `json:"-"`
+	Word            string                            `json:"word"`
+	SrcLang         string                            `json:"source_language"`
+	TrgtLang        string                            `json:"target_language"`
+	Examples        []string                          `json:"examples,omitempty"`
+	Definitions     map[PartOfSpeech][]WordDefinition `json:"definitions_with_examples,omitempty"`
+	Translations    map[PartOfSpeech][]string         `json:"transltions,omitempty"`
+	MainTranslation string                            `json:"main_translation,omitempty"`
+	Provider        string                            `json:"provider,omitempty"`
+}
@@ -7,8 +7,12 @@ type (
 
 	WordData struct {
 		WordTrans
-		LastRepeat time.Time     `json:"last_repeat"`
-		TimeDiff   time.Duration `json:"time_diff"`
+		LastRepeat  time.Time     `json:"last_repeat"`
+		TimeDiff    time.Duration `json:"time_diff"`
+		EaseFactor  float64       `json:"ease_factor"`
+		Repetitions int           `json:"repetitions"`
+		Stability   float64       `json:"stability"`
+		Difficulty  float64       `json:"difficulty"`
 	}
 
 	UserWords struct {
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+const (
+	WordsSortAdded      WordsSort = "added"
+	WordsSortLastRepeat WordsSort = "last_repeat"
+	WordsSortWord       WordsSort = "word"
+)
+
+type (
+	// WordsSort is an order of a words page, ties are broken by word and collection name.
+	WordsSort string
+
+	// WordsQuery selects a page of user words, empty filters are not applied.
+	WordsQuery struct {
+		UserID       string
+		Collection   string
+		PartOfSpeech PartOfSpeech
+		// Substring of a word, case insensitive.
+		Search string
+		// Only due words if true, only not due words if false.
+		Due   *bool
+		Now   time.Time
+		Sort  WordsSort
+		Desc  bool
+		Limit int
+		// Opaque cursor of the previous page, decoded into After by the service.
+		Cursor string
+		After  *WordsCursor
+	}
+
+	// WordsCursor points at the last word of a page.
+	WordsCursor struct {
+		Sort       WordsSort `json:"s"`
+		Desc       bool      `json:"d"`
+		Time       time.Time `json:"t,omitempty"`
+		Word       string    `json:"w"`
+		Collection string    `json:"c"`
+	}
+
+	ListedWord struct {
+		WordData
+		CollectionName CollectionName `json:"collection_name"`
+		AddedAt        time.Time      `json:"added_at"`
+	}
+
+	WordsPage struct {
+		Words []ListedWord `json:"words"`
+		// Empty on the last page.
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+)
@@ -19,5 +19,9 @@ type (
 		Definitions     map[PartOfSpeech][]WordDefinition `json:"definitions_with_examples,omitempty"`
 		Translations    map[PartOfSpeech][]string         `json:"transltions"`
 		MainTranslation string                            `json:"main_translation"`
+		// Name of the provider which produced the translation.
+		Provider string `json:"provider,omitempty"`
+		// Version of the provider adapter, translations of older versions are refreshed.
+		ProviderVersion int `json:"provider_version,omitempty"`
 	}
 )
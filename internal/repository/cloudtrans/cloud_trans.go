@@ -0,0 +1,95 @@
+// Package cloudtrans represents adapter layer for the official Google Cloud Translation API (v2).
+package cloudtrans
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	otelName = "github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/cloudtrans"
+
+	// Version of the adapter, stored translations of older versions are refreshed.
+	Version = 1
+)
+
+var _ = service.TransRepo((*CloudTranslate)(nil))
+
+type response struct {
+	Data struct {
+		Translations []struct {
+			TranslatedText string `json:"translatedText"`
+		} `json:"translations"`
+	} `json:"data"`
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CloudTranslate translates words with the paid API, it provides only the main translation of a word
+// without parts of speech, definitions and examples.
+type CloudTranslate struct {
+	client *http.Client
+	url    string
+	key    string
+}
+
+func (t *CloudTranslate) Translate(ctx context.Context, word, srcLang, trgtLang string) (entity.WordTrans, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "CloudTranslate - Translate")
+	defer span.End()
+
+	form := url.Values{
+		"q":      {word},
+		"source": {srcLang},
+		"target": {trgtLang},
+		"format": {"text"},
+	}
+	reqURL := t.url + "?" + url.Values{"key": {t.key}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return entity.WordTrans{}, fmt.Errorf("CloudTranslate - Translate - http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return entity.WordTrans{}, fmt.Errorf("CloudTranslate - Translate - client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var res response
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return entity.WordTrans{}, fmt.Errorf("CloudTranslate - Translate - Decode: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return entity.WordTrans{}, fmt.Errorf("CloudTranslate - Translate - status %d: %s", resp.StatusCode, res.Error.Message)
+	}
+	if len(res.Data.Translations) == 0 || res.Data.Translations[0].TranslatedText == "" {
+		return entity.WordTrans{}, entity.ErrWordNotSupported
+	}
+
+	return entity.WordTrans{
+		Word:            word,
+		SrcLang:         srcLang,
+		TrgtLang:        trgtLang,
+		MainTranslation: res.Data.Translations[0].TranslatedText,
+	}, nil
+}
+
+func New(apiurl, key string, timeout time.Duration) *CloudTranslate {
+	return &CloudTranslate{
+		client: &http.Client{Timeout: timeout},
+		url:    apiurl,
+		key:    key,
+	}
+}
@@ -0,0 +1,85 @@
+package cloudtrans
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func setupCloudTrans(t *testing.T, status int, body string) *CloudTranslate {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("key") != "test_key" || r.FormValue("q") != "gift" ||
+			r.FormValue("source") != "en" || r.FormValue("target") != "de" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"code": 400, "message": "Invalid Value"}}`))
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL, "test_key", time.Second)
+}
+
+func Test_Translate(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    entity.WordTrans
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name:   "Translated word",
+			status: http.StatusOK,
+			body:   `{"data": {"translations": [{"translatedText": "Geschenk"}]}}`,
+			want:   entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "de", MainTranslation: "Geschenk"},
+		},
+		{
+			name:    "Empty translation",
+			status:  http.StatusOK,
+			body:    `{"data": {"translations": []}}`,
+			wantErr: true,
+			errIs:   entity.ErrWordNotSupported,
+		},
+		{
+			name:    "Quota exceeded",
+			status:  http.StatusForbidden,
+			body:    `{"error": {"code": 403, "message": "Daily Limit Exceeded"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Malformed body",
+			status:  http.StatusOK,
+			body:    `<html></html>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		cloudtrans := setupCloudTrans(t, tt.status, tt.body)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cloudtrans.Translate(ctx, "gift", "en", "de")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if tt.errIs != nil && !cmp.Equal(err, tt.errIs, cmpopts.EquateErrors()) {
+				t.Fatalf("wanted: %v but got: %v", tt.errIs, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("translation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
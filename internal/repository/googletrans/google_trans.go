@@ -4,7 +4,10 @@ package googletrans
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
@@ -13,25 +16,34 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
-const otelName = "github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/googletrans"
+const (
+	otelName = "github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/googletrans"
+
+	// Version of the response parser, it is bumped when parsing is improved so stored translations are refreshed.
+	Version = 1
+)
 
 var _ = service.TransRepo((*GoogleTranslate)(nil))
 
 type GoogleTranslate struct {
-	client          *googletransclient.TranlateClient
-	defaultSrcLang  string
-	defaultTrgtLang string
+	client *googletransclient.TranlateClient
 }
 
-func (t *GoogleTranslate) Translate(ctx context.Context, word string) (entity.WordTrans, error) {
-	_, span := otel.Tracer(otelName).Start(ctx, "GoogleTranslate - Translate")
+func (t *GoogleTranslate) Translate(ctx context.Context, word, srcLang, trgtLang string) (entity.WordTrans, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "GoogleTranslate - Translate")
 	defer span.End()
 
-	response, err := t.client.Translate(word, t.defaultSrcLang, t.defaultTrgtLang)
+	response, err := t.client.Translate(ctx, word, srcLang, trgtLang)
+	if t.unavailable(err) {
+		return entity.WordTrans{}, fmt.Errorf("GoogleTranslate - Translate - client.Translate: %w: %v", entity.ErrTranslatorUnavailable, err)
+	}
 	if err != nil {
 		return entity.WordTrans{}, fmt.Errorf("GoogleTranslate - Translate - client.Translate: %w", err)
 	}
-	wordTrans := t.unmarshal(response)
+	wordTrans, err := t.unmarshal(response)
+	if err != nil {
+		return entity.WordTrans{}, fmt.Errorf("GoogleTranslate - Translate - unmarshal: %w", err)
+	}
 	if len(wordTrans.Translations) == 0 {
 		return entity.WordTrans{}, entity.ErrWordNotSupported
 	}
@@ -39,59 +51,153 @@ func (t *GoogleTranslate) Translate(ctx context.Context, word string) (entity.Wo
 	return wordTrans, nil
 }
 
-func (t *GoogleTranslate) getValidJSON(data []byte) []byte {
-	const validJSONPartIndex = 3
-	return bytes.Split(data, []byte{'\n'})[validJSONPartIndex]
+// Circuit breaker is open or translate is overloaded even after retries.
+func (t *GoogleTranslate) unavailable(err error) bool {
+	var (
+		openErr   *googletransclient.CircuitOpenError
+		statusErr *googletransclient.StatusError
+	)
+	return errors.As(err, &openErr) || (errors.As(err, &statusErr) && statusErr.Temporary())
+}
+
+// Finds payload of the translate RPC. Response is a stream of chunk lengths and JSON arrays of envelopes
+// prefixed with anti XSSI line, envelope of the RPC is ["wrb.fr", rpcID, payload, ...].
+func (t *GoogleTranslate) getEnvelope(data []byte) (gjson.Result, error) {
+	const (
+		xssiPrefix = ")]}'"
+		envelopeID = "wrb.fr"
+		rpcID      = "MkEWBc"
+	)
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte(xssiPrefix))
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var chunk json.RawMessage
+		err := dec.Decode(&chunk)
+		if errors.Is(err, io.EOF) {
+			return gjson.Result{}, fmt.Errorf("%w: no %s envelope of %s", entity.ErrUpstreamFormatChanged, envelopeID, rpcID)
+		}
+		if err != nil {
+			return gjson.Result{}, fmt.Errorf("%w: %v", entity.ErrUpstreamFormatChanged, err)
+		}
+
+		envelopes := gjson.ParseBytes(chunk)
+		if !envelopes.IsArray() {
+			// Chunk length.
+			continue
+		}
+		for _, envelope := range envelopes.Array() {
+			if envelope.Get("0").String() == envelopeID && envelope.Get("1").String() == rpcID {
+				return envelope, nil
+			}
+		}
+	}
+}
+
+// Payload of the envelope is a JSON encoded string, it is null when translate rejects the text.
+func (t *GoogleTranslate) getTrans(data []byte) (gjson.Result, error) {
+	const transPath = "2"
+
+	envelope, err := t.getEnvelope(data)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	payload := envelope.Get(transPath)
+	switch {
+	case payload.Type == gjson.Null:
+		return gjson.Result{}, entity.ErrWordNotSupported
+	case payload.Type != gjson.String || !gjson.Valid(payload.Str):
+		return gjson.Result{}, fmt.Errorf("%w: payload isn't JSON string", entity.ErrUpstreamFormatChanged)
+	}
+
+	wordTransJRes := gjson.Parse(payload.Str)
+	if !wordTransJRes.IsArray() {
+		return gjson.Result{}, fmt.Errorf("%w: payload isn't array", entity.ErrUpstreamFormatChanged)
+	}
+	return wordTransJRes, nil
 }
 
-func (t *GoogleTranslate) getTrans(data []byte) gjson.Result {
-	data = t.getValidJSON(data)
-	const transPath = "0.2"
-	return gjson.Parse(gjson.GetBytes(data, transPath).String())
+// Returns string at the path, missing value is an error only when it is required.
+func (t *GoogleTranslate) getString(res gjson.Result, path string, required bool) (string, error) {
+	value := res.Get(path)
+	switch {
+	case value.Type == gjson.String:
+		return value.Str, nil
+	case value.Type == gjson.Null && !required:
+		return "", nil
+	default:
+		return "", fmt.Errorf("%w: no string at %q", entity.ErrUpstreamFormatChanged, path)
+	}
 }
 
-func (t *GoogleTranslate) getWord(wordTransJRes gjson.Result) string {
+// Returns elements of array at the path, missing or null array has no elements.
+func (t *GoogleTranslate) getArray(res gjson.Result, path string) ([]gjson.Result, error) {
+	value := res.Get(path)
+	switch {
+	case value.IsArray():
+		return value.Array(), nil
+	case value.Type == gjson.Null:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: no array at %q", entity.ErrUpstreamFormatChanged, path)
+	}
+}
+
+func (t *GoogleTranslate) getWord(wordTransJRes gjson.Result) (string, error) {
 	const (
 		wordPath = "1.4.0"
 	)
 
-	return wordTransJRes.Get(wordPath).String()
+	return t.getString(wordTransJRes, wordPath, true)
 }
 
-func (t *GoogleTranslate) getLangs(wordTransJRes gjson.Result) (srcLang, trgtLang string) {
+func (t *GoogleTranslate) getLangs(wordTransJRes gjson.Result) (srcLang, trgtLang string, err error) {
 	const (
 		srcLangPath  = "1.3"
 		trgtLangPath = "1.1"
 	)
-	srcLang = wordTransJRes.Get(srcLangPath).String()
-	trgtLang = wordTransJRes.Get(trgtLangPath).String()
-	return srcLang, trgtLang
+	srcLang, err = t.getString(wordTransJRes, srcLangPath, true)
+	if err != nil {
+		return "", "", err
+	}
+	trgtLang, err = t.getString(wordTransJRes, trgtLangPath, true)
+	if err != nil {
+		return "", "", err
+	}
+	return srcLang, trgtLang, nil
 }
 
-func (t *GoogleTranslate) getMainTranslation(wordTransJRes gjson.Result) string {
+func (t *GoogleTranslate) getMainTranslation(wordTransJRes gjson.Result) (string, error) {
 	const (
 		mainTransPath = "1.0.0.5.0.0"
 	)
-	return wordTransJRes.Get(mainTransPath).String()
+	return t.getString(wordTransJRes, mainTransPath, true)
 }
 
-func (t *GoogleTranslate) getExamples(wordTransJRes gjson.Result) []string {
+func (t *GoogleTranslate) getExamples(wordTransJRes gjson.Result) ([]string, error) {
 	const (
 		examplesPath = "3.2.0"
 		examplePath  = "1"
 	)
-	examplesJRes := wordTransJRes.Get(examplesPath).Array()
+	examplesJRes, err := t.getArray(wordTransJRes, examplesPath)
+	if err != nil {
+		return nil, err
+	}
 	examples := make([]string, 0, len(examplesJRes))
 
 	for _, exampleJRes := range examplesJRes {
-		example := exampleJRes.Get(examplePath).String()
+		example, err := t.getString(exampleJRes, examplePath, true)
+		if err != nil {
+			return nil, err
+		}
 		examples = append(examples, example)
 	}
 
-	return examples
+	return examples, nil
 }
 
-func (t *GoogleTranslate) getPOSDefs(defsJRes []gjson.Result) []entity.WordDefinition {
+func (t *GoogleTranslate) getPOSDefs(defsJRes []gjson.Result) ([]entity.WordDefinition, error) {
 	const (
 		defPath     = "0"
 		examplePath = "1"
@@ -99,8 +205,14 @@ func (t *GoogleTranslate) getPOSDefs(defsJRes []gjson.Result) []entity.WordDefin
 	defsWithExamples := make([]entity.WordDefinition, 0, len(defsJRes))
 
 	for _, defJRes := range defsJRes {
-		def := defJRes.Get(defPath).String()
-		example := defJRes.Get(examplePath).String()
+		def, err := t.getString(defJRes, defPath, true)
+		if err != nil {
+			return nil, err
+		}
+		example, err := t.getString(defJRes, examplePath, false)
+		if err != nil {
+			return nil, err
+		}
 
 		defsWithExamples = append(
 			defsWithExamples,
@@ -108,77 +220,122 @@ func (t *GoogleTranslate) getPOSDefs(defsJRes []gjson.Result) []entity.WordDefin
 		)
 	}
 
-	return defsWithExamples
+	return defsWithExamples, nil
 }
 
 func (t *GoogleTranslate) getDefs(
 	wordTransJRes gjson.Result,
-) map[entity.PartOfSpeech][]entity.WordDefinition {
+) (map[entity.PartOfSpeech][]entity.WordDefinition, error) {
 	const (
-		defsPath = "3.1.0"
-		POSPath  = "0"
+		defsPath    = "3.1.0"
+		POSPath     = "0"
+		POSDefsPath = "1"
 	)
-	POSDefsJRes := wordTransJRes.Get(defsPath).Array()
+	POSDefsJRes, err := t.getArray(wordTransJRes, defsPath)
+	if err != nil {
+		return nil, err
+	}
 	defs := make(map[entity.PartOfSpeech][]entity.WordDefinition, len(POSDefsJRes))
 
-	for _, defsJRes := range POSDefsJRes {
-		POS := entity.PartOfSpeech(defsJRes.Get(POSPath).String())
-		defs[POS] = t.getPOSDefs(defsJRes.Get("1").Array())
+	for _, POSJRes := range POSDefsJRes {
+		POS, err := t.getString(POSJRes, POSPath, true)
+		if err != nil {
+			return nil, err
+		}
+		defsJRes, err := t.getArray(POSJRes, POSDefsPath)
+		if err != nil {
+			return nil, err
+		}
+		defs[entity.PartOfSpeech(POS)], err = t.getPOSDefs(defsJRes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return defs
+	return defs, nil
 }
 
-func (t *GoogleTranslate) getPOSTransltions(transJRes []gjson.Result) []string {
+func (t *GoogleTranslate) getPOSTransltions(transJRes []gjson.Result) ([]string, error) {
 	const (
 		transPath = "0"
 	)
 	trans := make([]string, 0, len(transJRes))
 
 	for _, tJres := range transJRes {
-		t := tJres.Get(transPath).String()
+		t, err := t.getString(tJres, transPath, true)
+		if err != nil {
+			return nil, err
+		}
 		trans = append(trans, t)
 	}
 
-	return trans
+	return trans, nil
 }
 
 func (t *GoogleTranslate) getTranslations(
 	wordTransJRes gjson.Result,
-) map[entity.PartOfSpeech][]string {
+) (map[entity.PartOfSpeech][]string, error) {
 	const (
 		POSPath          = "0"
+		POSTransPath     = "1"
 		translationsPath = "3.5.0"
 	)
-	POStransJRes := wordTransJRes.Get(translationsPath).Array()
+	POStransJRes, err := t.getArray(wordTransJRes, translationsPath)
+	if err != nil {
+		return nil, err
+	}
 	trans := make(map[entity.PartOfSpeech][]string, len(POStransJRes))
 
-	for _, transJRes := range POStransJRes {
-		POS := entity.PartOfSpeech(transJRes.Get(POSPath).String())
-		trans[POS] = t.getPOSTransltions(transJRes.Get("1").Array())
+	for _, POSJRes := range POStransJRes {
+		POS, err := t.getString(POSJRes, POSPath, true)
+		if err != nil {
+			return nil, err
+		}
+		transJRes, err := t.getArray(POSJRes, POSTransPath)
+		if err != nil {
+			return nil, err
+		}
+		trans[entity.PartOfSpeech(POS)], err = t.getPOSTransltions(transJRes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return trans
+	return trans, nil
 }
 
-func (t *GoogleTranslate) unmarshal(data []byte) entity.WordTrans {
-	wordTransJRes := t.getTrans(data)
+// Unmarshals the response, ErrUpstreamFormatChanged is returned when it doesn't have the expected structure.
+func (t *GoogleTranslate) unmarshal(data []byte) (entity.WordTrans, error) {
+	wordTransJRes, err := t.getTrans(data)
+	if err != nil {
+		return entity.WordTrans{}, err
+	}
 	var wordTrans entity.WordTrans
 
-	wordTrans.Translations = t.getTranslations(wordTransJRes)
-	wordTrans.Word = t.getWord(wordTransJRes)
-	wordTrans.SrcLang, wordTrans.TrgtLang = t.getLangs(wordTransJRes)
-	wordTrans.MainTranslation = t.getMainTranslation(wordTransJRes)
-	wordTrans.Examples = t.getExamples(wordTransJRes)
-	wordTrans.Definitions = t.getDefs(wordTransJRes)
+	if wordTrans.Translations, err = t.getTranslations(wordTransJRes); err != nil {
+		return entity.WordTrans{}, err
+	}
+	if wordTrans.Word, err = t.getWord(wordTransJRes); err != nil {
+		return entity.WordTrans{}, err
+	}
+	if wordTrans.SrcLang, wordTrans.TrgtLang, err = t.getLangs(wordTransJRes); err != nil {
+		return entity.WordTrans{}, err
+	}
+	if wordTrans.MainTranslation, err = t.getMainTranslation(wordTransJRes); err != nil {
+		return entity.WordTrans{}, err
+	}
+	if wordTrans.Examples, err = t.getExamples(wordTransJRes); err != nil {
+		return entity.WordTrans{}, err
+	}
+	if wordTrans.Definitions, err = t.getDefs(wordTransJRes); err != nil {
+		return entity.WordTrans{}, err
+	}
 
-	return wordTrans
+	return wordTrans, nil
 }
 
-func New(client *googletransclient.TranlateClient, defaultSrcLang, defaultTrgtLang string) *GoogleTranslate {
+func New(client *googletransclient.TranlateClient) *GoogleTranslate {
 	return &GoogleTranslate{
-		client:          client,
-		defaultSrcLang:  defaultSrcLang,
-		defaultTrgtLang: defaultTrgtLang,
+		client: client,
 	}
 }
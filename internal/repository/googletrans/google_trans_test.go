@@ -2,36 +2,52 @@ package googletrans
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/Kin-dza-dzaa/flash_cards_api/config"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/googletransclient"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/googletransclient/fake"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
-func setupGoogleTrans(t *testing.T) *GoogleTranslate {
+var update = flag.Bool("update", false, "update golden files")
+
+// Translator is backed by fake server which replays recorded responses.
+func setupGoogleTrans(t *testing.T) (*GoogleTranslate, *fake.Server) {
 	t.Helper()
-	cfg, err := config.ReadConfig()
+	srv, err := fake.New(fake.Options{})
 	if err != nil {
-		t.Fatalf("setupGoogleTrans - config.ReadConfig: %v", err)
+		t.Fatalf("setupGoogleTrans - fake.New: %v", err)
 	}
+	t.Cleanup(srv.Close)
 
-	gc, err := googletransclient.New(cfg.GoogleAPI.URL)
+	gc, err := googletransclient.New(srv.URL(), googletransclient.Options{
+		Timeout: time.Second,
+		CAFile:  srv.CAFile(),
+	})
 	if err != nil {
 		t.Fatalf("setupGoogleTrans - googletransclient.New: %v", err)
 	}
 
-	return New(gc, cfg.GoogleAPI.DefaultSrcLang, cfg.GoogleAPI.DefaultTrgtLang)
+	return New(gc), srv
 }
 
-// Test makes real calls to google translate api.
 func Test_Translate(t *testing.T) {
 	tests := []struct {
-		name    string
-		word    string
-		wantErr error
+		name     string
+		word     string
+		srcLang  string
+		trgtLang string
+		faults   []fake.Fault
+		wantErr  error
 	}{
 		{
 			name:    "Unsupported word",
@@ -43,17 +59,195 @@ func Test_Translate(t *testing.T) {
 			word:    "lead",
 			wantErr: nil,
 		},
+		{
+			name:     "Supported word other language pair",
+			word:     "Geschenk",
+			srcLang:  "de",
+			trgtLang: "es",
+			wantErr:  nil,
+		},
+		{
+			name:    "Phrase",
+			word:    "break a leg",
+			wantErr: entity.ErrWordNotSupported,
+		},
+		{
+			name:    "Malformed response",
+			word:    "lead",
+			faults:  []fake.Fault{fake.Malformed},
+			wantErr: entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:    "Too many requests",
+			word:    "lead",
+			faults:  []fake.Fault{fake.TooManyRequests},
+			wantErr: entity.ErrTranslatorUnavailable,
+		},
 	}
 
 	for _, tt := range tests {
 		ctx := context.Background()
-		googletrans := setupGoogleTrans(t)
+		googletrans, srv := setupGoogleTrans(t)
+		srv.Inject(tt.faults...)
+		if tt.srcLang == "" {
+			tt.srcLang, tt.trgtLang = "en", "ru"
+		}
 
 		t.Run(tt.name, func(t *testing.T) {
-			_, gotErr := googletrans.Translate(ctx, tt.word)
+			_, gotErr := googletrans.Translate(ctx, tt.word, tt.srcLang, tt.trgtLang)
 			if !cmp.Equal(gotErr, tt.wantErr, cmpopts.EquateErrors()) {
 				t.Fatalf("wanted: %v but got: %v", tt.wantErr, gotErr)
 			}
 		})
 	}
 }
+
+// Unmarshaled translation or error of the response, it is stored in golden files.
+type golden struct {
+	Trans *entity.WordTrans `json:"translation,omitempty"`
+	Err   string            `json:"error,omitempty"`
+}
+
+// Test unmarshals responses of testdata and compares them with golden files, run with -update to rewrite them.
+func Test_unmarshal(t *testing.T) {
+	responses, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if err != nil {
+		t.Fatalf("Test_unmarshal - filepath.Glob: %v", err)
+	}
+	if len(responses) == 0 {
+		t.Fatal("Test_unmarshal - no responses in testdata")
+	}
+
+	for _, response := range responses {
+		googletrans := New(nil)
+		goldenFile := strings.TrimSuffix(response, ".txt") + ".golden.json"
+
+		t.Run(filepath.Base(response), func(t *testing.T) {
+			data, err := os.ReadFile(response)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+
+			var got golden
+			wordTrans, err := googletrans.unmarshal(data)
+			if err != nil {
+				got.Err = err.Error()
+			} else {
+				got.Trans = &wordTrans
+			}
+
+			if *update {
+				data, err := json.MarshalIndent(got, "", "\t")
+				if err != nil {
+					t.Fatalf("MarshalIndent: %v", err)
+				}
+				if err := os.WriteFile(goldenFile, append(data, '\n'), 0o644); err != nil {
+					t.Fatalf("WriteFile: %v", err)
+				}
+			}
+
+			data, err = os.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			var want golden
+			if err := json.Unmarshal(data, &want); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+
+			if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("unmarshaled response doesn't match golden file (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_unmarshalMalformed(t *testing.T) {
+	const validPayload = `[null,[[[null,null,null,true,null,[[\"вести\"]]]],\"ru\",1,\"en\",[\"lead\"]],\"en\",null]`
+	envelope := func(payload string) string {
+		return ")]}'\n\n100\n[[\"wrb.fr\",\"MkEWBc\"," + payload + ",null,null,null,\"generic\"]]\n"
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		wantErr  error
+	}{
+		{
+			name:     "Valid response",
+			response: envelope(`"` + validPayload + `"`),
+			wantErr:  nil,
+		},
+		{
+			name:     "Empty response",
+			response: "",
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "Only prefix",
+			response: ")]}'\n",
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "Not JSON",
+			response: "<html><body>Sorry...</body></html>",
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "Truncated chunk",
+			response: envelope(`"` + validPayload + `"`)[:40],
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "No envelope of RPC",
+			response: ")]}'\n\n24\n[[\"e\",4,null,null,615]]\n",
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "Rejected text",
+			response: envelope("null"),
+			wantErr:  entity.ErrWordNotSupported,
+		},
+		{
+			name:     "Payload isn't string",
+			response: envelope("[1,2]"),
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "Payload isn't JSON",
+			response: envelope(`"[null,"`),
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "Payload isn't array",
+			response: envelope(`"{}"`),
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "No main translation",
+			response: envelope(`"[null,[[],\"ru\",1,\"en\",[\"lead\"]],\"en\",null]"`),
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "Translations aren't array",
+			response: envelope(`"` + strings.TrimSuffix(validPayload, "null]") + `[null,null,null,null,null,[\"noun\"]]]"`),
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+		{
+			name:     "Translation isn't string",
+			response: envelope(`"` + strings.TrimSuffix(validPayload, "null]") + `[null,null,null,null,null,[[[\"noun\",[[1]]]]]]]"`),
+			wantErr:  entity.ErrUpstreamFormatChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		googletrans := New(nil)
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, gotErr := googletrans.unmarshal([]byte(tt.response))
+			if !errors.Is(gotErr, tt.wantErr) || (tt.wantErr == nil && gotErr != nil) {
+				t.Fatalf("wanted: %v but got: %v", tt.wantErr, gotErr)
+			}
+		})
+	}
+}
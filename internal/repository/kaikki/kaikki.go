@@ -0,0 +1,233 @@
+// Package kaikki represents adapter layer for Wiktionary JSONL dumps extracted by wiktextract (https://kaikki.org).
+package kaikki
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	otelName = "github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/kaikki"
+
+	// Provider name of imported translations.
+	Provider = "kaikki"
+	// Version of the dump parser.
+	Version = 1
+
+	maxExamples = 10
+)
+
+var _ = service.DumpRepo((*Dump)(nil))
+
+// Wiktextract parts of speech which differ from google ones.
+var posNames = map[string]entity.PartOfSpeech{
+	"adj":    "adjective",
+	"adv":    "adverb",
+	"pron":   "pronoun",
+	"prep":   "preposition",
+	"conj":   "conjunction",
+	"intj":   "interjection",
+	"abbrev": "abbreviation",
+	"name":   "noun",
+}
+
+// Removes stress marks of translations, e.g. "пода́рок".
+var stressMarks = strings.NewReplacer("\u0301", "", "\u0300", "")
+
+type (
+	translation struct {
+		Code string `json:"code"`
+		Word string `json:"word"`
+	}
+
+	// Dump line, a word with one part of speech.
+	entry struct {
+		Word     string `json:"word"`
+		POS      string `json:"pos"`
+		LangCode string `json:"lang_code"`
+		Senses   []struct {
+			Glosses  []string `json:"glosses"`
+			Examples []struct {
+				Text string `json:"text"`
+			} `json:"examples"`
+			Translations []translation `json:"translations"`
+		} `json:"senses"`
+		Translations []translation `json:"translations"`
+	}
+)
+
+// Dump reads words of the source language which have translations to the target language,
+// the dump can be gzipped.
+type Dump struct {
+	path     string
+	srcLang  string
+	trgtLang string
+}
+
+// Words calls fn for each word, lines of a word are merged if they follow each other as they do in the dumps.
+// Offsets are offsets in the uncompressed dump.
+func (d *Dump) Words(ctx context.Context, offset int64, fn func(wordTrans entity.WordTrans, next int64) error) (int64, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "KaikkiDump - Words")
+	defer span.End()
+
+	r, closeDump, err := d.open(offset)
+	if err != nil {
+		return 0, fmt.Errorf("Dump - Words - d.open: %w", err)
+	}
+	defer closeDump()
+
+	var (
+		wordTrans entity.WordTrans
+		reader    = bufio.NewReaderSize(r, 1<<20)
+	)
+	// Current word is complete when a line of another word is read.
+	emit := func(next int64) error {
+		if len(wordTrans.Translations) == 0 {
+			return nil
+		}
+		return fn(wordTrans, next)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return offset, err
+		}
+
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return offset, fmt.Errorf("Dump - Words - ReadBytes: %w", err)
+		}
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			offset += int64(len(line))
+			continue
+		}
+		var e entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return offset, fmt.Errorf("Dump - Words - Unmarshal at %d: %w", offset, err)
+		}
+		if e.LangCode == d.srcLang && e.Word != wordTrans.Word {
+			if err := emit(offset); err != nil {
+				return offset, err
+			}
+			wordTrans = entity.WordTrans{
+				Word:            e.Word,
+				SrcLang:         d.srcLang,
+				TrgtLang:        d.trgtLang,
+				Translations:    make(map[entity.PartOfSpeech][]string),
+				Definitions:     make(map[entity.PartOfSpeech][]entity.WordDefinition),
+				Provider:        Provider,
+				ProviderVersion: Version,
+			}
+		}
+		offset += int64(len(line))
+
+		if e.LangCode == d.srcLang {
+			d.merge(&wordTrans, e)
+		}
+	}
+
+	if err := emit(offset); err != nil {
+		return offset, err
+	}
+	return offset, nil
+}
+
+// Adds translations, definitions and examples of the entry to the word.
+func (d *Dump) merge(wordTrans *entity.WordTrans, e entry) {
+	pos, ok := posNames[e.POS]
+	if !ok {
+		pos = entity.PartOfSpeech(e.POS)
+	}
+
+	translations := e.Translations
+	for _, sense := range e.Senses {
+		translations = append(translations, sense.Translations...)
+
+		if len(sense.Glosses) == 0 {
+			continue
+		}
+		// The last gloss is the most specific one, previous ones are glosses of parent senses.
+		def := entity.WordDefinition{Definition: sense.Glosses[len(sense.Glosses)-1]}
+		for _, example := range sense.Examples {
+			if def.Example == "" {
+				def.Example = example.Text
+			}
+			if len(wordTrans.Examples) < maxExamples {
+				wordTrans.Examples = append(wordTrans.Examples, example.Text)
+			}
+		}
+		wordTrans.Definitions[pos] = append(wordTrans.Definitions[pos], def)
+	}
+
+	for _, t := range translations {
+		word := stressMarks.Replace(strings.TrimSpace(t.Word))
+		if t.Code != d.trgtLang || word == "" || contains(wordTrans.Translations[pos], word) {
+			continue
+		}
+		wordTrans.Translations[pos] = append(wordTrans.Translations[pos], word)
+		if wordTrans.MainTranslation == "" {
+			wordTrans.MainTranslation = word
+		}
+	}
+}
+
+// Opens dump at the offset of uncompressed data.
+func (d *Dump) open(offset int64) (io.Reader, func(), error) {
+	f, err := os.Open(d.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("os.Open: %w", err)
+	}
+	closeDump := func() { f.Close() }
+
+	if !strings.HasSuffix(d.path, ".gz") {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			closeDump()
+			return nil, nil, fmt.Errorf("Seek: %w", err)
+		}
+		return f, closeDump, nil
+	}
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		closeDump()
+		return nil, nil, fmt.Errorf("gzip.NewReader: %w", err)
+	}
+	// Gzip can't seek, already imported part is skipped.
+	if _, err := io.CopyN(io.Discard, gz, offset); err != nil {
+		closeDump()
+		return nil, nil, fmt.Errorf("CopyN: %w", err)
+	}
+	return gz, closeDump, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func New(path, srcLang, trgtLang string) *Dump {
+	return &Dump{
+		path:     path,
+		srcLang:  srcLang,
+		trgtLang: trgtLang,
+	}
+}
@@ -0,0 +1,138 @@
+package kaikki
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+const dump = `{"word": "gift", "pos": "noun", "lang_code": "en", "senses": [{"glosses": ["Something given to another voluntarily."], "examples": [{"text": "a birthday gift"}]}], "translations": [{"code": "ru", "word": "пода́рок"}, {"code": "de", "word": "Geschenk"}]}
+{"word": "gift", "pos": "verb", "lang_code": "en", "senses": [{"glosses": ["To give as a gift."], "translations": [{"code": "ru", "word": "дари́ть"}]}]}
+{"word": "Gift", "pos": "noun", "lang_code": "de", "senses": [{"glosses": ["poison"]}]}
+
+{"word": "lead", "pos": "verb", "lang_code": "en", "senses": [{"glosses": ["To guide."]}], "translations": [{"code": "de", "word": "führen"}]}
+{"word": "regalo", "pos": "noun", "lang_code": "es", "translations": [{"code": "ru", "word": "подарок"}]}
+{"word": "bank", "pos": "noun", "lang_code": "en", "senses": [{"glosses": ["Financial institution.", "A branch of a bank."]}], "translations": [{"code": "ru", "word": "банк"}]}
+`
+
+var (
+	gift = entity.WordTrans{
+		Word:     "gift",
+		SrcLang:  "en",
+		TrgtLang: "ru",
+		Examples: []string{"a birthday gift"},
+		Definitions: map[entity.PartOfSpeech][]entity.WordDefinition{
+			"noun": {{Definition: "Something given to another voluntarily.", Example: "a birthday gift"}},
+			"verb": {{Definition: "To give as a gift."}},
+		},
+		Translations:    map[entity.PartOfSpeech][]string{"noun": {"подарок"}, "verb": {"дарить"}},
+		MainTranslation: "подарок",
+		Provider:        Provider,
+		ProviderVersion: Version,
+	}
+	bank = entity.WordTrans{
+		Word:     "bank",
+		SrcLang:  "en",
+		TrgtLang: "ru",
+		Definitions: map[entity.PartOfSpeech][]entity.WordDefinition{
+			"noun": {{Definition: "A branch of a bank."}},
+		},
+		Translations:    map[entity.PartOfSpeech][]string{"noun": {"банк"}},
+		MainTranslation: "банк",
+		Provider:        Provider,
+		ProviderVersion: Version,
+	}
+)
+
+// Writes the dump into a temporary file, the file is gzipped if name ends with .gz.
+func setupDump(t *testing.T, name string) string {
+	t.Helper()
+	data := []byte(dump)
+	if filepath.Ext(name) == ".gz" {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, _ = w.Write(data)
+		_ = w.Close()
+		data = buf.Bytes()
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	return path
+}
+
+func Test_Words(t *testing.T) {
+	// Offset of the "lead" line, the first line after "gift".
+	leadOffset := int64(bytes.Index([]byte(dump), []byte(`{"word": "lead"`)))
+	bankOffset := int64(bytes.Index([]byte(dump), []byte(`{"word": "bank"`)))
+	tests := []struct {
+		name      string
+		file      string
+		offset    int64
+		wantWords []entity.WordTrans
+		wantNext  []int64
+	}{
+		{
+			name:      "Whole dump",
+			file:      "dump.jsonl",
+			wantWords: []entity.WordTrans{gift, bank},
+			wantNext:  []int64{leadOffset, int64(len(dump))},
+		},
+		{
+			name:      "Gzipped dump",
+			file:      "dump.jsonl.gz",
+			wantWords: []entity.WordTrans{gift, bank},
+			wantNext:  []int64{leadOffset, int64(len(dump))},
+		},
+		{
+			name:      "Resumed dump",
+			file:      "dump.jsonl",
+			offset:    leadOffset,
+			wantWords: []entity.WordTrans{bank},
+			wantNext:  []int64{int64(len(dump))},
+		},
+		{
+			name:      "Resumed gzipped dump",
+			file:      "dump.jsonl.gz",
+			offset:    bankOffset,
+			wantWords: []entity.WordTrans{bank},
+			wantNext:  []int64{int64(len(dump))},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		d := New(setupDump(t, tt.file), "en", "ru")
+
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				words []entity.WordTrans
+				next  []int64
+			)
+			end, err := d.Words(ctx, tt.offset, func(wordTrans entity.WordTrans, n int64) error {
+				words, next = append(words, wordTrans), append(next, n)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("want nil but got: %v", err)
+			}
+			if end != int64(len(dump)) {
+				t.Fatalf("want end %v but got: %v", len(dump), end)
+			}
+			if diff := cmp.Diff(tt.wantWords, words); diff != "" {
+				t.Fatalf("words mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantNext, next); diff != "" {
+				t.Fatalf("offsets mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package localdict
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+)
+
+type format int
+
+const (
+	formatStarDict format = iota
+	formatDSL
+)
+
+// Translations of an article without part of speech labels.
+const otherPOS entity.PartOfSpeech = "other"
+
+// Part of speech labels used by dictionaries, keys are lower case without dots.
+var posLabels = map[string]entity.PartOfSpeech{
+	"n": "noun", "noun": "noun", "сущ": "noun",
+	"v": "verb", "vt": "verb", "vi": "verb", "verb": "verb", "гл": "verb",
+	"a": "adjective", "adj": "adjective", "adjective": "adjective", "прил": "adjective",
+	"adv": "adverb", "adverb": "adverb", "нареч": "adverb",
+	"pron": "pronoun", "pronoun": "pronoun", "мест": "pronoun",
+	"prep": "preposition", "preposition": "preposition", "предл": "preposition",
+	"conj": "conjunction", "conjunction": "conjunction", "союз": "conjunction",
+	"int": "interjection", "interj": "interjection", "interjection": "interjection", "межд": "interjection",
+	"abbr": "abbreviation", "abbreviation": "abbreviation", "сокр": "abbreviation",
+}
+
+// Article line without markup.
+type line struct {
+	// Part of speech set by the line, empty if line doesn't have a label.
+	pos     entity.PartOfSpeech
+	text    string
+	example bool
+}
+
+var (
+	dslLabel      = regexp.MustCompile(`\[p\](.*?)\[/p\]`)
+	dslDropped    = regexp.MustCompile(`\{\{.*?\}\}|\[(t|s)\].*?\[/(t|s)\]|\[[^\]]*\]`)
+	xdxfLabel     = regexp.MustCompile(`<abr>(.*?)</abr>`)
+	tags          = regexp.MustCompile(`<[^>]*>`)
+	brTag         = regexp.MustCompile(`(?i)<br\s*/?>`)
+	transcription = regexp.MustCompile(`\[[^\]]*\]`)
+	numbering     = regexp.MustCompile(`^(\d+[.)]|[a-zа-я]\))\s*`)
+	remarks       = regexp.MustCompile(`\([^)]*\)`)
+)
+
+func (d *dictionary) lines(data []byte) []line {
+	if d.format == formatDSL {
+		return dslLines(string(data))
+	}
+
+	var lines []line
+	fields, types := starDictFields(data, d.types)
+	for i, field := range fields {
+		switch types[i] {
+		case 'm', 'l', 'g', 't', 'y':
+			lines = append(lines, plainLines(field)...)
+		case 'h':
+			lines = append(lines, plainLines(html.UnescapeString(tags.ReplaceAllString(brTag.ReplaceAllString(field, "\n"), "")))...)
+		case 'x':
+			lines = append(lines, xdxfLines(field)...)
+		}
+	}
+	return lines
+}
+
+func dslLines(article string) []line {
+	var lines []line
+	for _, raw := range strings.Split(article, "\n") {
+		l := line{example: strings.Contains(raw, "[ex]")}
+		for _, label := range dslLabel.FindAllStringSubmatch(raw, -1) {
+			if pos, ok := posLabel(label[1]); ok {
+				l.pos = pos
+				break
+			}
+		}
+		raw = dslLabel.ReplaceAllString(raw, "")
+		raw = strings.NewReplacer(`\[`, "\x00", `\]`, "\x01").Replace(raw)
+		raw = dslDropped.ReplaceAllString(raw, "")
+		raw = strings.NewReplacer("\x00", "[", "\x01", "]", `\`, "").Replace(raw)
+		l.text = strings.TrimSpace(raw)
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+func xdxfLines(article string) []line {
+	var lines []line
+	for _, raw := range strings.Split(brTag.ReplaceAllString(article, "\n"), "\n") {
+		l := line{example: strings.Contains(raw, "<ex>")}
+		for _, label := range xdxfLabel.FindAllStringSubmatch(raw, -1) {
+			if pos, ok := posLabel(label[1]); ok {
+				l.pos = pos
+				break
+			}
+		}
+		raw = xdxfLabel.ReplaceAllString(raw, "")
+		if k := strings.Index(raw, "</k>"); k >= 0 {
+			// Headword is repeated in the article.
+			raw = raw[k+len("</k>"):]
+		}
+		l.text = strings.TrimSpace(html.UnescapeString(tags.ReplaceAllString(raw, "")))
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// Plain text articles mark parts of speech by a leading label like "n." or "_v.".
+func plainLines(article string) []line {
+	var lines []line
+	for _, raw := range strings.Split(article, "\n") {
+		raw = strings.TrimSpace(transcription.ReplaceAllString(raw, ""))
+		l := line{text: raw}
+		for {
+			label, rest, _ := strings.Cut(l.text, " ")
+			// Short labels must be marked, otherwise "a" of "a gift" would be an adjective.
+			marked := strings.HasPrefix(label, "_") || strings.HasSuffix(label, ".")
+			pos, ok := posLabel(label)
+			if !ok || (!marked && utf8.RuneCountInString(label) < 4) {
+				break
+			}
+			l.pos, l.text = pos, strings.TrimSpace(rest)
+		}
+		l.example = !strings.HasPrefix(l.text, "—") && strings.Contains(l.text, " — ")
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+func posLabel(label string) (entity.PartOfSpeech, bool) {
+	label = strings.ToLower(strings.Trim(strings.TrimSpace(label), "_."))
+	pos, ok := posLabels[label]
+	return pos, ok
+}
+
+// Adds translations and examples of an article to wordTrans.
+func mergeArticle(wordTrans *entity.WordTrans, lines []line) {
+	pos := otherPOS
+	for _, l := range lines {
+		if l.pos != "" {
+			pos = l.pos
+		}
+		if l.text == "" {
+			continue
+		}
+		if l.example {
+			wordTrans.Examples = append(wordTrans.Examples, l.text)
+			continue
+		}
+
+		text := remarks.ReplaceAllString(numbering.ReplaceAllString(l.text, ""), "")
+		for _, trans := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' }) {
+			trans = strings.TrimSpace(trans)
+			if trans == "" || strings.EqualFold(trans, wordTrans.Word) || contains(wordTrans.Translations[pos], trans) {
+				continue
+			}
+			wordTrans.Translations[pos] = append(wordTrans.Translations[pos], trans)
+			if wordTrans.MainTranslation == "" {
+				wordTrans.MainTranslation = trans
+			}
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
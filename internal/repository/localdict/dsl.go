@@ -0,0 +1,112 @@
+package localdict
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Loads ABBYY Lingvo DSL dictionary, DSL files are usually small so articles are kept in memory.
+func (d *LocalDict) loadDSL(pair langPair, path string) error {
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasSuffix(path, ".dz") {
+		data, err = readGzip(path)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	dict := &dictionary{name: path, format: formatDSL}
+	var (
+		articles  strings.Builder
+		headwords []string
+		inBody    bool
+		start     int
+	)
+	// Article of the collected headwords ends with a new headword or the end of file.
+	flush := func() {
+		size := articles.Len() - start
+		for _, headword := range headwords {
+			d.add(pair, headword, entry{dict: dict, offset: int64(start), size: int64(size)})
+		}
+		headwords, inBody, start = headwords[:0], false, articles.Len()
+	}
+
+	for _, line := range strings.Split(decodeDSL(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(line, "#"):
+			if name := strings.TrimPrefix(line, "#NAME"); name != line {
+				dict.name = strings.Trim(strings.TrimSpace(name), `"`)
+			}
+		case strings.TrimSpace(line) == "":
+		case line[0] == ' ' || line[0] == '\t':
+			inBody = true
+			articles.WriteString(strings.TrimSpace(line))
+			articles.WriteByte('\n')
+		default:
+			if inBody {
+				flush()
+			}
+			headwords = append(headwords, dslHeadword(line))
+		}
+	}
+	flush()
+
+	dict.data = strings.NewReader(articles.String())
+	return nil
+}
+
+// Decodes UTF-16 files with BOM, other files are treated as UTF-8.
+func decodeDSL(data []byte) string {
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		order = binary.BigEndian
+	default:
+		return strings.TrimPrefix(string(data), "\uFEFF")
+	}
+
+	data = data[2:]
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// Removes unsorted parts and escapes of a headword, unsorted parts aren't indexed.
+func dslHeadword(line string) string {
+	var b strings.Builder
+	escaped, unsorted := false, false
+	for len(line) > 0 {
+		r, size := utf8.DecodeRuneInString(line)
+		line = line[size:]
+		switch {
+		case escaped:
+			if !unsorted {
+				b.WriteRune(r)
+			}
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '{':
+			unsorted = true
+		case r == '}':
+			unsorted = false
+		case !unsorted:
+			b.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
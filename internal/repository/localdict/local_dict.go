@@ -0,0 +1,152 @@
+// Package localdict represents adapter layer for local StarDict and ABBYY Lingvo DSL dictionaries.
+//
+// Dictionaries are placed in subdirectories named by their language pair, e.g. dicts/en-ru/mueller.ifo.
+// All dictionaries are indexed on Load, articles are read from files on lookup.
+package localdict
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	otelName = "github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/localdict"
+
+	// Version of the dictionary parsers, stored translations of older versions are refreshed.
+	Version = 1
+)
+
+var _ = service.TransRepo((*LocalDict)(nil))
+
+type (
+	langPair struct {
+		src, trgt string
+	}
+
+	// Location of an article in a dictionary.
+	entry struct {
+		dict   *dictionary
+		offset int64
+		size   int64
+	}
+
+	dictionary struct {
+		name   string
+		data   io.ReaderAt
+		format format
+		// StarDict sametypesequence, empty if each article stores its types.
+		types string
+	}
+
+	// Headword in lower case to its articles.
+	index map[string][]entry
+)
+
+type LocalDict struct {
+	indexes map[langPair]index
+	files   []io.Closer
+}
+
+func (d *LocalDict) Translate(ctx context.Context, word, srcLang, trgtLang string) (entity.WordTrans, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "LocalDict - Translate")
+	defer span.End()
+
+	entries := d.indexes[langPair{src: srcLang, trgt: trgtLang}][strings.ToLower(word)]
+	if len(entries) == 0 {
+		return entity.WordTrans{}, entity.ErrWordNotSupported
+	}
+
+	wordTrans := entity.WordTrans{
+		Word:         word,
+		SrcLang:      srcLang,
+		TrgtLang:     trgtLang,
+		Translations: make(map[entity.PartOfSpeech][]string),
+	}
+	for _, e := range entries {
+		data := make([]byte, e.size)
+		if _, err := e.dict.data.ReadAt(data, e.offset); err != nil && err != io.EOF {
+			return entity.WordTrans{}, fmt.Errorf("LocalDict - Translate - ReadAt %s: %w", e.dict.name, err)
+		}
+		mergeArticle(&wordTrans, e.dict.lines(data))
+	}
+	if len(wordTrans.Translations) == 0 {
+		return entity.WordTrans{}, entity.ErrWordNotSupported
+	}
+
+	return wordTrans, nil
+}
+
+// Close closes dictionary files.
+func (d *LocalDict) Close() error {
+	for _, f := range d.files {
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("LocalDict - Close: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *LocalDict) add(pair langPair, headword string, e entry) {
+	idx, ok := d.indexes[pair]
+	if !ok {
+		idx = make(index)
+		d.indexes[pair] = idx
+	}
+	key := strings.ToLower(headword)
+	for _, other := range idx[key] {
+		// Headwords differing only in case share an article.
+		if other == e {
+			return
+		}
+	}
+	idx[key] = append(idx[key], e)
+}
+
+// Load indexes all dictionaries of the directory.
+func Load(dir string) (*LocalDict, error) {
+	pairDirs, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("localdict - Load - os.ReadDir: %w", err)
+	}
+
+	d := &LocalDict{indexes: make(map[langPair]index)}
+	for _, pairDir := range pairDirs {
+		if !pairDir.IsDir() {
+			continue
+		}
+		src, trgt, ok := strings.Cut(pairDir.Name(), "-")
+		if !ok || src == "" || trgt == "" {
+			continue
+		}
+		pair := langPair{src: src, trgt: trgt}
+
+		files, err := os.ReadDir(filepath.Join(dir, pairDir.Name()))
+		if err != nil {
+			d.Close()
+			return nil, fmt.Errorf("localdict - Load - os.ReadDir: %w", err)
+		}
+		for _, file := range files {
+			path := filepath.Join(dir, pairDir.Name(), file.Name())
+			switch name := file.Name(); {
+			case strings.HasSuffix(name, ".ifo"):
+				err = d.loadStarDict(pair, path)
+			case strings.HasSuffix(name, ".dsl"), strings.HasSuffix(name, ".dsl.dz"):
+				err = d.loadDSL(pair, path)
+			}
+			if err != nil {
+				d.Close()
+				return nil, fmt.Errorf("localdict - Load - %s: %w", path, err)
+			}
+		}
+	}
+
+	return d, nil
+}
@@ -0,0 +1,208 @@
+package localdict
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// Writes StarDict dictionary of articles into dir, dictionary is compressed if dz is true.
+func setupStarDict(t *testing.T, dir, name, types string, dz bool, articles map[string]string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("os.MkdirAll: %v", err)
+	}
+
+	words := make([]string, 0, len(articles))
+	for word := range articles {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	var idx, dict bytes.Buffer
+	for _, word := range words {
+		idx.WriteString(word)
+		idx.WriteByte(0)
+		_ = binary.Write(&idx, binary.BigEndian, uint32(dict.Len()))
+		_ = binary.Write(&idx, binary.BigEndian, uint32(len(articles[word])))
+		dict.WriteString(articles[word])
+	}
+
+	ifo := "StarDict's dict ifo file\nversion=2.4.2\nbookname=" + name + "\nsametypesequence=" + types + "\n"
+	files := map[string][]byte{name + ".ifo": []byte(ifo), name + ".idx": idx.Bytes()}
+	if dz {
+		var gz bytes.Buffer
+		w := gzip.NewWriter(&gz)
+		_, _ = w.Write(dict.Bytes())
+		_ = w.Close()
+		files[name+".dict.dz"] = gz.Bytes()
+	} else {
+		files[name+".dict"] = dict.Bytes()
+	}
+	for file, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, file), data, 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+	}
+}
+
+// Writes DSL dictionary in UTF-16LE like ABBYY Lingvo does.
+func setupDSL(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("os.MkdirAll: %v", err)
+	}
+
+	data := []byte{0xFF, 0xFE}
+	for _, unit := range utf16.Encode([]rune(content)) {
+		data = append(data, byte(unit), byte(unit>>8))
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".dsl"), data, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+}
+
+func setupLocalDict(t *testing.T) *LocalDict {
+	t.Helper()
+	dir := t.TempDir()
+
+	setupStarDict(t, filepath.Join(dir, "en-ru"), "mueller", "m", false, map[string]string{
+		"gift": "[ɡɪft] _n.\n1) подарок, дар\n2) талант (к чему-л.)\na birthday gift — подарок на день рождения\n_v.\nдарить",
+		"lead": "_v. вести; руководить",
+	})
+	setupStarDict(t, filepath.Join(dir, "en-es"), "html", "h", true, map[string]string{
+		"gift": "<i>n.</i> regalo<br>a gift for music — un don para la música",
+	})
+	setupDSL(t, filepath.Join(dir, "en-de"), "mini", `#NAME "Mini"
+#INDEX_LANGUAGE "English"
+#CONTENTS_LANGUAGE "German"
+
+gift
+Gift {(s)}
+	[m1][p]n[/p] [trn]Geschenk, Gabe[/trn][/m]
+	[m2][ex][lang id=1033]a birthday gift[/lang] — ein Geburtstagsgeschenk[/ex][/m]
+	[m1][p]v[/p] [trn]schenken[/trn] {{comment}}[/m]
+lead
+	[m1][p]v[/p] [trn]führen[/trn][/m]
+`)
+
+	d, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+
+	return d
+}
+
+func Test_Translate(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		srcLang  string
+		trgtLang string
+		want     entity.WordTrans
+		wantErr  error
+	}{
+		{
+			name:     "StarDict plain text",
+			word:     "Gift",
+			srcLang:  "en",
+			trgtLang: "ru",
+			want: entity.WordTrans{
+				Word:     "Gift",
+				SrcLang:  "en",
+				TrgtLang: "ru",
+				Examples: []string{"a birthday gift — подарок на день рождения"},
+				Translations: map[entity.PartOfSpeech][]string{
+					"noun": {"подарок", "дар", "талант"},
+					"verb": {"дарить"},
+				},
+				MainTranslation: "подарок",
+			},
+		},
+		{
+			name:     "StarDict compressed html",
+			word:     "gift",
+			srcLang:  "en",
+			trgtLang: "es",
+			want: entity.WordTrans{
+				Word:            "gift",
+				SrcLang:         "en",
+				TrgtLang:        "es",
+				Examples:        []string{"a gift for music — un don para la música"},
+				Translations:    map[entity.PartOfSpeech][]string{"noun": {"regalo"}},
+				MainTranslation: "regalo",
+			},
+		},
+		{
+			name:     "DSL",
+			word:     "gift",
+			srcLang:  "en",
+			trgtLang: "de",
+			want: entity.WordTrans{
+				Word:     "gift",
+				SrcLang:  "en",
+				TrgtLang: "de",
+				Examples: []string{"a birthday gift — ein Geburtstagsgeschenk"},
+				Translations: map[entity.PartOfSpeech][]string{
+					"noun": {"Geschenk", "Gabe"},
+					"verb": {"schenken"},
+				},
+				MainTranslation: "Geschenk",
+			},
+		},
+		{
+			name:     "DSL last article",
+			word:     "lead",
+			srcLang:  "en",
+			trgtLang: "de",
+			want: entity.WordTrans{
+				Word:            "lead",
+				SrcLang:         "en",
+				TrgtLang:        "de",
+				Translations:    map[entity.PartOfSpeech][]string{"verb": {"führen"}},
+				MainTranslation: "führen",
+			},
+		},
+		{
+			name:     "Unknown word",
+			word:     "unknown",
+			srcLang:  "en",
+			trgtLang: "ru",
+			wantErr:  entity.ErrWordNotSupported,
+		},
+		{
+			name:     "Unknown language pair",
+			word:     "gift",
+			srcLang:  "en",
+			trgtLang: "fr",
+			wantErr:  entity.ErrWordNotSupported,
+		},
+	}
+
+	localDict := setupLocalDict(t)
+	for _, tt := range tests {
+		ctx := context.Background()
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := localDict.Translate(ctx, tt.word, tt.srcLang, tt.trgtLang)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("wanted: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("translation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package localdict
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var errMalformedIndex = errors.New("malformed index")
+
+// Loads StarDict dictionary of the .ifo file, .idx and .dict files can be gzipped (.idx.gz, .dict.dz).
+func (d *LocalDict) loadStarDict(pair langPair, ifoPath string) error {
+	info, err := readIfo(ifoPath)
+	if err != nil {
+		return fmt.Errorf("readIfo: %w", err)
+	}
+	base := strings.TrimSuffix(ifoPath, ".ifo")
+
+	dict := &dictionary{name: info["bookname"], format: formatStarDict, types: info["sametypesequence"]}
+	if f, err := os.Open(base + ".dict"); err == nil {
+		dict.data = f
+		d.files = append(d.files, f)
+	} else {
+		// Dictzip is gzip compatible, compressed dictionary is kept in memory.
+		data, err := readGzip(base + ".dict.dz")
+		if err != nil {
+			return fmt.Errorf("readGzip: %w", err)
+		}
+		dict.data = bytes.NewReader(data)
+	}
+
+	idx, err := os.ReadFile(base + ".idx")
+	if errors.Is(err, os.ErrNotExist) {
+		idx, err = readGzip(base + ".idx.gz")
+	}
+	if err != nil {
+		return fmt.Errorf("read index: %w", err)
+	}
+
+	offsetSize := 4
+	if info["idxoffsetbits"] == "64" {
+		offsetSize = 8
+	}
+	// Each record is a null terminated word, article offset and size in big endian.
+	for len(idx) > 0 {
+		end := bytes.IndexByte(idx, 0)
+		if end < 0 || len(idx) < end+1+offsetSize+4 {
+			return errMalformedIndex
+		}
+		word := string(idx[:end])
+		idx = idx[end+1:]
+
+		var offset int64
+		if offsetSize == 8 {
+			offset = int64(binary.BigEndian.Uint64(idx))
+		} else {
+			offset = int64(binary.BigEndian.Uint32(idx))
+		}
+		size := int64(binary.BigEndian.Uint32(idx[offsetSize:]))
+		idx = idx[offsetSize+4:]
+
+		d.add(pair, word, entry{dict: dict, offset: offset, size: size})
+	}
+
+	return nil
+}
+
+// Reads key=value lines of .ifo file.
+func readIfo(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			info[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return info, scanner.Err()
+}
+
+func readGzip(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// Splits StarDict article into text fields with their type.
+// Lower case types are null terminated, upper case types are binary data prefixed by size.
+func starDictFields(data []byte, types string) (fields []string, fieldTypes []byte) {
+	// The last field of sametypesequence articles isn't terminated.
+	if types != "" {
+		for i := 0; i < len(types) && len(data) > 0; i++ {
+			typ := types[i]
+			if typ >= 'A' && typ <= 'Z' {
+				if i == len(types)-1 || len(data) < 4 {
+					return fields, fieldTypes
+				}
+				size := int(binary.BigEndian.Uint32(data))
+				if len(data) < 4+size {
+					return fields, fieldTypes
+				}
+				data = data[4+size:]
+				continue
+			}
+
+			end := bytes.IndexByte(data, 0)
+			if i == len(types)-1 || end < 0 {
+				end = len(data)
+			}
+			fields, fieldTypes = append(fields, string(data[:end])), append(fieldTypes, typ)
+			if end == len(data) {
+				return fields, fieldTypes
+			}
+			data = data[end+1:]
+		}
+		return fields, fieldTypes
+	}
+
+	for len(data) > 0 {
+		typ := data[0]
+		data = data[1:]
+		if typ >= 'A' && typ <= 'Z' {
+			if len(data) < 4 {
+				return fields, fieldTypes
+			}
+			size := int(binary.BigEndian.Uint32(data))
+			if len(data) < 4+size {
+				return fields, fieldTypes
+			}
+			data = data[4+size:]
+			continue
+		}
+
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			end = len(data)
+		}
+		fields, fieldTypes = append(fields, string(data[:end])), append(fieldTypes, typ)
+		if end == len(data) {
+			return fields, fieldTypes
+		}
+		data = data[end+1:]
+	}
+	return fields, fieldTypes
+}
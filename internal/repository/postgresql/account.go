@@ -0,0 +1,412 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+)
+
+var _ = service.AccountRepo((*Account)(nil))
+
+const accountDeletionColumns = "id, user_id, requested_at, completed_at, deleted_rows"
+
+// Tables with rows of a user, referencing tables go first.
+var userTables = []string{
+	"review_log",
+	"user_collection",
+	"collections",
+	"user_translation",
+	"api_keys",
+	"users",
+}
+
+type Account struct {
+	*postgres.ConnPool
+}
+
+// Export reads all data of the user from one snapshot of the database.
+func (p *Account) Export(ctx context.Context, userID string) (entity.Export, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AccountPostgresql - Export")
+	defer span.End()
+
+	export := entity.Export{
+		Collections: make([]entity.CollectionInfo, 0),
+		Cards:       make([]entity.Card, 0),
+		Overrides:   make([]entity.UserTrans, 0),
+		Reviews:     make([]entity.ReviewLog, 0),
+		APIKeys:     make([]entity.APIKey, 0),
+	}
+	err := p.Pool.BeginTxFunc(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		sql, args, err := p.Builder.Select(userColumns+", NOW() AT TIME ZONE 'UTC'").
+			From("users").
+			Where("user_id = ?", userID).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("Account - Export - ToSql: %w", err)
+		}
+		row := tx.QueryRow(ctx, sql, args...)
+		err = row.Scan(
+			&export.User.ID,
+			&export.User.CreatedAt,
+			&export.User.Scheduler,
+			&export.User.SrcLang,
+			&export.User.TrgtLang,
+			&export.User.DailyNewLimit,
+			&export.User.Timezone,
+			&export.ExportedAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrUserNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("Account - Export - Scan: %w", err)
+		}
+		export.User.UserID = userID
+
+		if err := p.exportCollections(ctx, tx, userID, &export); err != nil {
+			return fmt.Errorf("Account - Export - p.exportCollections: %w", err)
+		}
+		if err := p.exportCards(ctx, tx, userID, &export); err != nil {
+			return fmt.Errorf("Account - Export - p.exportCards: %w", err)
+		}
+		if err := p.exportOverrides(ctx, tx, userID, &export); err != nil {
+			return fmt.Errorf("Account - Export - p.exportOverrides: %w", err)
+		}
+		if err := p.exportReviews(ctx, tx, userID, &export); err != nil {
+			return fmt.Errorf("Account - Export - p.exportReviews: %w", err)
+		}
+		if err := p.exportAPIKeys(ctx, tx, userID, &export); err != nil {
+			return fmt.Errorf("Account - Export - p.exportAPIKeys: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.Export{}, fmt.Errorf("Account - Export - BeginTxFunc: %w", err)
+	}
+
+	return export, nil
+}
+
+func (p *Account) exportCollections(ctx context.Context, tx pgx.Tx, userID string, export *entity.Export) error {
+	sql, args, err := p.Builder.
+		Select("c.id, c.name, c.description, c.src_lang, c.trgt_lang, c.created_at, COUNT(uc.word)").
+		From("collections c").
+		LeftJoin("user_collection uc ON uc.user_id = c.user_id AND uc.collection_name = c.name").
+		Where("c.user_id = ?", userID).
+		GroupBy("c.id").
+		OrderBy("c.name").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ToSql: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		collection := entity.CollectionInfo{UserID: userID}
+		if err := rows.Scan(
+			&collection.ID,
+			&collection.Name,
+			&collection.Description,
+			&collection.SrcLang,
+			&collection.TrgtLang,
+			&collection.CreatedAt,
+			&collection.Words,
+		); err != nil {
+			return fmt.Errorf("Scan: %w", err)
+		}
+		export.Collections = append(export.Collections, collection)
+	}
+	return rows.Err()
+}
+
+func (p *Account) exportCards(ctx context.Context, tx pgx.Tx, userID string, export *entity.Export) error {
+	sql, args, err := p.Builder.
+		Select("word, collection_name, src_lang, trgt_lang, added_at, introduced_at, last_repeat, time_diff, ease_factor, repetitions, stability, difficulty").
+		Column(cardTransSQL).
+		From("user_collection").
+		LeftJoin(sharedTransJoin).
+		LeftJoin(userTransJoin).
+		Where("user_id = ?", userID).
+		OrderBy("collection_name, added_at, word").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ToSql: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var card entity.Card
+		if err := rows.Scan(
+			&card.Word,
+			&card.CollectionName,
+			&card.SrcLang,
+			&card.TrgtLang,
+			&card.AddedAt,
+			&card.IntroducedAt,
+			&card.LastRepeat,
+			&card.TimeDiff,
+			&card.EaseFactor,
+			&card.Repetitions,
+			&card.Stability,
+			&card.Difficulty,
+			&card.Translation,
+		); err != nil {
+			return fmt.Errorf("Scan: %w", err)
+		}
+		export.Cards = append(export.Cards, card)
+	}
+	return rows.Err()
+}
+
+func (p *Account) exportOverrides(ctx context.Context, tx pgx.Tx, userID string, export *entity.Export) error {
+	sql, args, err := p.Builder.Select("trans_data").
+		From("user_translation").
+		Where("user_id = ?", userID).
+		OrderBy("word, src_lang, trgt_lang").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ToSql: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		userTrans := entity.UserTrans{UserID: userID}
+		if err := rows.Scan(&userTrans); err != nil {
+			return fmt.Errorf("Scan: %w", err)
+		}
+		export.Overrides = append(export.Overrides, userTrans)
+	}
+	return rows.Err()
+}
+
+func (p *Account) exportReviews(ctx context.Context, tx pgx.Tx, userID string, export *entity.Export) error {
+	sql, args, err := p.Builder.
+		Select("word, collection_name, grade, scheduler, elapsed, prev_time_diff, next_time_diff, reviewed_at").
+		From("review_log").
+		Where("user_id = ?", userID).
+		OrderBy("reviewed_at, id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ToSql: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		reviewLog := entity.ReviewLog{UserID: userID}
+		if err := rows.Scan(
+			&reviewLog.Word,
+			&reviewLog.CollectionName,
+			&reviewLog.Grade,
+			&reviewLog.Scheduler,
+			&reviewLog.Elapsed,
+			&reviewLog.PrevTimeDiff,
+			&reviewLog.NextTimeDiff,
+			&reviewLog.ReviewedAt,
+		); err != nil {
+			return fmt.Errorf("Scan: %w", err)
+		}
+		export.Reviews = append(export.Reviews, reviewLog)
+	}
+	return rows.Err()
+}
+
+func (p *Account) exportAPIKeys(ctx context.Context, tx pgx.Tx, userID string, export *entity.Export) error {
+	sql, args, err := p.Builder.Select(apiKeyColumns).
+		From("api_keys").
+		Where("user_id = ?", userID).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ToSql: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return fmt.Errorf("scanAPIKey: %w", err)
+		}
+		export.APIKeys = append(export.APIKeys, key)
+	}
+	return rows.Err()
+}
+
+// RequestDeletion records deletion request of the user, pending request is returned if there is one.
+func (p *Account) RequestDeletion(ctx context.Context, userID string) (entity.AccountDeletion, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AccountPostgresql - RequestDeletion")
+	defer span.End()
+
+	// Pending request is updated without changes so it's returned.
+	sql, args, err := p.Builder.Insert("account_deletions").
+		Columns("user_id").
+		Values(userID).
+		Suffix("ON CONFLICT (user_id) WHERE completed_at IS NULL DO UPDATE SET user_id = EXCLUDED.user_id RETURNING " + accountDeletionColumns).
+		ToSql()
+	if err != nil {
+		return entity.AccountDeletion{}, fmt.Errorf("Account - RequestDeletion - ToSql: %w", err)
+	}
+
+	var deletion entity.AccountDeletion
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		deletion, err = scanAccountDeletion(tx.QueryRow(ctx, sql, args...))
+		if err != nil {
+			return fmt.Errorf("Account - RequestDeletion - scanAccountDeletion: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.AccountDeletion{}, fmt.Errorf("Account - RequestDeletion - BeginFunc: %w", err)
+	}
+
+	return deletion, nil
+}
+
+// PendingDeletions returns up to limit deletion requests which aren't completed, oldest first.
+func (p *Account) PendingDeletions(ctx context.Context, limit int) ([]entity.AccountDeletion, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AccountPostgresql - PendingDeletions")
+	defer span.End()
+
+	sql, args, err := p.Builder.Select(accountDeletionColumns).
+		From("account_deletions").
+		Where("completed_at IS NULL").
+		OrderBy("requested_at, id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Account - PendingDeletions - ToSql: %w", err)
+	}
+
+	deletions := make([]entity.AccountDeletion, 0)
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Account - PendingDeletions - Query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			deletion, err := scanAccountDeletion(rows)
+			if err != nil {
+				return fmt.Errorf("Account - PendingDeletions - scanAccountDeletion: %w", err)
+			}
+			deletions = append(deletions, deletion)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Account - PendingDeletions - BeginFunc: %w", err)
+	}
+
+	return deletions, nil
+}
+
+// DeleteUserData deletes rows of the user from all tables and completes the request in one transaction.
+// Request completed by another instance meanwhile is returned without deleting anything.
+func (p *Account) DeleteUserData(ctx context.Context, deletion entity.AccountDeletion) (entity.AccountDeletion, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AccountPostgresql - DeleteUserData")
+	defer span.End()
+
+	// Completing the request first locks it, so concurrent deletions of the same request run one by one.
+	completeSQL, completeArgs, err := p.Builder.Update("account_deletions").
+		Set("completed_at", sq.Expr("NOW() AT TIME ZONE 'UTC'")).
+		Where("id = ? AND completed_at IS NULL", deletion.ID).
+		Suffix("RETURNING " + accountDeletionColumns).
+		ToSql()
+	if err != nil {
+		return entity.AccountDeletion{}, fmt.Errorf("Account - DeleteUserData - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		completed, err := scanAccountDeletion(tx.QueryRow(ctx, completeSQL, completeArgs...))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Account - DeleteUserData - scanAccountDeletion: %w", err)
+		}
+
+		for _, table := range userTables {
+			sql, args, err := p.Builder.Delete(table).
+				Where("user_id = ?", deletion.UserID).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("Account - DeleteUserData - ToSql: %w", err)
+			}
+			tag, err := tx.Exec(ctx, sql, args...)
+			if err != nil {
+				return fmt.Errorf("Account - DeleteUserData - Exec %s: %w", table, err)
+			}
+			completed.DeletedRows += tag.RowsAffected()
+		}
+
+		sql, args, err := p.Builder.Update("account_deletions").
+			Set("deleted_rows", completed.DeletedRows).
+			Where("id = ?", completed.ID).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("Account - DeleteUserData - ToSql: %w", err)
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("Account - DeleteUserData - Exec: %w", err)
+		}
+
+		deletion = completed
+		return nil
+	})
+	if err != nil {
+		return entity.AccountDeletion{}, fmt.Errorf("Account - DeleteUserData - BeginFunc: %w", err)
+	}
+
+	return deletion, nil
+}
+
+// Scans accountDeletionColumns.
+func scanAccountDeletion(row pgx.Row) (entity.AccountDeletion, error) {
+	var deletion entity.AccountDeletion
+	err := row.Scan(
+		&deletion.ID,
+		&deletion.UserID,
+		&deletion.RequestedAt,
+		&deletion.CompletedAt,
+		&deletion.DeletedRows,
+	)
+	return deletion, err
+}
+
+func NewAccountPostgre(pool *postgres.ConnPool) *Account {
+	return &Account{
+		pool,
+	}
+}
@@ -0,0 +1,139 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Account(t *testing.T) {
+	ctx := context.Background()
+	pool := setupContainer(ctx, t, "Account")
+	accountRepo := NewAccountPostgre(pool)
+	wordRepo := NewWordPostgre(pool)
+	settingsRepo := NewSettingsPostgre(pool)
+	keyRepo := NewAPIKeyPostgre(pool)
+
+	lastRepeat := time.Date(2023, 5, 10, 15, 4, 5, 0, time.UTC)
+	for _, userID := range []string{"12345", "other_user"} {
+		coll := entity.Collection{UserID: userID, Word: "test_word", Name: "test_coll", LastRepeat: lastRepeat, TimeDiff: time.Hour}
+		if err := settingsRepo.CreateUser(ctx, userID); err != nil {
+			t.Fatalf("settingsRepo.CreateUser: %v", err)
+		}
+		if userID == "12345" {
+			setupReviewedWord(ctx, t, coll, wordRepo)
+		} else {
+			setupAddWordToUser(ctx, t, coll, wordRepo)
+		}
+		if err := wordRepo.SaveUserTrans(ctx, entity.UserTrans{UserID: userID, Word: "test_word", MainTranslation: "дар"}); err != nil {
+			t.Fatalf("wordRepo.SaveUserTrans: %v", err)
+		}
+		if _, err := keyRepo.CreateAPIKey(ctx, entity.APIKey{UserID: userID, Name: "import", Prefix: "fc_abcde", Hash: []byte(userID)}); err != nil {
+			t.Fatalf("keyRepo.CreateAPIKey: %v", err)
+		}
+	}
+
+	t.Run("Export", func(t *testing.T) {
+		got, err := accountRepo.Export(ctx, "12345")
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if got.User.ID != "12345" || got.ExportedAt.IsZero() {
+			t.Fatalf("wrong user: %+v", got.User)
+		}
+		if len(got.Collections) != 1 || got.Collections[0].Name != "test_coll" || got.Collections[0].Words != 1 {
+			t.Fatalf("wrong collections: %+v", got.Collections)
+		}
+		if len(got.Cards) != 1 || got.Cards[0].Word != "test_word" || got.Cards[0].IntroducedAt == nil {
+			t.Fatalf("wrong cards: %+v", got.Cards)
+		}
+		if got.Cards[0].Translation.MainTranslation != "дар" {
+			t.Fatalf("want card translation with user edits but got: %+v", got.Cards[0].Translation)
+		}
+		wantOverrides := []entity.UserTrans{{UserID: "12345", Word: "test_word", MainTranslation: "дар"}}
+		if diff := cmp.Diff(wantOverrides, got.Overrides); diff != "" {
+			t.Fatalf("overrides must be equal diff: %v", diff)
+		}
+		if len(got.Reviews) != 1 || got.Reviews[0].Grade != entity.GradeGood {
+			t.Fatalf("wrong reviews: %+v", got.Reviews)
+		}
+		if len(got.APIKeys) != 1 || got.APIKeys[0].Name != "import" {
+			t.Fatalf("wrong api keys: %+v", got.APIKeys)
+		}
+	})
+
+	t.Run("Export_unknown_user", func(t *testing.T) {
+		if _, err := accountRepo.Export(ctx, "unknown_user"); !errors.Is(err, entity.ErrUserNotFound) {
+			t.Fatalf("want err %v but got: %v", entity.ErrUserNotFound, err)
+		}
+	})
+
+	var deletion entity.AccountDeletion
+	t.Run("Request_deletion", func(t *testing.T) {
+		var err error
+		deletion, err = accountRepo.RequestDeletion(ctx, "12345")
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if deletion.ID == 0 || deletion.UserID != "12345" || deletion.CompletedAt != nil {
+			t.Fatalf("wrong deletion: %+v", deletion)
+		}
+		repeated, err := accountRepo.RequestDeletion(ctx, "12345")
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if diff := cmp.Diff(deletion, repeated); diff != "" {
+			t.Fatalf("pending request must be returned diff: %v", diff)
+		}
+	})
+
+	t.Run("Pending_deletions", func(t *testing.T) {
+		got, err := accountRepo.PendingDeletions(ctx, 10)
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if diff := cmp.Diff([]entity.AccountDeletion{deletion}, got); diff != "" {
+			t.Fatalf("pending deletions must be equal diff: %v", diff)
+		}
+	})
+
+	t.Run("Delete_user_data", func(t *testing.T) {
+		got, err := accountRepo.DeleteUserData(ctx, deletion)
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		// Review, card, collection, user translation, api key and user.
+		if got.CompletedAt == nil || got.DeletedRows != 6 {
+			t.Fatalf("wrong completed deletion: %+v", got)
+		}
+		if _, err := accountRepo.Export(ctx, "12345"); !errors.Is(err, entity.ErrUserNotFound) {
+			t.Fatalf("want err %v but got: %v", entity.ErrUserNotFound, err)
+		}
+		other, err := accountRepo.Export(ctx, "other_user")
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if len(other.Cards) != 1 || len(other.Overrides) != 1 || len(other.APIKeys) != 1 {
+			t.Fatalf("data of other user must be kept: %+v", other)
+		}
+
+		pending, err := accountRepo.PendingDeletions(ctx, 10)
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if len(pending) != 0 {
+			t.Fatalf("want no pending deletions but got: %+v", pending)
+		}
+		again, err := accountRepo.DeleteUserData(ctx, deletion)
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if diff := cmp.Diff(deletion, again); diff != "" {
+			t.Fatalf("completed request must be returned as is diff: %v", diff)
+		}
+	})
+}
@@ -0,0 +1,276 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+)
+
+var _ = service.AdminRepo((*Admin)(nil))
+
+// Number of user cards with the shared translation wt.
+const translationCardsSQL = `(SELECT COUNT(*) FROM user_collection uc
+	WHERE uc.word = wt.word AND uc.src_lang = wt.src_lang AND uc.trgt_lang = wt.trgt_lang)`
+
+type Admin struct {
+	*postgres.ConnPool
+}
+
+func (p *Admin) Users(ctx context.Context, query entity.UsersQuery) ([]entity.UserSummary, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminPostgresql - Users")
+	defer span.End()
+
+	sql, args, err := p.Builder.Select(userColumns).
+		Column("(SELECT COUNT(*) FROM collections c WHERE c.user_id = u.user_id)").
+		Column("(SELECT COUNT(*) FROM user_collection uc WHERE uc.user_id = u.user_id)").
+		Column("(SELECT COUNT(*) FROM review_log rl WHERE rl.user_id = u.user_id)").
+		Column("(SELECT COUNT(*) FROM api_keys k WHERE k.user_id = u.user_id)").
+		From("users u").
+		Where("u.user_id > ?", query.After).
+		OrderBy("u.user_id").
+		Limit(uint64(query.Limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Admin - Users - ToSql: %w", err)
+	}
+
+	users := make([]entity.UserSummary, 0)
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Admin - Users - Query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var user entity.UserSummary
+			if err := rows.Scan(
+				&user.ID,
+				&user.CreatedAt,
+				&user.Scheduler,
+				&user.SrcLang,
+				&user.TrgtLang,
+				&user.DailyNewLimit,
+				&user.Timezone,
+				&user.Collections,
+				&user.Words,
+				&user.Reviews,
+				&user.APIKeys,
+			); err != nil {
+				return fmt.Errorf("Admin - Users - Scan: %w", err)
+			}
+			user.UserID = user.ID
+			users = append(users, user)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Admin - Users - BeginFunc: %w", err)
+	}
+
+	return users, nil
+}
+
+func (p *Admin) CountUsers(ctx context.Context) (int, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminPostgresql - CountUsers")
+	defer span.End()
+
+	sql, args, err := p.Builder.Select("COUNT(*)").
+		From("users").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Admin - CountUsers - ToSql: %w", err)
+	}
+
+	var total int
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&total); err != nil {
+			return fmt.Errorf("Admin - CountUsers - Scan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Admin - CountUsers - BeginFunc: %w", err)
+	}
+
+	return total, nil
+}
+
+func (p *Admin) Translations(ctx context.Context, word, srcLang, trgtLang string) ([]entity.StoredTranslation, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminPostgresql - Translations")
+	defer span.End()
+
+	where := sq.Eq{"wt.word": word}
+	if srcLang != "" {
+		where["wt.src_lang"] = srcLang
+	}
+	if trgtLang != "" {
+		where["wt.trgt_lang"] = trgtLang
+	}
+	sql, args, err := p.Builder.Select("wt.word, wt.src_lang, wt.trgt_lang, wt.provider, wt.provider_version, wt.trans_data, wt.fetched_at").
+		Column(translationCardsSQL).
+		From("word_translation wt").
+		Where(where).
+		OrderBy("wt.src_lang, wt.trgt_lang").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Admin - Translations - ToSql: %w", err)
+	}
+
+	translations := make([]entity.StoredTranslation, 0)
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Admin - Translations - Query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			translation, err := scanStoredTranslation(rows)
+			if err != nil {
+				return fmt.Errorf("Admin - Translations - scanStoredTranslation: %w", err)
+			}
+			translations = append(translations, translation)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Admin - Translations - BeginFunc: %w", err)
+	}
+
+	return translations, nil
+}
+
+func (p *Admin) UpdateTranslation(ctx context.Context, wordTrans entity.WordTrans) (entity.StoredTranslation, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminPostgresql - UpdateTranslation")
+	defer span.End()
+
+	sql, args, err := p.Builder.Update("word_translation wt").
+		Set("provider", wordTrans.Provider).
+		Set("provider_version", wordTrans.ProviderVersion).
+		Set("trans_data", wordTrans).
+		Set("fetched_at", sq.Expr("NOW() AT TIME ZONE 'UTC'")).
+		Where("word = ? AND src_lang = ? AND trgt_lang = ?", wordTrans.Word, wordTrans.SrcLang, wordTrans.TrgtLang).
+		Suffix("RETURNING wt.word, wt.src_lang, wt.trgt_lang, wt.provider, wt.provider_version, wt.trans_data, wt.fetched_at, " + translationCardsSQL).
+		ToSql()
+	if err != nil {
+		return entity.StoredTranslation{}, fmt.Errorf("Admin - UpdateTranslation - ToSql: %w", err)
+	}
+
+	var translation entity.StoredTranslation
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		translation, err = scanStoredTranslation(tx.QueryRow(ctx, sql, args...))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrTranslationNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("Admin - UpdateTranslation - scanStoredTranslation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.StoredTranslation{}, fmt.Errorf("Admin - UpdateTranslation - BeginFunc: %w", err)
+	}
+
+	return translation, nil
+}
+
+func (p *Admin) DeleteTranslation(ctx context.Context, word, srcLang, trgtLang string) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminPostgresql - DeleteTranslation")
+	defer span.End()
+
+	lockSQL, lockArgs, err := p.Builder.Select("1").
+		From("word_translation").
+		Where("word = ? AND src_lang = ? AND trgt_lang = ?", word, srcLang, trgtLang).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Admin - DeleteTranslation - ToSql: %w", err)
+	}
+	inUseSQL, inUseArgs, err := p.Builder.Select("1").
+		From("user_collection").
+		Where("word = ? AND src_lang = ? AND trgt_lang = ?", word, srcLang, trgtLang).
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Admin - DeleteTranslation - ToSql: %w", err)
+	}
+	deleteSQL, deleteArgs, err := p.Builder.Delete("word_translation").
+		Where("word = ? AND src_lang = ? AND trgt_lang = ?", word, srcLang, trgtLang).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Admin - DeleteTranslation - ToSql: %w", err)
+	}
+
+	// Word.AddWord holds FOR KEY SHARE lock of the translation until the card is committed, so the cards
+	// check waits for cards being added, and cards can't be added after the deletion.
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var found int
+		err := tx.QueryRow(ctx, lockSQL, lockArgs...).Scan(&found)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrTranslationNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("Admin - DeleteTranslation - Scan: %w", err)
+		}
+
+		var inUse bool
+		if err := tx.QueryRow(ctx, inUseSQL, inUseArgs...).Scan(&inUse); err != nil {
+			return fmt.Errorf("Admin - DeleteTranslation - Scan: %w", err)
+		}
+		if inUse {
+			return entity.ErrTranslationInUse
+		}
+
+		if _, err := tx.Exec(ctx, deleteSQL, deleteArgs...); err != nil {
+			return fmt.Errorf("Admin - DeleteTranslation - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Admin - DeleteTranslation - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// Scans word, languages, provider, provider version, data, fetch time and number of cards of a shared translation.
+func scanStoredTranslation(row pgx.Row) (entity.StoredTranslation, error) {
+	var (
+		translation                       entity.StoredTranslation
+		word, srcLang, trgtLang, provider string
+		providerVersion                   int
+	)
+	err := row.Scan(
+		&word,
+		&srcLang,
+		&trgtLang,
+		&provider,
+		&providerVersion,
+		&translation.WordTrans,
+		&translation.FetchedAt,
+		&translation.Cards,
+	)
+	if err != nil {
+		return entity.StoredTranslation{}, err
+	}
+
+	// Columns are the source of truth, data may lack them.
+	translation.Word, translation.SrcLang, translation.TrgtLang = word, srcLang, trgtLang
+	translation.Provider, translation.ProviderVersion = provider, providerVersion
+	return translation, nil
+}
+
+func NewAdminPostgre(pool *postgres.ConnPool) *Admin {
+	return &Admin{
+		pool,
+	}
+}
@@ -0,0 +1,146 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+)
+
+func Test_Admin(t *testing.T) {
+	ctx := context.Background()
+	pool := setupContainer(ctx, t, "Admin")
+	adminRepo := NewAdminPostgre(pool)
+	wordRepo := NewWordPostgre(pool)
+	settingsRepo := NewSettingsPostgre(pool)
+
+	for _, userID := range []string{"1", "2", "3"} {
+		if err := settingsRepo.CreateUser(ctx, userID); err != nil {
+			t.Fatalf("settingsRepo.CreateUser: %v", err)
+		}
+	}
+	used := entity.Collection{UserID: "1", Word: "gift", Name: "test_coll", SrcLang: "en", TrgtLang: "ru", LastRepeat: time.Now().UTC(), TimeDiff: time.Hour}
+	unused := entity.Collection{Word: "gift", SrcLang: "en", TrgtLang: "de"}
+	setupAddTranslationToDB(ctx, t, used, wordRepo)
+	setupAddTranslationToDB(ctx, t, unused, wordRepo)
+	setupAddWordToUser(ctx, t, used, wordRepo)
+
+	t.Run("Users", func(t *testing.T) {
+		got, err := adminRepo.Users(ctx, entity.UsersQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if len(got) != 2 || got[0].ID != "1" || got[1].ID != "2" {
+			t.Fatalf("wrong users: %+v", got)
+		}
+		if got[0].Collections != 1 || got[0].Words != 1 || got[1].Words != 0 {
+			t.Fatalf("wrong counters: %+v", got)
+		}
+	})
+
+	t.Run("Users_after", func(t *testing.T) {
+		got, err := adminRepo.Users(ctx, entity.UsersQuery{Limit: 2, After: "2"})
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if len(got) != 1 || got[0].ID != "3" {
+			t.Fatalf("wrong users: %+v", got)
+		}
+	})
+
+	t.Run("Count_users", func(t *testing.T) {
+		got, err := adminRepo.CountUsers(ctx)
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if got != 3 {
+			t.Fatalf("want 3 users but got: %v", got)
+		}
+	})
+
+	t.Run("Translations", func(t *testing.T) {
+		got, err := adminRepo.Translations(ctx, "gift", "en", "")
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if len(got) != 2 || got[0].TrgtLang != "de" || got[0].Cards != 0 || got[1].TrgtLang != "ru" || got[1].Cards != 1 {
+			t.Fatalf("wrong translations: %+v", got)
+		}
+	})
+
+	t.Run("Update_translation", func(t *testing.T) {
+		got, err := adminRepo.UpdateTranslation(ctx, entity.WordTrans{
+			Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "подарок", Provider: "admin",
+		})
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if got.MainTranslation != "подарок" || got.Provider != "admin" || got.Cards != 1 {
+			t.Fatalf("wrong translation: %+v", got)
+		}
+	})
+
+	t.Run("Update_unknown_translation", func(t *testing.T) {
+		_, err := adminRepo.UpdateTranslation(ctx, entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "fr"})
+		if !errors.Is(err, entity.ErrTranslationNotFound) {
+			t.Fatalf("want err %v but got: %v", entity.ErrTranslationNotFound, err)
+		}
+	})
+
+	t.Run("Delete_used_translation", func(t *testing.T) {
+		if err := adminRepo.DeleteTranslation(ctx, "gift", "en", "ru"); !errors.Is(err, entity.ErrTranslationInUse) {
+			t.Fatalf("want err %v but got: %v", entity.ErrTranslationInUse, err)
+		}
+		got, err := adminRepo.Translations(ctx, "gift", "en", "ru")
+		if err != nil || len(got) != 1 {
+			t.Fatalf("want translation kept but got: %+v err: %v", got, err)
+		}
+	})
+
+	t.Run("Delete_translation", func(t *testing.T) {
+		if err := adminRepo.DeleteTranslation(ctx, "gift", "en", "de"); err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if err := adminRepo.DeleteTranslation(ctx, "gift", "en", "de"); !errors.Is(err, entity.ErrTranslationNotFound) {
+			t.Fatalf("want err %v but got: %v", entity.ErrTranslationNotFound, err)
+		}
+	})
+
+	t.Run("Add_word_of_deleted_translation", func(t *testing.T) {
+		coll := entity.Collection{UserID: "2", Word: "gift", Name: "test_coll", SrcLang: "en", TrgtLang: "de"}
+		if err := wordRepo.AddWord(ctx, coll); !errors.Is(err, entity.ErrTranslationNotFound) {
+			t.Fatalf("want err %v but got: %v", entity.ErrTranslationNotFound, err)
+		}
+	})
+
+	t.Run("Delete_translation_of_card_being_added", func(t *testing.T) {
+		adding := entity.Collection{UserID: "2", Word: "gift", Name: "test_coll", SrcLang: "en", TrgtLang: "fr", LastRepeat: time.Now().UTC()}
+		setupAddTranslationToDB(ctx, t, adding, wordRepo)
+
+		deleted := make(chan error, 1)
+		err := pool.WithinTx(ctx, func(ctx context.Context) error {
+			if err := wordRepo.AddWord(ctx, adding); err != nil {
+				return err
+			}
+			go func() {
+				deleted <- adminRepo.DeleteTranslation(context.Background(), "gift", "en", "fr")
+			}()
+			// Deletion waits until the card is committed.
+			select {
+			case err := <-deleted:
+				return fmt.Errorf("deletion didn't wait for the card: %v", err)
+			case <-time.After(200 * time.Millisecond):
+				return nil
+			}
+		})
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if err := <-deleted; !errors.Is(err, entity.ErrTranslationInUse) {
+			t.Fatalf("want err %v but got: %v", entity.ErrTranslationInUse, err)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+)
+
+var _ = service.APIKeyRepo((*APIKey)(nil))
+
+const apiKeyColumns = "id, user_id, name, prefix, scopes, expires_at, created_at, last_used_at"
+
+type APIKey struct {
+	*postgres.ConnPool
+}
+
+func (p *APIKey) CreateAPIKey(ctx context.Context, key entity.APIKey) (entity.APIKey, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "APIKeyPostgresql - CreateAPIKey")
+	defer span.End()
+
+	// Timestamps are stored in UTC without time zone.
+	var expiresAt *time.Time
+	if key.ExpiresAt != nil {
+		utc := key.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+	sql, args, err := p.Builder.Insert("api_keys").
+		Columns("user_id, name, prefix, key_hash, scopes, expires_at").
+		Values(key.UserID, key.Name, key.Prefix, key.Hash, scopesToDB(key.Scopes), expiresAt).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("APIKey - CreateAPIKey - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&key.ID, &key.CreatedAt); err != nil {
+			return fmt.Errorf("APIKey - CreateAPIKey - Scan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("APIKey - CreateAPIKey - BeginFunc: %w", err)
+	}
+
+	key.ExpiresAt = expiresAt
+	return key, nil
+}
+
+func (p *APIKey) APIKeys(ctx context.Context, userID string) ([]entity.APIKey, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "APIKeyPostgresql - APIKeys")
+	defer span.End()
+
+	sql, args, err := p.Builder.Select(apiKeyColumns).
+		From("api_keys").
+		Where("user_id = ?", userID).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("APIKey - APIKeys - ToSql: %w", err)
+	}
+
+	keys := make([]entity.APIKey, 0)
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("APIKey - APIKeys - Query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			key, err := scanAPIKey(rows)
+			if err != nil {
+				return fmt.Errorf("APIKey - APIKeys - scanAPIKey: %w", err)
+			}
+			keys = append(keys, key)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("APIKey - APIKeys - BeginFunc: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (p *APIKey) DeleteAPIKey(ctx context.Context, userID string, id int64) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "APIKeyPostgresql - DeleteAPIKey")
+	defer span.End()
+
+	sql, args, err := p.Builder.Delete("api_keys").
+		Where("id = ? AND user_id = ?", id, userID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("APIKey - DeleteAPIKey - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("APIKey - DeleteAPIKey - Exec: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return entity.ErrAPIKeyNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("APIKey - DeleteAPIKey - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// UseAPIKey finds not expired key by hash and sets its last use time.
+func (p *APIKey) UseAPIKey(ctx context.Context, hash []byte) (entity.APIKey, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "APIKeyPostgresql - UseAPIKey")
+	defer span.End()
+
+	sql, args, err := p.Builder.Update("api_keys").
+		Set("last_used_at", sq.Expr("NOW() AT TIME ZONE 'UTC'")).
+		Where("key_hash = ? AND (expires_at IS NULL OR expires_at > NOW() AT TIME ZONE 'UTC')", hash).
+		Suffix("RETURNING " + apiKeyColumns).
+		ToSql()
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("APIKey - UseAPIKey - ToSql: %w", err)
+	}
+
+	var key entity.APIKey
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		key, err = scanAPIKey(tx.QueryRow(ctx, sql, args...))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrAPIKeyNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("APIKey - UseAPIKey - scanAPIKey: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("APIKey - UseAPIKey - BeginFunc: %w", err)
+	}
+
+	return key, nil
+}
+
+// Scans apiKeyColumns.
+func scanAPIKey(row pgx.Row) (entity.APIKey, error) {
+	var (
+		key    entity.APIKey
+		scopes []string
+	)
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&scopes,
+		&key.ExpiresAt,
+		&key.CreatedAt,
+		&key.LastUsedAt,
+	)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	key.Scopes = make([]entity.APIKeyScope, 0, len(scopes))
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, entity.APIKeyScope(scope))
+	}
+	return key, nil
+}
+
+func scopesToDB(scopes []entity.APIKeyScope) []string {
+	s := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		s = append(s, string(scope))
+	}
+	return s
+}
+
+func NewAPIKeyPostgre(pool *postgres.ConnPool) *APIKey {
+	return &APIKey{
+		pool,
+	}
+}
@@ -0,0 +1,81 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_APIKey(t *testing.T) {
+	ctx := context.Background()
+	keyRepo := NewAPIKeyPostgre(setupContainer(ctx, t, "APIKey"))
+
+	expired := time.Now().Add(-time.Hour)
+	created, err := keyRepo.CreateAPIKey(ctx, entity.APIKey{
+		UserID: "12345",
+		Name:   "import",
+		Prefix: "fc_abcde",
+		Hash:   []byte("hash"),
+		Scopes: []entity.APIKeyScope{entity.ScopeWordsWrite},
+	})
+	if err != nil {
+		t.Fatalf("keyRepo.CreateAPIKey: %v", err)
+	}
+	if created.ID == 0 || created.CreatedAt.IsZero() {
+		t.Fatalf("want id and created_at set but got: %v", created)
+	}
+	if _, err := keyRepo.CreateAPIKey(ctx, entity.APIKey{
+		UserID:    "12345",
+		Name:      "expired",
+		Prefix:    "fc_fghij",
+		Hash:      []byte("expired hash"),
+		ExpiresAt: &expired,
+	}); err != nil {
+		t.Fatalf("keyRepo.CreateAPIKey: %v", err)
+	}
+
+	t.Run("Use_key", func(t *testing.T) {
+		got, err := keyRepo.UseAPIKey(ctx, []byte("hash"))
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if got.ID != created.ID || got.UserID != "12345" || got.LastUsedAt == nil {
+			t.Fatalf("wrong used key: %+v", got)
+		}
+		if diff := cmp.Diff(created.Scopes, got.Scopes); diff != "" {
+			t.Fatalf("scopes must be equal diff: %v", diff)
+		}
+	})
+
+	t.Run("Use_expired_key", func(t *testing.T) {
+		if _, err := keyRepo.UseAPIKey(ctx, []byte("expired hash")); !errors.Is(err, entity.ErrAPIKeyNotFound) {
+			t.Fatalf("want err %v but got: %v", entity.ErrAPIKeyNotFound, err)
+		}
+	})
+
+	t.Run("List_keys", func(t *testing.T) {
+		got, err := keyRepo.APIKeys(ctx, "12345")
+		if err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if len(got) != 2 || got[0].Name != "import" || got[1].Name != "expired" || got[1].ExpiresAt == nil {
+			t.Fatalf("wrong keys: %+v", got)
+		}
+	})
+
+	t.Run("Revoke_key", func(t *testing.T) {
+		if err := keyRepo.DeleteAPIKey(ctx, "other_user", created.ID); !errors.Is(err, entity.ErrAPIKeyNotFound) {
+			t.Fatalf("want err %v but got: %v", entity.ErrAPIKeyNotFound, err)
+		}
+		if err := keyRepo.DeleteAPIKey(ctx, "12345", created.ID); err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+		if _, err := keyRepo.UseAPIKey(ctx, []byte("hash")); !errors.Is(err, entity.ErrAPIKeyNotFound) {
+			t.Fatalf("want err %v but got: %v", entity.ErrAPIKeyNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+)
+
+var _ = service.CollectionRepo((*Collection)(nil))
+
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const uniqueViolation = "23505"
+
+type Collection struct {
+	*postgres.ConnPool
+}
+
+func (p *Collection) CreateCollection(ctx context.Context, collection entity.CollectionInfo) (entity.CollectionInfo, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "CollectionPostgresql - CreateCollection")
+	defer span.End()
+
+	sql, args, err := p.Builder.Insert("collections").
+		Columns("user_id, name, description, src_lang, trgt_lang").
+		Values(
+			collection.UserID,
+			collection.Name,
+			collection.Description,
+			collection.SrcLang,
+			collection.TrgtLang,
+		).
+		Suffix("ON CONFLICT (user_id, name) DO NOTHING RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return entity.CollectionInfo{}, fmt.Errorf("Collection - CreateCollection - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, sql, args...).Scan(&collection.ID, &collection.CreatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrCollectionExists
+		}
+		if err != nil {
+			return fmt.Errorf("Collection - CreateCollection - Scan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.CollectionInfo{}, fmt.Errorf("Collection - CreateCollection - BeginFunc: %w", err)
+	}
+
+	return collection, nil
+}
+
+func (p *Collection) Collections(ctx context.Context, userID string) ([]entity.CollectionInfo, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "CollectionPostgresql - Collections")
+	defer span.End()
+
+	sql, args, err := p.Builder.
+		Select("c.id, c.name, c.description, c.src_lang, c.trgt_lang, c.created_at, COUNT(uc.word)").
+		From("collections c").
+		LeftJoin("user_collection uc ON uc.user_id = c.user_id AND uc.collection_name = c.name").
+		Where("c.user_id = ?", userID).
+		GroupBy("c.id").
+		OrderBy("c.name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Collection - Collections - ToSql: %w", err)
+	}
+
+	collections := make([]entity.CollectionInfo, 0)
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Collection - Collections - Query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			collection := entity.CollectionInfo{UserID: userID}
+			if err := rows.Scan(
+				&collection.ID,
+				&collection.Name,
+				&collection.Description,
+				&collection.SrcLang,
+				&collection.TrgtLang,
+				&collection.CreatedAt,
+				&collection.Words,
+			); err != nil {
+				return fmt.Errorf("Collection - Collections - Scan: %w", err)
+			}
+			collections = append(collections, collection)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Collection - Collections - BeginFunc: %w", err)
+	}
+
+	return collections, nil
+}
+
+// UpdateCollection sets name and description, words and their reviews are moved to the new name.
+func (p *Collection) UpdateCollection(ctx context.Context, collection entity.CollectionInfo) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "CollectionPostgresql - UpdateCollection")
+	defer span.End()
+
+	nameSQL, nameArgs, err := p.Builder.Select("name").
+		From("collections").
+		Where("id = ? AND user_id = ?", collection.ID, collection.UserID).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Collection - UpdateCollection - ToSql: %w", err)
+	}
+
+	// Words follow by ON UPDATE CASCADE.
+	sql, args, err := p.Builder.Update("collections").
+		Set("name", collection.Name).
+		Set("description", collection.Description).
+		Where("id = ? AND user_id = ?", collection.ID, collection.UserID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Collection - UpdateCollection - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var name string
+		err := tx.QueryRow(ctx, nameSQL, nameArgs...).Scan(&name)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrCollectionNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("Collection - UpdateCollection - Scan: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return entity.ErrCollectionExists
+		}
+		if err != nil {
+			return fmt.Errorf("Collection - UpdateCollection - Exec: %w", err)
+		}
+
+		logSQL, logArgs, err := p.Builder.Update("review_log").
+			Set("collection_name", collection.Name).
+			Where("user_id = ? AND collection_name = ?", collection.UserID, name).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("Collection - UpdateCollection - ToSql: %w", err)
+		}
+		if _, err := tx.Exec(ctx, logSQL, logArgs...); err != nil {
+			return fmt.Errorf("Collection - UpdateCollection - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Collection - UpdateCollection - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCollection refuses to delete a collection with words unless force is set,
+// then words are deleted, their review history is kept.
+func (p *Collection) DeleteCollection(ctx context.Context, collection entity.CollectionInfo, force bool) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "CollectionPostgresql - DeleteCollection")
+	defer span.End()
+
+	nameSQL, nameArgs, err := p.Builder.Select("name").
+		From("collections").
+		Where("id = ? AND user_id = ?", collection.ID, collection.UserID).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Collection - DeleteCollection - ToSql: %w", err)
+	}
+
+	deleteSQL, deleteArgs, err := p.Builder.Delete("collections").
+		Where("id = ? AND user_id = ?", collection.ID, collection.UserID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Collection - DeleteCollection - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var name string
+		err := tx.QueryRow(ctx, nameSQL, nameArgs...).Scan(&name)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrCollectionNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("Collection - DeleteCollection - Scan: %w", err)
+		}
+
+		if !force {
+			wordsSQL, wordsArgs, err := p.Builder.Select("COUNT(*)").
+				From("user_collection").
+				Where("user_id = ? AND collection_name = ?", collection.UserID, name).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("Collection - DeleteCollection - ToSql: %w", err)
+			}
+			var words int
+			if err := tx.QueryRow(ctx, wordsSQL, wordsArgs...).Scan(&words); err != nil {
+				return fmt.Errorf("Collection - DeleteCollection - Scan: %w", err)
+			}
+			if words > 0 {
+				return entity.ErrCollectionNotEmpty
+			}
+		}
+
+		if _, err := tx.Exec(ctx, deleteSQL, deleteArgs...); err != nil {
+			return fmt.Errorf("Collection - DeleteCollection - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Collection - DeleteCollection - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+func NewCollectionPostgre(pool *postgres.ConnPool) *Collection {
+	return &Collection{
+		pool,
+	}
+}
@@ -0,0 +1,213 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+)
+
+func Test_CreateCollection(t *testing.T) {
+	tests := []struct {
+		name    string
+		exists  bool
+		coll    entity.CollectionInfo
+		wantErr error
+	}{
+		{
+			name: "Create_collection",
+			coll: entity.CollectionInfo{UserID: "12345", Name: "test_coll", Description: "desc", SrcLang: "de", TrgtLang: "en"},
+		},
+		{
+			name:    "Create_existing_collection",
+			exists:  true,
+			coll:    entity.CollectionInfo{UserID: "12345", Name: "test_coll"},
+			wantErr: entity.ErrCollectionExists,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		collRepo := NewCollectionPostgre(setupContainer(ctx, t, tt.name))
+		if tt.exists {
+			if _, err := collRepo.CreateCollection(ctx, tt.coll); err != nil {
+				t.Fatalf("collRepo.CreateCollection: %v", err)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := collRepo.CreateCollection(ctx, tt.coll)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			if err == nil && (got.ID == 0 || got.CreatedAt.IsZero()) {
+				t.Fatalf("want id and created_at set but got: %v", got)
+			}
+		})
+	}
+}
+
+func Test_Collections(t *testing.T) {
+	ctx := context.Background()
+	pool := setupContainer(ctx, t, "Collections")
+	collRepo, wordRepo := NewCollectionPostgre(pool), NewWordPostgre(pool)
+
+	if _, err := collRepo.CreateCollection(ctx, entity.CollectionInfo{UserID: "12345", Name: "empty"}); err != nil {
+		t.Fatalf("collRepo.CreateCollection: %v", err)
+	}
+	word := entity.Collection{UserID: "12345", Word: "test_word", Name: "with_words"}
+	setupAddTranslationToDB(ctx, t, word, wordRepo)
+	if err := wordRepo.AddWord(ctx, word); err != nil {
+		t.Fatalf("wordRepo.AddWord: %v", err)
+	}
+
+	got, err := collRepo.Collections(ctx, "12345")
+	if err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("want 2 collections but got: %v", got)
+	}
+	if got[0].Name != "empty" || got[0].Words != 0 || got[1].Name != "with_words" || got[1].Words != 1 {
+		t.Fatalf("wrong collections: %v", got)
+	}
+}
+
+func Test_UpdateCollection(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(created, other entity.CollectionInfo) entity.CollectionInfo
+		wantErr error
+	}{
+		{
+			name: "Rename_collection",
+			update: func(created, other entity.CollectionInfo) entity.CollectionInfo {
+				created.Name, created.Description = "renamed", "desc"
+				return created
+			},
+		},
+		{
+			name: "Rename_to_existing_name",
+			update: func(created, other entity.CollectionInfo) entity.CollectionInfo {
+				created.Name = other.Name
+				return created
+			},
+			wantErr: entity.ErrCollectionExists,
+		},
+		{
+			name: "Rename_not_existing_collection",
+			update: func(created, other entity.CollectionInfo) entity.CollectionInfo {
+				created.ID, created.Name = -1, "renamed"
+				return created
+			},
+			wantErr: entity.ErrCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		pool := setupContainer(ctx, t, tt.name)
+		collRepo, wordRepo := NewCollectionPostgre(pool), NewWordPostgre(pool)
+
+		word := entity.Collection{UserID: "12345", Word: "test_word", Name: "test_coll"}
+		setupAddTranslationToDB(ctx, t, word, wordRepo)
+		if err := wordRepo.AddWord(ctx, word); err != nil {
+			t.Fatalf("wordRepo.AddWord: %v", err)
+		}
+		other, err := collRepo.CreateCollection(ctx, entity.CollectionInfo{UserID: "12345", Name: "other_coll"})
+		if err != nil {
+			t.Fatalf("collRepo.CreateCollection: %v", err)
+		}
+		colls, err := collRepo.Collections(ctx, "12345")
+		if err != nil {
+			t.Fatalf("collRepo.Collections: %v", err)
+		}
+		created := colls[1]
+		reviewLog := entity.ReviewLog{UserID: "12345", Word: "test_word", CollectionName: "test_coll", Grade: entity.GradeGood, Scheduler: entity.SchedulerSM2}
+		if err := wordRepo.SaveReview(ctx, word, reviewLog); err != nil {
+			t.Fatalf("wordRepo.SaveReview: %v", err)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			updated := tt.update(created, other)
+			err := collRepo.UpdateCollection(ctx, updated)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			// Words follow the renamed collection.
+			inColl, err := wordRepo.IsWordInCollection(ctx, entity.Collection{UserID: "12345", Word: "test_word", Name: updated.Name})
+			if err != nil {
+				t.Fatalf("wordRepo.IsWordInCollection: %v", err)
+			}
+			if !inColl {
+				t.Fatalf("word must be in renamed collection")
+			}
+			history, err := wordRepo.WordHistory(ctx, entity.Collection{UserID: "12345", Word: "test_word", Name: updated.Name})
+			if err != nil {
+				t.Fatalf("wordRepo.WordHistory: %v", err)
+			}
+			if len(history) != 1 {
+				t.Fatalf("review history must follow renamed collection, got: %+v", history)
+			}
+		})
+	}
+}
+
+func Test_DeleteCollection(t *testing.T) {
+	tests := []struct {
+		name      string
+		withWords bool
+		force     bool
+		wantErr   error
+	}{
+		{
+			name: "Delete_empty_collection",
+		},
+		{
+			name:      "Delete_not_empty_collection",
+			withWords: true,
+			wantErr:   entity.ErrCollectionNotEmpty,
+		},
+		{
+			name:      "Force_delete_not_empty_collection",
+			withWords: true,
+			force:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		pool := setupContainer(ctx, t, tt.name)
+		collRepo, wordRepo := NewCollectionPostgre(pool), NewWordPostgre(pool)
+
+		created, err := collRepo.CreateCollection(ctx, entity.CollectionInfo{UserID: "12345", Name: "test_coll"})
+		if err != nil {
+			t.Fatalf("collRepo.CreateCollection: %v", err)
+		}
+		word := entity.Collection{UserID: "12345", Word: "test_word", Name: "test_coll"}
+		if tt.withWords {
+			setupAddTranslationToDB(ctx, t, word, wordRepo)
+			if err := wordRepo.AddWord(ctx, word); err != nil {
+				t.Fatalf("wordRepo.AddWord: %v", err)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := collRepo.DeleteCollection(ctx, created, tt.force)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			inColl, err := wordRepo.IsWordInCollection(ctx, word)
+			if err != nil {
+				t.Fatalf("wordRepo.IsWordInCollection: %v", err)
+			}
+			if inColl != (tt.withWords && !tt.force) {
+				t.Fatalf("word must be deleted only with collection")
+			}
+		})
+	}
+}
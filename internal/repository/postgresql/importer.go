@@ -0,0 +1,132 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+)
+
+var _ = service.ImportRepo((*Import)(nil))
+
+const importTable = "word_translation_import"
+
+type Import struct {
+	*postgres.ConnPool
+}
+
+func (p *Import) ImportOffset(ctx context.Context, source string) (int64, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "ImportPostgresql - ImportOffset")
+	defer span.End()
+
+	sql, args, err := p.Builder.Select("byte_offset").
+		From("import_progress").
+		Where("source = ?", source).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Import - ImportOffset - ToSql: %w", err)
+	}
+
+	var offset int64
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, sql, args...).Scan(&offset)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("Import - ImportOffset - Scan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Import - ImportOffset - BeginFunc: %w", err)
+	}
+
+	return offset, nil
+}
+
+// ImportTranslations copies translations into a temporary table and upserts them from it,
+// imported translations replace stored ones.
+func (p *Import) ImportTranslations(ctx context.Context, source string, words []entity.WordTrans, offset int64) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "ImportPostgresql - ImportTranslations")
+	defer span.End()
+
+	// A word can be repeated in a dump, only its last translation is kept.
+	upsertSQL, upsertArgs, err := p.Builder.Insert("word_translation").
+		Columns("word, src_lang, trgt_lang, provider, provider_version, trans_data").
+		Select(sq.
+			Select("DISTINCT ON (word, src_lang, trgt_lang) word, src_lang, trgt_lang, provider, provider_version, trans_data").
+			From(importTable).
+			OrderBy("word, src_lang, trgt_lang, n DESC")).
+		Suffix(`ON CONFLICT (word, src_lang, trgt_lang) DO UPDATE SET
+			provider = EXCLUDED.provider,
+			provider_version = EXCLUDED.provider_version,
+			trans_data = EXCLUDED.trans_data,
+			fetched_at = NOW() AT TIME ZONE 'UTC'`).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Import - ImportTranslations - ToSql: %w", err)
+	}
+
+	progressSQL, progressArgs, err := p.Builder.Insert("import_progress").
+		Columns("source, byte_offset, words").
+		Values(source, offset, len(words)).
+		Suffix(`ON CONFLICT (source) DO UPDATE SET
+			byte_offset = EXCLUDED.byte_offset,
+			words = import_progress.words + EXCLUDED.words,
+			updated_at = NOW() AT TIME ZONE 'UTC'`).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Import - ImportTranslations - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `CREATE TEMP TABLE `+importTable+` (
+			n BIGINT,
+			word TEXT,
+			src_lang TEXT,
+			trgt_lang TEXT,
+			provider TEXT,
+			provider_version INTEGER,
+			trans_data JSONB
+		) ON COMMIT DROP`)
+		if err != nil {
+			return fmt.Errorf("Import - ImportTranslations - Exec: %w", err)
+		}
+
+		_, err = tx.CopyFrom(
+			ctx,
+			pgx.Identifier{importTable},
+			[]string{"n", "word", "src_lang", "trgt_lang", "provider", "provider_version", "trans_data"},
+			pgx.CopyFromSlice(len(words), func(i int) ([]interface{}, error) {
+				w := words[i]
+				return []interface{}{i, w.Word, w.SrcLang, w.TrgtLang, w.Provider, w.ProviderVersion, w}, nil
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("Import - ImportTranslations - CopyFrom: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, upsertSQL, upsertArgs...); err != nil {
+			return fmt.Errorf("Import - ImportTranslations - Exec: %w", err)
+		}
+		if _, err := tx.Exec(ctx, progressSQL, progressArgs...); err != nil {
+			return fmt.Errorf("Import - ImportTranslations - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Import - ImportTranslations - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+func NewImportPostgre(pool *postgres.ConnPool) *Import {
+	return &Import{
+		pool,
+	}
+}
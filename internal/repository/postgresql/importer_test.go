@@ -0,0 +1,76 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+)
+
+func Test_ImportTranslations(t *testing.T) {
+	gift := entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "подарок", Provider: "kaikki"}
+	tests := []struct {
+		name       string
+		stored     bool
+		batches    [][]entity.WordTrans
+		wantOffset int64
+	}{
+		{
+			name:       "Import_batches",
+			batches:    [][]entity.WordTrans{{gift}, {{Word: "bank", SrcLang: "en", TrgtLang: "ru", Provider: "kaikki"}}},
+			wantOffset: 20,
+		},
+		{
+			name:       "Import_stored_translation",
+			stored:     true,
+			batches:    [][]entity.WordTrans{{gift}},
+			wantOffset: 10,
+		},
+		{
+			name:       "Import_repeated_word",
+			batches:    [][]entity.WordTrans{{gift, gift}},
+			wantOffset: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		pool := setupContainer(ctx, t, tt.name)
+		importRepo, wordRepo := NewImportPostgre(pool), NewWordPostgre(pool)
+		if tt.stored {
+			if err := wordRepo.AddTranslation(ctx, entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "ru", Provider: "google"}); err != nil {
+				t.Fatalf("wordRepo.AddTranslation: %v", err)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := importRepo.ImportOffset(ctx, "dump")
+			if err != nil || offset != 0 {
+				t.Fatalf("want zero offset of a new source but got: %v, %v", offset, err)
+			}
+			for i, batch := range tt.batches {
+				if err := importRepo.ImportTranslations(ctx, "dump", batch, int64(10*(i+1))); err != nil {
+					t.Fatalf("want nil but got: %v", err)
+				}
+			}
+
+			offset, err = importRepo.ImportOffset(ctx, "dump")
+			if err != nil {
+				t.Fatalf("importRepo.ImportOffset: %v", err)
+			}
+			if offset != tt.wantOffset {
+				t.Fatalf("want offset %v but got: %v", tt.wantOffset, offset)
+			}
+
+			var provider string
+			if err := pool.Pool.QueryRow(ctx,
+				"SELECT provider FROM word_translation WHERE word = 'gift' AND src_lang = 'en' AND trgt_lang = 'ru'",
+			).Scan(&provider); err != nil {
+				t.Fatalf("select imported translation: %v", err)
+			}
+			if provider != "kaikki" {
+				t.Fatalf("imported translation must replace stored one but got provider: %v", provider)
+			}
+		})
+	}
+}
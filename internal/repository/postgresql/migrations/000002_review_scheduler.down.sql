@@ -0,0 +1,3 @@
+ALTER TABLE user_collection
+    DROP COLUMN IF EXISTS ease_factor,
+    DROP COLUMN IF EXISTS repetitions;
@@ -0,0 +1,10 @@
+ALTER TABLE user_collection
+    ADD COLUMN IF NOT EXISTS ease_factor        DOUBLE PRECISION                            NOT NULL DEFAULT 2.5,
+    ADD COLUMN IF NOT EXISTS repetitions        INTEGER                                     NOT NULL DEFAULT 0;
+
+-- Cards reviewed with the old doubling schedule keep their progress.
+UPDATE user_collection SET repetitions = CASE
+    WHEN time_diff >= INTERVAL '6 days' THEN 2
+    WHEN time_diff > INTERVAL '0' THEN 1
+    ELSE 0
+END;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS user_settings;
+
+ALTER TABLE user_collection
+    DROP COLUMN IF EXISTS stability,
+    DROP COLUMN IF EXISTS difficulty;
@@ -0,0 +1,16 @@
+ALTER TABLE user_collection
+    ADD COLUMN IF NOT EXISTS stability          DOUBLE PRECISION                            NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS difficulty         DOUBLE PRECISION                            NOT NULL DEFAULT 0;
+
+-- Seeds FSRS memory state from the current schedule: interval in days becomes stability
+-- and ease factor is mapped to difficulty, same as service.FSRS does for cards without state.
+UPDATE user_collection SET
+    stability = EXTRACT(EPOCH FROM time_diff) / 86400,
+    difficulty = LEAST(GREATEST(4.93 - (ease_factor - 2.5) * 4.225, 1), 10)
+WHERE time_diff > INTERVAL '0';
+
+CREATE TABLE IF NOT EXISTS user_settings(
+    user_id                                     TEXT                                        NOT NULL,
+    scheduler                                   TEXT                                        NOT NULL DEFAULT 'sm2' CHECK(scheduler IN ('sm2', 'fsrs')),
+    PRIMARY KEY (user_id)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS user_collection_introduced_idx;
+DROP INDEX IF EXISTS user_collection_new_idx;
+DROP INDEX IF EXISTS user_collection_due_idx;
+
+ALTER TABLE user_collection DROP COLUMN IF EXISTS introduced_at;
@@ -0,0 +1,16 @@
+-- NULL until the first review, words without it are new.
+ALTER TABLE user_collection ADD COLUMN IF NOT EXISTS introduced_at TIMESTAMP;
+
+UPDATE user_collection SET introduced_at = last_repeat
+WHERE repetitions > 0 OR time_diff > INTERVAL '0';
+
+CREATE INDEX IF NOT EXISTS user_collection_due_idx
+    ON user_collection (user_id, (last_repeat + time_diff))
+    WHERE introduced_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS user_collection_new_idx
+    ON user_collection (user_id, last_repeat)
+    WHERE introduced_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS user_collection_introduced_idx
+    ON user_collection (user_id, introduced_at);
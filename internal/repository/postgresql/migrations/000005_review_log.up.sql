@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS review_log(
+    id                                          BIGSERIAL                                   NOT NULL,
+    user_id                                     TEXT                                        NOT NULL,
+    word                                        TEXT                                        NOT NULL,
+    collection_name                             TEXT                                        NOT NULL,
+    grade                                       TEXT                                        NOT NULL CHECK(grade IN ('again', 'hard', 'good', 'easy')),
+    scheduler                                   TEXT                                        NOT NULL,
+    elapsed                                     INTERVAL                                    NOT NULL,
+    prev_time_diff                              INTERVAL                                    NOT NULL,
+    next_time_diff                              INTERVAL                                    NOT NULL,
+    reviewed_at                                 TIMESTAMP                                   NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id, word, collection_name)
+        REFERENCES user_collection(user_id, word, collection_name) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS review_log_word_idx ON review_log (user_id, word, collection_name, reviewed_at);
+CREATE INDEX IF NOT EXISTS review_log_user_idx ON review_log (user_id, reviewed_at);
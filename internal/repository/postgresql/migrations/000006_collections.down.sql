@@ -0,0 +1,3 @@
+ALTER TABLE user_collection DROP CONSTRAINT IF EXISTS user_collection_collection_fkey;
+
+DROP TABLE IF EXISTS collections;
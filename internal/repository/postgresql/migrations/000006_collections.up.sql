@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS collections(
+    id                                          BIGSERIAL                                   NOT NULL,
+    user_id                                     TEXT                                        NOT NULL,
+    name                                        TEXT                                        NOT NULL CHECK(name != ''),
+    description                                 TEXT                                        NOT NULL DEFAULT '',
+    -- Empty language means the default language of the deployment.
+    src_lang                                    TEXT                                        NOT NULL DEFAULT '',
+    trgt_lang                                   TEXT                                        NOT NULL DEFAULT '',
+    created_at                                  TIMESTAMP                                   NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (id),
+    UNIQUE(user_id, name)
+);
+
+INSERT INTO collections (user_id, name, created_at)
+SELECT user_id, collection_name, MIN(last_repeat)
+FROM user_collection
+GROUP BY user_id, collection_name
+ON CONFLICT DO NOTHING;
+
+-- Renaming a collection renames it in user_collection and review_log, deleting one deletes its words.
+ALTER TABLE user_collection
+    ADD CONSTRAINT user_collection_collection_fkey FOREIGN KEY (user_id, collection_name)
+        REFERENCES collections(user_id, name) ON UPDATE CASCADE ON DELETE CASCADE;
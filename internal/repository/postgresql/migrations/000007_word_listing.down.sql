@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS user_collection_last_repeat_idx;
+DROP INDEX IF EXISTS user_collection_added_idx;
+
+ALTER TABLE user_collection DROP COLUMN IF EXISTS added_at;
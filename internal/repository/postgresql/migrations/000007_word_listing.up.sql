@@ -0,0 +1,9 @@
+ALTER TABLE user_collection ADD COLUMN IF NOT EXISTS added_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC');
+
+UPDATE user_collection SET added_at = COALESCE(introduced_at, last_repeat);
+
+CREATE INDEX IF NOT EXISTS user_collection_added_idx
+    ON user_collection (user_id, added_at, word, collection_name);
+
+CREATE INDEX IF NOT EXISTS user_collection_last_repeat_idx
+    ON user_collection (user_id, last_repeat, word, collection_name);
@@ -0,0 +1,17 @@
+ALTER TABLE user_collection
+    DROP CONSTRAINT IF EXISTS user_collection_translation_fkey,
+    DROP COLUMN IF EXISTS src_lang,
+    DROP COLUMN IF EXISTS trgt_lang;
+
+-- Only one language pair of a word can be kept.
+DELETE FROM word_translation wt USING word_translation other
+WHERE wt.word = other.word AND (wt.src_lang, wt.trgt_lang) > (other.src_lang, other.trgt_lang);
+
+ALTER TABLE word_translation
+    DROP CONSTRAINT IF EXISTS word_translation_pkey,
+    DROP COLUMN IF EXISTS src_lang,
+    DROP COLUMN IF EXISTS trgt_lang,
+    ADD PRIMARY KEY (word);
+
+ALTER TABLE user_collection
+    ADD CONSTRAINT user_collection_word_fkey FOREIGN KEY (word) REFERENCES word_translation(word);
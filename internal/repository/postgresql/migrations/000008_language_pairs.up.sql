@@ -0,0 +1,43 @@
+ALTER TABLE user_collection DROP CONSTRAINT IF EXISTS user_collection_word_fkey;
+
+-- Translations are cached per language pair, existing ones keep the pair they were fetched with.
+ALTER TABLE word_translation
+    ADD COLUMN IF NOT EXISTS src_lang TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS trgt_lang TEXT NOT NULL DEFAULT '';
+
+UPDATE word_translation SET
+    src_lang = COALESCE(trans_data->>'source_language', ''),
+    trgt_lang = COALESCE(trans_data->>'target_language', '');
+
+ALTER TABLE word_translation
+    ALTER COLUMN src_lang DROP DEFAULT,
+    ALTER COLUMN trgt_lang DROP DEFAULT,
+    DROP CONSTRAINT IF EXISTS word_translation_pkey,
+    ADD PRIMARY KEY (word, src_lang, trgt_lang);
+
+ALTER TABLE user_collection
+    ADD COLUMN IF NOT EXISTS src_lang TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS trgt_lang TEXT NOT NULL DEFAULT '';
+
+UPDATE user_collection uc SET
+    src_lang = wt.src_lang,
+    trgt_lang = wt.trgt_lang
+FROM word_translation wt
+WHERE wt.word = uc.word;
+
+ALTER TABLE user_collection
+    ALTER COLUMN src_lang DROP DEFAULT,
+    ALTER COLUMN trgt_lang DROP DEFAULT,
+    ADD CONSTRAINT user_collection_translation_fkey FOREIGN KEY (word, src_lang, trgt_lang)
+        REFERENCES word_translation(word, src_lang, trgt_lang);
+
+-- Collections without a language pair take the pair of their words.
+UPDATE collections c SET
+    src_lang = uc.src_lang,
+    trgt_lang = uc.trgt_lang
+FROM (
+    SELECT DISTINCT ON (user_id, collection_name) user_id, collection_name, src_lang, trgt_lang
+    FROM user_collection
+    ORDER BY user_id, collection_name, added_at
+) uc
+WHERE c.user_id = uc.user_id AND c.name = uc.collection_name AND c.src_lang = '' AND c.trgt_lang = '';
@@ -0,0 +1,3 @@
+UPDATE word_translation SET trans_data = trans_data - 'provider';
+
+ALTER TABLE word_translation DROP COLUMN IF EXISTS provider;
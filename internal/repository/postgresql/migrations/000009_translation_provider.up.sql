@@ -0,0 +1,6 @@
+ALTER TABLE word_translation ADD COLUMN IF NOT EXISTS provider TEXT NOT NULL DEFAULT '';
+
+-- Google scraper was the only provider so far.
+UPDATE word_translation SET
+    provider = 'google',
+    trans_data = jsonb_set(trans_data, '{provider}', '"google"');
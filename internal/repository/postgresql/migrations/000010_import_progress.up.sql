@@ -0,0 +1,8 @@
+-- Position of the last imported word of a dump, import resumes from it.
+CREATE TABLE IF NOT EXISTS import_progress(
+    source                                      TEXT                                        NOT NULL,
+    byte_offset                                 BIGINT                                      NOT NULL DEFAULT 0,
+    words                                       BIGINT                                      NOT NULL DEFAULT 0,
+    updated_at                                  TIMESTAMP                                   NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (source)
+);
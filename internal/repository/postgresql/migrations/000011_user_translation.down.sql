@@ -0,0 +1,12 @@
+-- Custom cards can't be kept without a shared translation.
+DELETE FROM user_collection uc
+WHERE NOT EXISTS (
+    SELECT 1 FROM word_translation wt
+    WHERE wt.word = uc.word AND wt.src_lang = uc.src_lang AND wt.trgt_lang = uc.trgt_lang
+);
+
+ALTER TABLE user_collection
+    ADD CONSTRAINT user_collection_translation_fkey FOREIGN KEY (word, src_lang, trgt_lang)
+        REFERENCES word_translation(word, src_lang, trgt_lang);
+
+DROP TABLE IF EXISTS user_translation;
@@ -0,0 +1,13 @@
+-- User edits of shared translations and translations of custom cards, edits apply to the word in all user collections.
+CREATE TABLE IF NOT EXISTS user_translation(
+    user_id                                     TEXT                                        NOT NULL,
+    word                                        TEXT                                        NOT NULL CHECK(word != ''),
+    src_lang                                    TEXT                                        NOT NULL,
+    trgt_lang                                   TEXT                                        NOT NULL,
+    trans_data                                  JSONB                                       NOT NULL,
+    updated_at                                  TIMESTAMP                                   NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (user_id, word, src_lang, trgt_lang)
+);
+
+-- Custom cards don't have a shared translation.
+ALTER TABLE user_collection DROP CONSTRAINT IF EXISTS user_collection_translation_fkey;
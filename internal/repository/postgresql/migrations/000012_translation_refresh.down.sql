@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS word_translation_fetched_idx;
+
+ALTER TABLE word_translation
+    DROP COLUMN IF EXISTS fetched_at,
+    DROP COLUMN IF EXISTS provider_version;
@@ -0,0 +1,9 @@
+-- Stored translations are re-translated when they get old or were made by an older provider version.
+-- Existing translations were made by the current versions.
+ALTER TABLE word_translation
+    ADD COLUMN IF NOT EXISTS fetched_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    ADD COLUMN IF NOT EXISTS provider_version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE word_translation ALTER COLUMN provider_version SET DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS word_translation_fetched_idx ON word_translation (fetched_at);
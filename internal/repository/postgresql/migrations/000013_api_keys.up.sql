@@ -0,0 +1,14 @@
+-- Personal API keys, a key is looked up by SHA-256 hash of its value.
+CREATE TABLE IF NOT EXISTS api_keys(
+    id                                          BIGSERIAL                                   PRIMARY KEY,
+    user_id                                     TEXT                                        NOT NULL,
+    name                                        TEXT                                        NOT NULL,
+    prefix                                      TEXT                                        NOT NULL,
+    key_hash                                    BYTEA                                       NOT NULL UNIQUE,
+    scopes                                      TEXT[]                                      NOT NULL DEFAULT '{}',
+    expires_at                                  TIMESTAMP,
+    created_at                                  TIMESTAMP                                   NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    last_used_at                                TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_user_idx ON api_keys (user_id);
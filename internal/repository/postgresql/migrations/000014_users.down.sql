@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_settings(
+    user_id                                     TEXT                                        NOT NULL,
+    scheduler                                   TEXT                                        NOT NULL DEFAULT 'sm2' CHECK(scheduler IN ('sm2', 'fsrs')),
+    PRIMARY KEY (user_id)
+);
+
+INSERT INTO user_settings (user_id, scheduler)
+SELECT user_id, scheduler FROM users
+ON CONFLICT (user_id) DO NOTHING;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,24 @@
+-- Users are created on their first authenticated request, settings are kept with the user.
+CREATE TABLE IF NOT EXISTS users(
+    user_id                                     TEXT                                        NOT NULL,
+    scheduler                                   TEXT                                        NOT NULL DEFAULT 'sm2' CHECK(scheduler IN ('sm2', 'fsrs')),
+    src_lang                                    TEXT                                        NOT NULL DEFAULT '',
+    trgt_lang                                   TEXT                                        NOT NULL DEFAULT '',
+    daily_new_limit                             INTEGER                                     NOT NULL DEFAULT 20 CHECK(daily_new_limit >= 0),
+    timezone                                    TEXT                                        NOT NULL DEFAULT 'UTC',
+    created_at                                  TIMESTAMP                                   NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (user_id)
+);
+
+INSERT INTO users (user_id, scheduler)
+SELECT user_id, scheduler FROM user_settings
+ON CONFLICT (user_id) DO NOTHING;
+
+-- Users who have words or collections but never saved settings.
+INSERT INTO users (user_id)
+SELECT user_id FROM user_collection
+UNION
+SELECT user_id FROM collections
+ON CONFLICT (user_id) DO NOTHING;
+
+DROP TABLE IF EXISTS user_settings;
@@ -0,0 +1,11 @@
+-- Audit of user data deletions, requests are processed in the background and the record is kept afterwards.
+CREATE TABLE IF NOT EXISTS account_deletions(
+    id                                          BIGSERIAL                                   PRIMARY KEY,
+    user_id                                     TEXT                                        NOT NULL,
+    requested_at                                TIMESTAMP                                   NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    completed_at                                TIMESTAMP,
+    deleted_rows                                BIGINT                                      NOT NULL DEFAULT 0
+);
+
+-- A user has at most one pending request.
+CREATE UNIQUE INDEX IF NOT EXISTS account_deletions_pending_idx ON account_deletions (user_id) WHERE completed_at IS NULL;
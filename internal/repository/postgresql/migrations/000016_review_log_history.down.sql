@@ -0,0 +1,9 @@
+DELETE FROM review_log rl
+WHERE NOT EXISTS (
+    SELECT 1 FROM user_collection uc
+    WHERE uc.user_id = rl.user_id AND uc.word = rl.word AND uc.collection_name = rl.collection_name
+);
+
+ALTER TABLE review_log
+    ADD CONSTRAINT review_log_user_id_word_collection_name_fkey FOREIGN KEY (user_id, word, collection_name)
+        REFERENCES user_collection(user_id, word, collection_name) ON UPDATE CASCADE ON DELETE CASCADE;
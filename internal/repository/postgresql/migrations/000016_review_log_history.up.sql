@@ -0,0 +1,3 @@
+-- Review history outlives deleted words and collections, it's deleted only with the account.
+-- Moving words and renaming collections update review_log explicitly.
+ALTER TABLE review_log DROP CONSTRAINT IF EXISTS review_log_user_id_word_collection_name_fkey;
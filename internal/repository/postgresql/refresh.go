@@ -0,0 +1,177 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+)
+
+var _ = service.RefreshRepo((*Refresh)(nil))
+
+type Refresh struct {
+	*postgres.ConnPool
+}
+
+// StaleTranslations returns translations of the given providers fetched before query.FetchedBefore
+// or made by their older versions.
+func (p *Refresh) StaleTranslations(ctx context.Context, query entity.StaleQuery) ([]entity.WordTrans, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "RefreshPostgresql - StaleTranslations")
+	defer span.End()
+
+	stale := make([]entity.WordTrans, 0, query.Limit)
+	if len(query.Versions) == 0 {
+		return stale, nil
+	}
+
+	providers := make(sq.Or, 0, len(query.Versions))
+	for provider, version := range query.Versions {
+		providers = append(providers, sq.And{
+			sq.Eq{"provider": provider},
+			sq.Or{
+				sq.Lt{"provider_version": version},
+				sq.Lt{"fetched_at": query.FetchedBefore},
+			},
+		})
+	}
+	sql, args, err := p.Builder.Select("word, src_lang, trgt_lang, provider, provider_version").
+		From("word_translation").
+		Where(providers).
+		OrderBy("fetched_at").
+		Limit(uint64(query.Limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Refresh - StaleTranslations - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Refresh - StaleTranslations - Query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var wordTrans entity.WordTrans
+			if err := rows.Scan(
+				&wordTrans.Word,
+				&wordTrans.SrcLang,
+				&wordTrans.TrgtLang,
+				&wordTrans.Provider,
+				&wordTrans.ProviderVersion,
+			); err != nil {
+				return fmt.Errorf("Refresh - StaleTranslations - Scan: %w", err)
+			}
+			stale = append(stale, wordTrans)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Refresh - StaleTranslations - BeginFunc: %w", err)
+	}
+
+	return stale, nil
+}
+
+// UpdateTranslation replaces data of the stored translation, entity.ErrTranslationNotFound is returned
+// if it was deleted, deleted translations aren't created again by background refresh.
+func (p *Refresh) UpdateTranslation(ctx context.Context, wordTrans entity.WordTrans) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "RefreshPostgresql - UpdateTranslation")
+	defer span.End()
+
+	sql, args, err := p.Builder.Update("word_translation").
+		Set("provider", wordTrans.Provider).
+		Set("provider_version", wordTrans.ProviderVersion).
+		Set("trans_data", wordTrans).
+		Set("fetched_at", sq.Expr("NOW() AT TIME ZONE 'UTC'")).
+		Where("word = ? AND src_lang = ? AND trgt_lang = ?", wordTrans.Word, wordTrans.SrcLang, wordTrans.TrgtLang).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Refresh - UpdateTranslation - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Refresh - UpdateTranslation - Exec: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return entity.ErrTranslationNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Refresh - UpdateTranslation - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// SaveTranslation stores translation, it's created if it doesn't exist.
+func (p *Refresh) SaveTranslation(ctx context.Context, wordTrans entity.WordTrans) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "RefreshPostgresql - SaveTranslation")
+	defer span.End()
+
+	sql, args, err := p.Builder.
+		Insert("word_translation").Columns("word, src_lang, trgt_lang, provider, provider_version, trans_data").
+		Values(wordTrans.Word, wordTrans.SrcLang, wordTrans.TrgtLang, wordTrans.Provider, wordTrans.ProviderVersion, wordTrans).
+		Suffix(`ON CONFLICT (word, src_lang, trgt_lang) DO UPDATE SET
+			provider = EXCLUDED.provider,
+			provider_version = EXCLUDED.provider_version,
+			trans_data = EXCLUDED.trans_data,
+			fetched_at = NOW() AT TIME ZONE 'UTC'`).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Refresh - SaveTranslation - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Refresh - SaveTranslation - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Refresh - SaveTranslation - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Refresh) PostponeRefresh(ctx context.Context, wordTrans entity.WordTrans) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "RefreshPostgresql - PostponeRefresh")
+	defer span.End()
+
+	sql, args, err := p.Builder.Update("word_translation").
+		Set("fetched_at", sq.Expr("NOW() AT TIME ZONE 'UTC'")).
+		Where("word = ? AND src_lang = ? AND trgt_lang = ?", wordTrans.Word, wordTrans.SrcLang, wordTrans.TrgtLang).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Refresh - PostponeRefresh - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Refresh - PostponeRefresh - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Refresh - PostponeRefresh - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+func NewRefreshPostgre(pool *postgres.ConnPool) *Refresh {
+	return &Refresh{
+		pool,
+	}
+}
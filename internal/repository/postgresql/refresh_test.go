@@ -0,0 +1,126 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_StaleTranslations(t *testing.T) {
+	// Translations fetched a week ago by the first version of their providers.
+	stored := []entity.WordTrans{
+		{Word: "gift", SrcLang: "en", TrgtLang: "ru", Provider: "google", ProviderVersion: 1},
+		{Word: "bank", SrcLang: "en", TrgtLang: "ru", Provider: "kaikki", ProviderVersion: 1},
+	}
+	weekAgo := time.Now().UTC().Add(-7 * 24 * time.Hour)
+	tests := []struct {
+		name  string
+		query entity.StaleQuery
+		want  []entity.WordTrans
+	}{
+		{
+			name:  "Older_provider_version",
+			query: entity.StaleQuery{Versions: map[string]int{"google": 2}, Limit: 10},
+			want:  stored[:1],
+		},
+		{
+			name:  "Old_translation",
+			query: entity.StaleQuery{Versions: map[string]int{"google": 1}, FetchedBefore: time.Now().UTC(), Limit: 10},
+			want:  stored[:1],
+		},
+		{
+			name:  "Fresh_translation",
+			query: entity.StaleQuery{Versions: map[string]int{"google": 1}, FetchedBefore: weekAgo.Add(-time.Hour), Limit: 10},
+			want:  []entity.WordTrans{},
+		},
+		{
+			name:  "Without_providers",
+			query: entity.StaleQuery{FetchedBefore: time.Now().UTC(), Limit: 10},
+			want:  []entity.WordTrans{},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		pool := setupContainer(ctx, t, tt.name)
+		refreshRepo := NewRefreshPostgre(pool)
+		for _, wordTrans := range stored {
+			if err := refreshRepo.SaveTranslation(ctx, wordTrans); err != nil {
+				t.Fatalf("refreshRepo.SaveTranslation: %v", err)
+			}
+		}
+		if _, err := pool.Pool.Exec(ctx, "UPDATE word_translation SET fetched_at = $1", weekAgo); err != nil {
+			t.Fatalf("update fetched_at: %v", err)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := refreshRepo.StaleTranslations(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("want nil but got: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("stale translations mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_PostponeRefresh(t *testing.T) {
+	ctx := context.Background()
+	pool := setupContainer(ctx, t, "Postpone_refresh")
+	refreshRepo := NewRefreshPostgre(pool)
+	gift := entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "ru", Provider: "google", ProviderVersion: 1}
+	if err := refreshRepo.SaveTranslation(ctx, gift); err != nil {
+		t.Fatalf("refreshRepo.SaveTranslation: %v", err)
+	}
+	if _, err := pool.Pool.Exec(ctx, "UPDATE word_translation SET fetched_at = '2000-01-01'"); err != nil {
+		t.Fatalf("update fetched_at: %v", err)
+	}
+
+	if err := refreshRepo.PostponeRefresh(ctx, gift); err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+
+	stale, err := refreshRepo.StaleTranslations(ctx, entity.StaleQuery{
+		Versions:      map[string]int{"google": 1},
+		FetchedBefore: time.Now().UTC().Add(-time.Hour),
+		Limit:         10,
+	})
+	if err != nil {
+		t.Fatalf("refreshRepo.StaleTranslations: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Fatalf("want postponed translation not stale but got: %v", stale)
+	}
+}
+
+func Test_UpdateTranslation(t *testing.T) {
+	ctx := context.Background()
+	pool := setupContainer(ctx, t, "Update_translation")
+	refreshRepo := NewRefreshPostgre(pool)
+	gift := entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "ru", Provider: "google", ProviderVersion: 1}
+
+	// Deleted translation isn't created again.
+	if err := refreshRepo.UpdateTranslation(ctx, gift); !errors.Is(err, entity.ErrTranslationNotFound) {
+		t.Fatalf("want err %v but got: %v", entity.ErrTranslationNotFound, err)
+	}
+	if err := refreshRepo.SaveTranslation(ctx, gift); err != nil {
+		t.Fatalf("refreshRepo.SaveTranslation: %v", err)
+	}
+
+	gift.ProviderVersion, gift.MainTranslation = 2, "подарок"
+	if err := refreshRepo.UpdateTranslation(ctx, gift); err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	stale, err := refreshRepo.StaleTranslations(ctx, entity.StaleQuery{Versions: map[string]int{"google": 2}, Limit: 10})
+	if err != nil {
+		t.Fatalf("refreshRepo.StaleTranslations: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Fatalf("want updated translation but got stale: %+v", stale)
+	}
+}
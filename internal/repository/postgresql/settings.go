@@ -0,0 +1,173 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+)
+
+var _ = service.SettingsRepo((*Settings)(nil))
+
+const userColumns = "user_id, created_at, scheduler, src_lang, trgt_lang, daily_new_limit, timezone"
+
+type Settings struct {
+	*postgres.ConnPool
+}
+
+func (p *Settings) Settings(ctx context.Context, userID string) (entity.Settings, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "SettingsPostgresql - Settings")
+	defer span.End()
+
+	user, err := p.User(ctx, userID)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		return entity.DefaultSettings(userID), nil
+	}
+	if err != nil {
+		return entity.Settings{}, fmt.Errorf("Settings - Settings - p.User: %w", err)
+	}
+
+	return user.Settings, nil
+}
+
+func (p *Settings) CreateUser(ctx context.Context, userID string) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "SettingsPostgresql - CreateUser")
+	defer span.End()
+
+	sql, args, err := p.Builder.Insert("users").
+		Columns("user_id").
+		Values(userID).
+		Suffix("ON CONFLICT (user_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Settings - CreateUser - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Settings - CreateUser - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Settings - CreateUser - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Settings) User(ctx context.Context, userID string) (entity.User, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "SettingsPostgresql - User")
+	defer span.End()
+
+	sql, args, err := p.Builder.Select(userColumns).
+		From("users").
+		Where("user_id = ?", userID).
+		ToSql()
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Settings - User - ToSql: %w", err)
+	}
+
+	var user entity.User
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		user, err = scanUser(tx.QueryRow(ctx, sql, args...))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrUserNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("Settings - User - scanUser: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Settings - User - BeginFunc: %w", err)
+	}
+
+	return user, nil
+}
+
+func (p *Settings) PatchSettings(ctx context.Context, patch entity.SettingsPatch) (entity.User, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "SettingsPostgresql - PatchSettings")
+	defer span.End()
+
+	columns, values := []string{"user_id"}, []interface{}{patch.UserID}
+	set := func(column string, value interface{}) {
+		columns = append(columns, column)
+		values = append(values, value)
+	}
+	if patch.Scheduler != nil {
+		set("scheduler", *patch.Scheduler)
+	}
+	if patch.SrcLang != nil {
+		set("src_lang", *patch.SrcLang)
+	}
+	if patch.TrgtLang != nil {
+		set("trgt_lang", *patch.TrgtLang)
+	}
+	if patch.DailyNewLimit != nil {
+		set("daily_new_limit", *patch.DailyNewLimit)
+	}
+	if patch.Timezone != nil {
+		set("timezone", *patch.Timezone)
+	}
+
+	// Conflicting row is updated with the set columns only, user_id is set to itself
+	// so the row is returned when nothing else is set.
+	update := ""
+	for i, column := range columns {
+		if i > 0 {
+			update += ", "
+		}
+		update += column + " = EXCLUDED." + column
+	}
+	sql, args, err := p.Builder.Insert("users").
+		Columns(columns...).
+		Values(values...).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET " + update + " RETURNING " + userColumns).
+		ToSql()
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Settings - PatchSettings - ToSql: %w", err)
+	}
+
+	var user entity.User
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		user, err = scanUser(tx.QueryRow(ctx, sql, args...))
+		if err != nil {
+			return fmt.Errorf("Settings - PatchSettings - scanUser: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Settings - PatchSettings - BeginFunc: %w", err)
+	}
+
+	return user, nil
+}
+
+// Scans userColumns.
+func scanUser(row pgx.Row) (entity.User, error) {
+	var user entity.User
+	err := row.Scan(
+		&user.ID,
+		&user.CreatedAt,
+		&user.Scheduler,
+		&user.SrcLang,
+		&user.TrgtLang,
+		&user.DailyNewLimit,
+		&user.Timezone,
+	)
+	user.UserID = user.ID
+	return user, err
+}
+
+func NewSettingsPostgre(pool *postgres.ConnPool) *Settings {
+	return &Settings{
+		pool,
+	}
+}
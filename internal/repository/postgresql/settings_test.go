@@ -0,0 +1,169 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_Settings(t *testing.T) {
+	fsrs := entity.SchedulerFSRS
+	tests := []struct {
+		name    string
+		userID  string
+		saved   *entity.SettingsPatch
+		want    entity.Settings
+		wantErr bool
+	}{
+		{
+			name:   "Not_saved_settings",
+			userID: "12345",
+			want:   entity.DefaultSettings("12345"),
+		},
+		{
+			name:   "Saved_settings",
+			userID: "12345",
+			saved:  &entity.SettingsPatch{UserID: "12345", Scheduler: &fsrs},
+			want: entity.Settings{
+				UserID:        "12345",
+				Scheduler:     entity.SchedulerFSRS,
+				DailyNewLimit: entity.DefaultDailyNewLimit,
+				Timezone:      entity.DefaultTimezone,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		settingsRepo := NewSettingsPostgre(setupContainer(ctx, t, tt.name))
+		if tt.saved != nil {
+			if _, err := settingsRepo.PatchSettings(ctx, *tt.saved); err != nil {
+				t.Fatalf("settingsRepo.PatchSettings: %v", err)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := settingsRepo.Settings(ctx, tt.userID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want err but got: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("settings must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
+func Test_PatchSettings(t *testing.T) {
+	var (
+		fsrs, sm2, unknown = entity.SchedulerFSRS, entity.SchedulerSM2, entity.SchedulerName("unknown")
+		de, es, berlin     = "de", "es", "Europe/Berlin"
+		limit, negative    = 5, -1
+	)
+	tests := []struct {
+		name    string
+		patches []entity.SettingsPatch
+		want    entity.Settings
+		wantErr bool
+	}{
+		{
+			name:    "Save_settings",
+			patches: []entity.SettingsPatch{{UserID: "12345", Scheduler: &fsrs, SrcLang: &de, TrgtLang: &es, DailyNewLimit: &limit, Timezone: &berlin}},
+			want: entity.Settings{
+				UserID:        "12345",
+				Scheduler:     entity.SchedulerFSRS,
+				SrcLang:       "de",
+				TrgtLang:      "es",
+				DailyNewLimit: 5,
+				Timezone:      "Europe/Berlin",
+			},
+		},
+		{
+			name: "Overwrite_only_set_fields",
+			patches: []entity.SettingsPatch{
+				{UserID: "12345", Scheduler: &fsrs, SrcLang: &de},
+				{UserID: "12345", Scheduler: &sm2, DailyNewLimit: &limit},
+			},
+			want: entity.Settings{
+				UserID:        "12345",
+				Scheduler:     entity.SchedulerSM2,
+				SrcLang:       "de",
+				DailyNewLimit: 5,
+				Timezone:      entity.DefaultTimezone,
+			},
+		},
+		{
+			name:    "Empty_patch",
+			patches: []entity.SettingsPatch{{UserID: "12345"}},
+			want:    entity.DefaultSettings("12345"),
+		},
+		{
+			name:    "Unknown_scheduler",
+			patches: []entity.SettingsPatch{{UserID: "12345", Scheduler: &unknown}},
+			wantErr: true,
+		},
+		{
+			name:    "Negative_limit",
+			patches: []entity.SettingsPatch{{UserID: "12345", DailyNewLimit: &negative}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		settingsRepo := NewSettingsPostgre(setupContainer(ctx, t, tt.name))
+
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got entity.User
+				err error
+			)
+			for _, patch := range tt.patches {
+				got, err = settingsRepo.PatchSettings(ctx, patch)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want err but got: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got.Settings); diff != "" || got.ID != "12345" || got.CreatedAt.IsZero() {
+				t.Fatalf("wrong user: %+v diff: %v", got, diff)
+			}
+		})
+	}
+}
+
+func Test_CreateUser(t *testing.T) {
+	ctx := context.Background()
+	settingsRepo := NewSettingsPostgre(setupContainer(ctx, t, "CreateUser"))
+
+	if _, err := settingsRepo.User(ctx, "12345"); !errors.Is(err, entity.ErrUserNotFound) {
+		t.Fatalf("want err %v but got: %v", entity.ErrUserNotFound, err)
+	}
+	if err := settingsRepo.CreateUser(ctx, "12345"); err != nil {
+		t.Fatalf("settingsRepo.CreateUser: %v", err)
+	}
+	fsrs := entity.SchedulerFSRS
+	if _, err := settingsRepo.PatchSettings(ctx, entity.SettingsPatch{UserID: "12345", Scheduler: &fsrs}); err != nil {
+		t.Fatalf("settingsRepo.PatchSettings: %v", err)
+	}
+	// Existing user is kept.
+	if err := settingsRepo.CreateUser(ctx, "12345"); err != nil {
+		t.Fatalf("settingsRepo.CreateUser: %v", err)
+	}
+
+	got, err := settingsRepo.User(ctx, "12345")
+	if err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	want := entity.User{ID: "12345", Settings: entity.DefaultSettings("12345")}
+	want.Scheduler = entity.SchedulerFSRS
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(entity.User{}, "CreatedAt")); diff != "" {
+		t.Fatalf("users must be equal diff: %v", diff)
+	}
+}
@@ -0,0 +1,176 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+)
+
+var _ = service.StatsRepo((*Stats)(nil))
+
+const (
+	// Cards with shorter interval are still being learned, reviews of such cards don't count in retention.
+	learningInterval = 24 * time.Hour
+	// Cards with this or longer interval are mature.
+	matureInterval = 21 * 24 * time.Hour
+)
+
+// UTC start of the user day of the UTC timestamp expression, time zone is the argument of both placeholders.
+const localDaySQL = "(date_trunc('day', %s AT TIME ZONE 'UTC' AT TIME ZONE ?) AT TIME ZONE ?) AT TIME ZONE 'UTC'"
+
+type Stats struct {
+	*postgres.ConnPool
+}
+
+func (p *Stats) Stats(ctx context.Context, query entity.StatsQuery) (*entity.Stats, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "StatsPostgresql - Stats")
+	defer span.End()
+
+	// Empty time zone is UTC.
+	loc, err := time.LoadLocation(query.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("Stats - Stats - time.LoadLocation: %w", err)
+	}
+	tz := loc.String()
+
+	inCollection := func(q sq.SelectBuilder) sq.SelectBuilder {
+		if query.Collection != "" {
+			return q.Where("collection_name = ?", query.Collection)
+		}
+		return q
+	}
+
+	retentionSQL, retentionArgs, err := inCollection(p.Builder.
+		Select("collection_name, COUNT(*), COUNT(*) FILTER (WHERE grade <> 'again')").
+		From("review_log").
+		Where("user_id = ? AND reviewed_at >= ? AND prev_time_diff >= ?", query.UserID, query.From, learningInterval)).
+		GroupBy("collection_name").
+		OrderBy("collection_name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Stats - Stats - ToSql: %w", err)
+	}
+
+	heatmapSQL, heatmapArgs, err := inCollection(p.Builder.
+		Select().
+		Column(sq.Expr(fmt.Sprintf(localDaySQL, "reviewed_at")+" AS day", tz, tz)).
+		Column("COUNT(*)").
+		From("review_log").
+		Where("user_id = ? AND reviewed_at >= ?", query.UserID, query.From)).
+		GroupBy("day").
+		OrderBy("day").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Stats - Stats - ToSql: %w", err)
+	}
+
+	y, m, d := query.DayStart.In(loc).Date()
+	forecastEnd := time.Date(y, m, d+query.ForecastDays, 0, 0, 0, 0, loc).UTC()
+	forecastSQL, forecastArgs, err := inCollection(p.Builder.
+		Select().
+		Column(sq.Expr(fmt.Sprintf(localDaySQL, "GREATEST(last_repeat + time_diff, ?)")+" AS day",
+			query.DayStart, tz, tz)).
+		Column("COUNT(*)").
+		From("user_collection").
+		Where("user_id = ? AND introduced_at IS NOT NULL AND last_repeat + time_diff < ?", query.UserID, forecastEnd)).
+		GroupBy("day").
+		OrderBy("day").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Stats - Stats - ToSql: %w", err)
+	}
+
+	maturitySQL, maturityArgs, err := inCollection(p.Builder.
+		Select().
+		Column("COUNT(*) FILTER (WHERE introduced_at IS NULL)").
+		Column(sq.Expr("COUNT(*) FILTER (WHERE introduced_at IS NOT NULL AND (repetitions = 0 OR time_diff < ?))",
+			learningInterval)).
+		Column(sq.Expr("COUNT(*) FILTER (WHERE introduced_at IS NOT NULL AND repetitions > 0 AND time_diff >= ? AND time_diff < ?)",
+			learningInterval, matureInterval)).
+		Column(sq.Expr("COUNT(*) FILTER (WHERE introduced_at IS NOT NULL AND repetitions > 0 AND time_diff >= ?)",
+			matureInterval)).
+		From("user_collection").
+		Where("user_id = ?", query.UserID)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Stats - Stats - ToSql: %w", err)
+	}
+
+	stats := &entity.Stats{
+		Retention: make([]entity.CollectionRetention, 0),
+	}
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, retentionSQL, retentionArgs...)
+		if err != nil {
+			return fmt.Errorf("Stats - Stats - Query: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var retention entity.CollectionRetention
+			if err := rows.Scan(&retention.CollectionName, &retention.Reviews, &retention.Passed); err != nil {
+				return fmt.Errorf("Stats - Stats - Scan: %w", err)
+			}
+			stats.Retention = append(stats.Retention, retention)
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("Stats - Stats - Err: %w", err)
+		}
+
+		if stats.Heatmap, err = p.queryDayCounts(ctx, tx, heatmapSQL, heatmapArgs); err != nil {
+			return fmt.Errorf("Stats - Stats - p.queryDayCounts: %w", err)
+		}
+		if stats.Forecast, err = p.queryDayCounts(ctx, tx, forecastSQL, forecastArgs); err != nil {
+			return fmt.Errorf("Stats - Stats - p.queryDayCounts: %w", err)
+		}
+
+		if err := tx.QueryRow(ctx, maturitySQL, maturityArgs...).Scan(
+			&stats.Maturity.New,
+			&stats.Maturity.Learning,
+			&stats.Maturity.Young,
+			&stats.Maturity.Mature,
+		); err != nil {
+			return fmt.Errorf("Stats - Stats - Scan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Stats - Stats - BeginFunc: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (p *Stats) queryDayCounts(ctx context.Context, tx pgx.Tx, sql string, args []interface{}) ([]entity.DayCount, error) {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Stats - queryDayCounts - Query: %w", err)
+	}
+	defer rows.Close()
+
+	dayCounts := make([]entity.DayCount, 0)
+	for rows.Next() {
+		var dayCount entity.DayCount
+		if err := rows.Scan(&dayCount.Day, &dayCount.Count); err != nil {
+			return nil, fmt.Errorf("Stats - queryDayCounts - Scan: %w", err)
+		}
+		dayCounts = append(dayCounts, dayCount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Stats - queryDayCounts - Err: %w", err)
+	}
+
+	return dayCounts, nil
+}
+
+func NewStatsPostgre(pool *postgres.ConnPool) *Stats {
+	return &Stats{
+		pool,
+	}
+}
@@ -0,0 +1,162 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Stats(t *testing.T) {
+	const day = 24 * time.Hour
+	dayStart := time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC)
+	card := func(word string, lastRepeat time.Time, timeDiff time.Duration, repetitions int) entity.Collection {
+		return entity.Collection{
+			UserID:      "12345",
+			Name:        "test_coll",
+			Word:        word,
+			LastRepeat:  lastRepeat,
+			TimeDiff:    timeDiff,
+			Repetitions: repetitions,
+		}
+	}
+	review := func(coll entity.Collection, grade entity.Grade, prevTimeDiff time.Duration) entity.ReviewLog {
+		return entity.ReviewLog{
+			UserID:         coll.UserID,
+			Word:           coll.Word,
+			CollectionName: coll.Name,
+			Grade:          grade,
+			Scheduler:      entity.SchedulerSM2,
+			PrevTimeDiff:   prevTimeDiff,
+			NextTimeDiff:   coll.TimeDiff,
+			ReviewedAt:     coll.LastRepeat,
+		}
+	}
+
+	learning := card("learning", dayStart.Add(-2*day), time.Hour, 0)
+	young := card("young", dayStart.Add(-day), 3*day, 2)
+	mature := card("mature", dayStart.Add(-30*day).Add(time.Hour), 30*day, 5)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("time.LoadLocation: %v", err)
+	}
+	// UTC-4 in May, reviews of a UTC day are split between two days.
+	newYorkDayStart := time.Date(2023, 5, 10, 0, 0, 0, 0, newYork).UTC()
+
+	tests := []struct {
+		name    string
+		new     []entity.Collection
+		reviews []entity.ReviewLog
+		query   entity.StatsQuery
+		want    *entity.Stats
+	}{
+		{
+			name: "Stats_of_collection",
+			new:  []entity.Collection{card("new", dayStart, 0, 0)},
+			reviews: []entity.ReviewLog{
+				review(learning, entity.GradeAgain, 0),
+				review(young, entity.GradeGood, day),
+				review(mature, entity.GradeAgain, 10*day),
+			},
+			query: entity.StatsQuery{
+				UserID:       "12345",
+				Collection:   "test_coll",
+				From:         dayStart.Add(-29 * day),
+				DayStart:     dayStart,
+				ForecastDays: 30,
+			},
+			want: &entity.Stats{
+				Retention: []entity.CollectionRetention{
+					{CollectionName: "test_coll", Reviews: 1, Passed: 1},
+				},
+				Heatmap: []entity.DayCount{
+					{Day: dayStart.Add(-2 * day), Count: 1},
+					{Day: dayStart.Add(-day), Count: 1},
+				},
+				Forecast: []entity.DayCount{
+					{Day: dayStart, Count: 2},
+					{Day: dayStart.Add(2 * day), Count: 1},
+				},
+				Maturity: entity.Maturity{New: 1, Learning: 1, Young: 1, Mature: 1},
+			},
+		},
+		{
+			name: "Stats_of_user_time_zone",
+			reviews: []entity.ReviewLog{
+				review(young, entity.GradeGood, day),
+			},
+			query: entity.StatsQuery{
+				UserID:       "12345",
+				From:         newYorkDayStart.Add(-29 * day),
+				DayStart:     newYorkDayStart,
+				Timezone:     "America/New_York",
+				ForecastDays: 30,
+			},
+			want: &entity.Stats{
+				Retention: []entity.CollectionRetention{
+					{CollectionName: "test_coll", Reviews: 1, Passed: 1},
+				},
+				Heatmap: []entity.DayCount{
+					{Day: newYorkDayStart.Add(-2 * day), Count: 1},
+				},
+				Forecast: []entity.DayCount{
+					{Day: newYorkDayStart.Add(day), Count: 1},
+				},
+				Maturity: entity.Maturity{Young: 1},
+			},
+		},
+		{
+			name: "Stats_of_other_collection",
+			reviews: []entity.ReviewLog{
+				review(young, entity.GradeGood, day),
+			},
+			query: entity.StatsQuery{
+				UserID:       "12345",
+				Collection:   "other_coll",
+				From:         dayStart.Add(-29 * day),
+				DayStart:     dayStart,
+				ForecastDays: 30,
+			},
+			want: &entity.Stats{
+				Retention: []entity.CollectionRetention{},
+				Heatmap:   []entity.DayCount{},
+				Forecast:  []entity.DayCount{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		pool := setupContainer(ctx, t, tt.name)
+		wordRepo, statsRepo := NewWordPostgre(pool), NewStatsPostgre(pool)
+		for _, coll := range tt.new {
+			setupAddTranslationToDB(ctx, t, coll, wordRepo)
+			setupAddWordToUser(ctx, t, coll, wordRepo)
+		}
+		for _, r := range tt.reviews {
+			coll := card(r.Word, r.ReviewedAt, r.NextTimeDiff, 0)
+			for _, c := range []entity.Collection{learning, young, mature} {
+				if c.Word == r.Word {
+					coll = c
+				}
+			}
+			setupAddTranslationToDB(ctx, t, coll, wordRepo)
+			setupAddWordToUser(ctx, t, coll, wordRepo)
+			if err := wordRepo.SaveReview(ctx, coll, r); err != nil {
+				t.Fatalf("wordRepo.SaveReview: %v", err)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := statsRepo.Stats(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("want nil but got: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("stats must be equal diff: %v", diff)
+			}
+		})
+	}
+}
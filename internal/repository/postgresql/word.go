@@ -3,12 +3,15 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
 	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"go.opentelemetry.io/otel"
 )
@@ -17,6 +20,14 @@ const otelName = "github.com/Kin-dza-dzaa/flash_cards_api/internal/repository/po
 
 var _ = service.WordRepo((*Word)(nil))
 
+// Translation of a card is the shared one with user edits on top of it,
+// custom cards have only the user translation.
+const (
+	cardTransSQL    = "COALESCE(wt.trans_data, '{}') || COALESCE(ut.trans_data, '{}')"
+	sharedTransJoin = "word_translation wt USING(word, src_lang, trgt_lang)"
+	userTransJoin   = "user_translation ut USING(user_id, word, src_lang, trgt_lang)"
+)
+
 type Word struct {
 	*postgres.ConnPool
 }
@@ -29,9 +40,11 @@ func (p *Word) UserWords(ctx context.Context, collection entity.Collection) (*en
 	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - UserWords")
 	defer span.End()
 
-	sql, args, err := p.Builder.Select("collection_name, time_diff, last_repeat, trans_data").
+	sql, args, err := p.Builder.Select("collection_name, time_diff, last_repeat, ease_factor, repetitions, stability, difficulty").
+		Column(cardTransSQL).
 		From("user_collection").
-		Join("word_translation USING(word)").
+		LeftJoin(sharedTransJoin).
+		LeftJoin(userTransJoin).
 		Where("user_id = ?", collection.UserID).
 		ToSql()
 	if err != nil {
@@ -40,7 +53,7 @@ func (p *Word) UserWords(ctx context.Context, collection entity.Collection) (*en
 
 	userWords := new(entity.UserWords)
 	userWords.Words = make(map[entity.CollectionName][]entity.WordData)
-	err = p.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
 		rows, err := tx.Query(ctx, sql, args...)
 		if err != nil {
 			return fmt.Errorf("Word - UserWords - Query: %w", err)
@@ -49,7 +62,16 @@ func (p *Word) UserWords(ctx context.Context, collection entity.Collection) (*en
 
 		collectionName, wordData := entity.CollectionName(""), entity.WordData{}
 		for rows.Next() {
-			if err := rows.Scan(&collectionName, &wordData.TimeDiff, &wordData.LastRepeat, &wordData.WordTrans); err != nil {
+			if err := rows.Scan(
+				&collectionName,
+				&wordData.TimeDiff,
+				&wordData.LastRepeat,
+				&wordData.EaseFactor,
+				&wordData.Repetitions,
+				&wordData.Stability,
+				&wordData.Difficulty,
+				&wordData.WordTrans,
+			); err != nil {
 				return fmt.Errorf("Word - UserWords - Scan: %w", err)
 			}
 
@@ -64,6 +86,209 @@ func (p *Word) UserWords(ctx context.Context, collection entity.Collection) (*en
 	return userWords, nil
 }
 
+func (p *Word) DueWords(ctx context.Context, query entity.DueQuery) (*entity.DueWords, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - DueWords")
+	defer span.End()
+
+	introducedSQL, introducedArgs, err := p.Builder.Select("COUNT(*)").
+		From("user_collection").
+		Where("user_id = ? AND introduced_at >= ?", query.UserID, query.DayStart).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Word - DueWords - ToSql: %w", err)
+	}
+
+	wordsQuery := func(limit int) sq.SelectBuilder {
+		q := p.Builder.
+			Select("collection_name, time_diff, last_repeat, ease_factor, repetitions, stability, difficulty").
+			Column(cardTransSQL).
+			From("user_collection").
+			LeftJoin(sharedTransJoin).
+			LeftJoin(userTransJoin).
+			Where("user_id = ?", query.UserID).
+			Limit(uint64(limit))
+		if query.Collection != "" {
+			q = q.Where("collection_name = ?", query.Collection)
+		}
+		return q
+	}
+
+	dueWords := new(entity.DueWords)
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var introduced int
+		if err := tx.QueryRow(ctx, introducedSQL, introducedArgs...).Scan(&introduced); err != nil {
+			return fmt.Errorf("Word - DueWords - Scan: %w", err)
+		}
+
+		// Overdue reviews go first, new words only fill the rest of the queue.
+		sql, args, err := wordsQuery(query.Limit).
+			Where("introduced_at IS NOT NULL AND last_repeat + time_diff <= ?", query.Now).
+			OrderBy("last_repeat + time_diff, word").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("Word - DueWords - ToSql: %w", err)
+		}
+		if dueWords.Reviews, err = p.queryDueWords(ctx, tx, sql, args, false); err != nil {
+			return fmt.Errorf("Word - DueWords - p.queryDueWords: %w", err)
+		}
+
+		newLimit := query.NewLimit - introduced
+		if rest := query.Limit - len(dueWords.Reviews); newLimit > rest {
+			newLimit = rest
+		}
+		if newLimit <= 0 {
+			return nil
+		}
+		sql, args, err = wordsQuery(newLimit).
+			Where("introduced_at IS NULL").
+			OrderBy("last_repeat, word").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("Word - DueWords - ToSql: %w", err)
+		}
+		if dueWords.New, err = p.queryDueWords(ctx, tx, sql, args, true); err != nil {
+			return fmt.Errorf("Word - DueWords - p.queryDueWords: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Word - DueWords - BeginFunc: %w", err)
+	}
+
+	return dueWords, nil
+}
+
+func (p *Word) queryDueWords(ctx context.Context, tx pgx.Tx, sql string, args []interface{}, isNew bool) ([]entity.DueWord, error) {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Word - queryDueWords - Query: %w", err)
+	}
+	defer rows.Close()
+
+	var dueWords []entity.DueWord
+	for rows.Next() {
+		dueWord := entity.DueWord{New: isNew}
+		if err := rows.Scan(
+			&dueWord.CollectionName,
+			&dueWord.TimeDiff,
+			&dueWord.LastRepeat,
+			&dueWord.EaseFactor,
+			&dueWord.Repetitions,
+			&dueWord.Stability,
+			&dueWord.Difficulty,
+			&dueWord.WordTrans,
+		); err != nil {
+			return nil, fmt.Errorf("Word - queryDueWords - Scan: %w", err)
+		}
+		dueWord.Due = dueWord.LastRepeat.Add(dueWord.TimeDiff)
+		dueWords = append(dueWords, dueWord)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Word - queryDueWords - Err: %w", err)
+	}
+
+	return dueWords, nil
+}
+
+// Escapes LIKE wildcards, so search is a plain substring.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Words returns a page of user words ordered by query.Sort starting after query.After.
+func (p *Word) Words(ctx context.Context, query entity.WordsQuery) ([]entity.ListedWord, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - Words")
+	defer span.End()
+
+	q := p.Builder.
+		Select("collection_name, added_at, time_diff, last_repeat, ease_factor, repetitions, stability, difficulty").
+		Column(cardTransSQL).
+		From("user_collection").
+		LeftJoin(sharedTransJoin).
+		LeftJoin(userTransJoin).
+		Where("user_id = ?", query.UserID).
+		Limit(uint64(query.Limit))
+	if query.Collection != "" {
+		q = q.Where("collection_name = ?", query.Collection)
+	}
+	if query.PartOfSpeech != "" {
+		// ?? is an escaped jsonb key existence operator.
+		q = q.Where("(("+cardTransSQL+")->'transltions' ?? ? OR ("+cardTransSQL+")->'definitions_with_examples' ?? ?)",
+			query.PartOfSpeech, query.PartOfSpeech)
+	}
+	if query.Search != "" {
+		q = q.Where("word ILIKE ?", "%"+likeEscaper.Replace(query.Search)+"%")
+	}
+	if query.Due != nil {
+		due := "introduced_at IS NOT NULL AND last_repeat + time_diff <= ?"
+		if !*query.Due {
+			due = "NOT (" + due + ")"
+		}
+		q = q.Where(due, query.Now)
+	}
+
+	dir, cmp := "ASC", ">"
+	if query.Desc {
+		dir, cmp = "DESC", "<"
+	}
+	switch query.Sort {
+	case entity.WordsSortWord:
+		q = q.OrderBy("word "+dir, "collection_name "+dir)
+		if query.After != nil {
+			q = q.Where("(word, collection_name) "+cmp+" (?, ?)", query.After.Word, query.After.Collection)
+		}
+	default:
+		column := "added_at"
+		if query.Sort == entity.WordsSortLastRepeat {
+			column = "last_repeat"
+		}
+		q = q.OrderBy(column+" "+dir, "word "+dir, "collection_name "+dir)
+		if query.After != nil {
+			q = q.Where("("+column+", word, collection_name) "+cmp+" (?, ?, ?)",
+				query.After.Time, query.After.Word, query.After.Collection)
+		}
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Word - Words - ToSql: %w", err)
+	}
+
+	words := make([]entity.ListedWord, 0, query.Limit)
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Word - Words - Query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var word entity.ListedWord
+			if err := rows.Scan(
+				&word.CollectionName,
+				&word.AddedAt,
+				&word.TimeDiff,
+				&word.LastRepeat,
+				&word.EaseFactor,
+				&word.Repetitions,
+				&word.Stability,
+				&word.Difficulty,
+				&word.WordTrans,
+			); err != nil {
+				return fmt.Errorf("Word - Words - Scan: %w", err)
+			}
+			words = append(words, word)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Word - Words - BeginFunc: %w", err)
+	}
+
+	return words, nil
+}
+
+// UpdateLearnInterval sets the interval written by a client and resets scheduler state, so it doesn't
+// contradict the interval: ease factor is the default, repetitions are derived from the interval as in
+// migration 000002 and FSRS seeds stability and difficulty from the interval on the next review.
 func (p *Word) UpdateLearnInterval(ctx context.Context, collection entity.Collection) error {
 	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - UpdateLearnInterval")
 	defer span.End()
@@ -71,6 +296,15 @@ func (p *Word) UpdateLearnInterval(ctx context.Context, collection entity.Collec
 	sql, args, err := p.Builder.Update("user_collection").
 		Set("time_diff", collection.TimeDiff).
 		Set("last_repeat", collection.LastRepeat).
+		Set("introduced_at", sq.Expr("COALESCE(introduced_at, ?)", collection.LastRepeat)).
+		Set("ease_factor", sq.Expr("DEFAULT")).
+		Set("repetitions", sq.Expr(`CASE
+			WHEN ?::INTERVAL >= INTERVAL '6 days' THEN 2
+			WHEN ?::INTERVAL > INTERVAL '0' THEN 1
+			ELSE 0
+		END`, collection.TimeDiff, collection.TimeDiff)).
+		Set("stability", 0).
+		Set("difficulty", 0).
 		Where("user_id = ? AND word = ? AND collection_name = ?",
 			collection.UserID, collection.Word, collection.Name).
 		ToSql()
@@ -78,7 +312,7 @@ func (p *Word) UpdateLearnInterval(ctx context.Context, collection entity.Collec
 		return fmt.Errorf("Word - UpdateLearnInterval - ToSql: %w", err)
 	}
 
-	err = p.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
 		_, err := tx.Exec(ctx, sql, args...)
 		if err != nil {
 			return fmt.Errorf("Word - UpdateLearnInterval - Exec: %w", err)
@@ -92,6 +326,159 @@ func (p *Word) UpdateLearnInterval(ctx context.Context, collection entity.Collec
 	return nil
 }
 
+func (p *Word) Card(ctx context.Context, collection entity.Collection) (entity.Collection, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - Card")
+	defer span.End()
+
+	query := p.Builder.Select("src_lang, trgt_lang, time_diff, last_repeat, ease_factor, repetitions, stability, difficulty").
+		From("user_collection").
+		Where("user_id = ? AND word = ? AND collection_name = ?",
+			collection.UserID, collection.Word, collection.Name)
+	// Caller's transaction updates the card, concurrent updates wait for it.
+	if postgres.InTx(ctx) {
+		query = query.Suffix("FOR UPDATE")
+	}
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("Word - Card - ToSql: %w", err)
+	}
+
+	card := collection
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, sql, args...).
+			Scan(
+				&card.SrcLang,
+				&card.TrgtLang,
+				&card.TimeDiff,
+				&card.LastRepeat,
+				&card.EaseFactor,
+				&card.Repetitions,
+				&card.Stability,
+				&card.Difficulty,
+			)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrWordNotInCollection
+		}
+		if err != nil {
+			return fmt.Errorf("Word - Card - Scan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("Word - Card - BeginFunc: %w", err)
+	}
+
+	return card, nil
+}
+
+func (p *Word) SaveReview(ctx context.Context, collection entity.Collection, reviewLog entity.ReviewLog) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - SaveReview")
+	defer span.End()
+
+	updateSQL, updateArgs, err := p.Builder.Update("user_collection").
+		Set("time_diff", collection.TimeDiff).
+		Set("last_repeat", collection.LastRepeat).
+		Set("ease_factor", collection.EaseFactor).
+		Set("repetitions", collection.Repetitions).
+		Set("stability", collection.Stability).
+		Set("difficulty", collection.Difficulty).
+		Set("introduced_at", sq.Expr("COALESCE(introduced_at, ?)", collection.LastRepeat)).
+		Where("user_id = ? AND word = ? AND collection_name = ?",
+			collection.UserID, collection.Word, collection.Name).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - SaveReview - ToSql: %w", err)
+	}
+
+	logSQL, logArgs, err := p.Builder.Insert("review_log").
+		Columns("user_id, word, collection_name, grade, scheduler, elapsed, prev_time_diff, next_time_diff, reviewed_at").
+		Values(
+			reviewLog.UserID,
+			reviewLog.Word,
+			reviewLog.CollectionName,
+			reviewLog.Grade,
+			reviewLog.Scheduler,
+			reviewLog.Elapsed,
+			reviewLog.PrevTimeDiff,
+			reviewLog.NextTimeDiff,
+			reviewLog.ReviewedAt,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - SaveReview - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, updateSQL, updateArgs...)
+		if err != nil {
+			return fmt.Errorf("Word - SaveReview - Exec: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return entity.ErrWordNotInCollection
+		}
+
+		if _, err := tx.Exec(ctx, logSQL, logArgs...); err != nil {
+			return fmt.Errorf("Word - SaveReview - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Word - SaveReview - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Word) WordHistory(ctx context.Context, collection entity.Collection) ([]entity.ReviewLog, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - WordHistory")
+	defer span.End()
+
+	query := p.Builder.
+		Select("word, collection_name, grade, scheduler, elapsed, prev_time_diff, next_time_diff, reviewed_at").
+		From("review_log").
+		Where("user_id = ? AND word = ?", collection.UserID, collection.Word).
+		OrderBy("reviewed_at, id")
+	if collection.Name != "" {
+		query = query.Where("collection_name = ?", collection.Name)
+	}
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Word - WordHistory - ToSql: %w", err)
+	}
+
+	reviews := make([]entity.ReviewLog, 0)
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("Word - WordHistory - Query: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			reviewLog := entity.ReviewLog{UserID: collection.UserID}
+			if err := rows.Scan(
+				&reviewLog.Word,
+				&reviewLog.CollectionName,
+				&reviewLog.Grade,
+				&reviewLog.Scheduler,
+				&reviewLog.Elapsed,
+				&reviewLog.PrevTimeDiff,
+				&reviewLog.NextTimeDiff,
+				&reviewLog.ReviewedAt,
+			); err != nil {
+				return fmt.Errorf("Word - WordHistory - Scan: %w", err)
+			}
+			reviews = append(reviews, reviewLog)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Word - WordHistory - BeginFunc: %w", err)
+	}
+
+	return reviews, nil
+}
+
 func (p *Word) IsWordInCollection(ctx context.Context, collection entity.Collection) (bool, error) {
 	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - IsWordInCollection")
 	defer span.End()
@@ -107,7 +494,7 @@ func (p *Word) IsWordInCollection(ctx context.Context, collection entity.Collect
 	}
 
 	var inColl bool
-	err = p.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
 		if err := tx.QueryRow(ctx, sql, args...).Scan(&inColl); err != nil {
 			return fmt.Errorf("Word - IsWordInCollection - Scan: %w", err)
 		}
@@ -127,14 +514,15 @@ func (p *Word) IsTransInDB(ctx context.Context, collection entity.Collection) (b
 	subQuery := p.Builder.
 		Select("*").
 		From("word_translation").
-		Where("word = ?", collection.Word)
+		Where("word = ? AND src_lang = ? AND trgt_lang = ?",
+			collection.Word, collection.SrcLang, collection.TrgtLang)
 	sql, args, err := sq.Expr("SELECT EXISTS(?)", subQuery).ToSql()
 	if err != nil {
 		return false, fmt.Errorf("Word - IsTransInDB - ToSql: %w", err)
 	}
 
 	var transInDB bool
-	err = p.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
 		if err := tx.QueryRow(ctx, sql, args...).Scan(&transInDB); err != nil {
 			return fmt.Errorf("Word - IsTransInDB - Scan: %w", err)
 		}
@@ -147,6 +535,7 @@ func (p *Word) IsTransInDB(ctx context.Context, collection entity.Collection) (b
 	return transInDB, nil
 }
 
+// DeleteWord deletes word from the collection, its review history is kept.
 func (p *Word) DeleteWord(ctx context.Context, collection entity.Collection) error {
 	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - DeleteWord")
 	defer span.End()
@@ -160,7 +549,7 @@ func (p *Word) DeleteWord(ctx context.Context, collection entity.Collection) err
 		return fmt.Errorf("Word - DeleteWord - ToSql: %w", err)
 	}
 
-	err = p.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
 		_, err := tx.Exec(ctx, sql, args...)
 		if err != nil {
 			return fmt.Errorf("Word - DeleteWord - Exec: %w", err)
@@ -174,25 +563,117 @@ func (p *Word) DeleteWord(ctx context.Context, collection entity.Collection) err
 	return nil
 }
 
+// AddWord adds word with the shared translation to the collection, ErrWordAlreadyInCollection is returned
+// if it is there already and ErrTranslationNotFound if the shared translation doesn't exist.
 func (p *Word) AddWord(ctx context.Context, collection entity.Collection) error {
 	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - AddWord")
 	defer span.End()
 
+	// Shared translation can't be deleted by Admin.DeleteTranslation until the card is committed.
+	transSQL, transArgs, err := p.Builder.Select("1").
+		From("word_translation").
+		Where("word = ? AND src_lang = ? AND trgt_lang = ?", collection.Word, collection.SrcLang, collection.TrgtLang).
+		Suffix("FOR KEY SHARE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - AddWord - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var found int
+		err := tx.QueryRow(ctx, transSQL, transArgs...).Scan(&found)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrTranslationNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("Word - AddWord - Scan: %w", err)
+		}
+		if err := p.insertWord(ctx, tx, collection); err != nil {
+			return fmt.Errorf("Word - AddWord - p.insertWord: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Word - AddWord - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// AddCustomWord adds word without a shared translation to the collection, translation of the card is
+// the user one. ErrWordAlreadyInCollection is returned if the word is there already.
+func (p *Word) AddCustomWord(ctx context.Context, collection entity.Collection) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - AddCustomWord")
+	defer span.End()
+
+	err := p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := p.insertWord(ctx, tx, collection); err != nil {
+			return fmt.Errorf("Word - AddCustomWord - p.insertWord: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Word - AddCustomWord - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// Inserts the card, collection is created on the first word added to it and takes its language pair.
+func (p *Word) insertWord(ctx context.Context, tx pgx.Tx, collection entity.Collection) error {
 	sql, args, err := p.Builder.Insert("user_collection").
-		Columns("user_id, word, collection_name, time_diff, last_repeat").
+		Columns("user_id, word, collection_name, src_lang, trgt_lang, time_diff, last_repeat").
 		Values(
 			collection.UserID,
 			collection.Word,
 			collection.Name,
+			collection.SrcLang,
+			collection.TrgtLang,
 			collection.TimeDiff,
 			collection.LastRepeat,
 		).
 		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - insertWord - ToSql: %w", err)
+	}
+	collSQL, collArgs, err := p.Builder.Insert("collections").
+		Columns("user_id, name, src_lang, trgt_lang").
+		Values(collection.UserID, collection.Name, collection.SrcLang, collection.TrgtLang).
+		Suffix(collectionPairUpsert).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - insertWord - ToSql: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, collSQL, collArgs...); err != nil {
+		return fmt.Errorf("Word - insertWord - Exec: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return entity.ErrWordAlreadyInCollection
+	}
+	if err != nil {
+		return fmt.Errorf("Word - insertWord - Exec: %w", err)
+	}
+	return nil
+}
+
+// AddTranslation stores translation of the word, translation stored concurrently by another request is kept.
+func (p *Word) AddTranslation(ctx context.Context, wordTrans entity.WordTrans) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - AddTranslation")
+	defer span.End()
+
+	sql, args, err := p.Builder.
+		Insert("word_translation").Columns("word, src_lang, trgt_lang, provider, provider_version, trans_data").
+		Values(wordTrans.Word, wordTrans.SrcLang, wordTrans.TrgtLang, wordTrans.Provider, wordTrans.ProviderVersion, wordTrans).
+		Suffix("ON CONFLICT (word, src_lang, trgt_lang) DO NOTHING").
+		ToSql()
 	if err != nil {
 		return fmt.Errorf("Word - AddTranslation - ToSql: %w", err)
 	}
 
-	err = p.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
 		_, err := tx.Exec(ctx, sql, args...)
 		if err != nil {
 			return fmt.Errorf("Word - AddTranslation - Exec: %w", err)
@@ -206,32 +687,269 @@ func (p *Word) AddWord(ctx context.Context, collection entity.Collection) error
 	return nil
 }
 
-func (p *Word) AddTranslation(ctx context.Context, wordTrans entity.WordTrans) error {
-	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - AddTranslation")
+// SaveUserTrans stores user edit of a word translation, fields of a previous edit are kept unless edited again.
+func (p *Word) SaveUserTrans(ctx context.Context, userTrans entity.UserTrans) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - SaveUserTrans")
 	defer span.End()
 
 	sql, args, err := p.Builder.
-		Insert("word_translation").Columns("word, trans_data").
-		Values(wordTrans.Word, wordTrans).
+		Insert("user_translation").Columns("user_id, word, src_lang, trgt_lang, trans_data").
+		Values(userTrans.UserID, userTrans.Word, userTrans.SrcLang, userTrans.TrgtLang, userTrans).
+		Suffix(`ON CONFLICT (user_id, word, src_lang, trgt_lang) DO UPDATE SET
+			trans_data = user_translation.trans_data || EXCLUDED.trans_data,
+			updated_at = NOW() AT TIME ZONE 'UTC'`).
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("Word - AddTranslation - ToSql: %w", err)
+		return fmt.Errorf("Word - SaveUserTrans - ToSql: %w", err)
 	}
 
-	err = p.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
 		_, err := tx.Exec(ctx, sql, args...)
 		if err != nil {
-			return fmt.Errorf("Word - AddTranslation - Exec: %w", err)
+			return fmt.Errorf("Word - SaveUserTrans - Exec: %w", err)
 		}
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("Word - AddTranslation - BeginFunc: %w", err)
+		return fmt.Errorf("Word - SaveUserTrans - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// MoveWord moves word with its learning progress and review history to the target collection,
+// target collection is created if it doesn't exist.
+func (p *Word) MoveWord(ctx context.Context, collection entity.Collection, target string) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - MoveWord")
+	defer span.End()
+
+	collSQL, collArgs, err := p.targetCollectionSQL(collection, target)
+	if err != nil {
+		return fmt.Errorf("Word - MoveWord - p.targetCollectionSQL: %w", err)
+	}
+
+	pairSQL, pairArgs, err := p.pairMismatchSQL(collection, target)
+	if err != nil {
+		return fmt.Errorf("Word - MoveWord - p.pairMismatchSQL: %w", err)
+	}
+
+	moveSQL, moveArgs, err := p.Builder.Update("user_collection").
+		Set("collection_name", target).
+		Where("user_id = ? AND word = ? AND collection_name = ?",
+			collection.UserID, collection.Word, collection.Name).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - MoveWord - ToSql: %w", err)
+	}
+
+	logSQL, logArgs, err := p.Builder.Update("review_log").
+		Set("collection_name", target).
+		Where("user_id = ? AND word = ? AND collection_name = ?",
+			collection.UserID, collection.Word, collection.Name).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - MoveWord - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, collSQL, collArgs...); err != nil {
+			return fmt.Errorf("Word - MoveWord - Exec: %w", err)
+		}
+		if err := p.checkPair(ctx, tx, pairSQL, pairArgs); err != nil {
+			return fmt.Errorf("Word - MoveWord - p.checkPair: %w", err)
+		}
+
+		tag, err := tx.Exec(ctx, moveSQL, moveArgs...)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return entity.ErrWordAlreadyInCollection
+		}
+		if err != nil {
+			return fmt.Errorf("Word - MoveWord - Exec: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return entity.ErrWordNotInCollection
+		}
+
+		if _, err := tx.Exec(ctx, logSQL, logArgs...); err != nil {
+			return fmt.Errorf("Word - MoveWord - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Word - MoveWord - BeginFunc: %w", err)
 	}
 
 	return nil
 }
 
+// CopyWord copies word with its learning progress and review history to the target collection,
+// target collection is created if it doesn't exist.
+func (p *Word) CopyWord(ctx context.Context, collection entity.Collection, target string) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - CopyWord")
+	defer span.End()
+
+	collSQL, collArgs, err := p.targetCollectionSQL(collection, target)
+	if err != nil {
+		return fmt.Errorf("Word - CopyWord - p.targetCollectionSQL: %w", err)
+	}
+
+	pairSQL, pairArgs, err := p.pairMismatchSQL(collection, target)
+	if err != nil {
+		return fmt.Errorf("Word - CopyWord - p.pairMismatchSQL: %w", err)
+	}
+
+	// Source row is locked so it can't be moved or deleted while copying.
+	sourceSQL, sourceArgs, err := p.Builder.Select("1").
+		From("user_collection").
+		Where("user_id = ? AND word = ? AND collection_name = ?",
+			collection.UserID, collection.Word, collection.Name).
+		Suffix("FOR SHARE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - CopyWord - ToSql: %w", err)
+	}
+
+	copySQL, copyArgs, err := p.Builder.Insert("user_collection").
+		Columns("user_id, word, collection_name, src_lang, trgt_lang, time_diff, last_repeat, ease_factor, repetitions, stability, difficulty, introduced_at").
+		Select(sq.
+			Select("user_id, word").
+			Column(sq.Expr("?", target)).
+			Columns("src_lang, trgt_lang, time_diff, last_repeat, ease_factor, repetitions, stability, difficulty, introduced_at").
+			From("user_collection").
+			Where("user_id = ? AND word = ? AND collection_name = ?",
+				collection.UserID, collection.Word, collection.Name)).
+		Suffix("ON CONFLICT (user_id, word, collection_name) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - CopyWord - ToSql: %w", err)
+	}
+
+	logSQL, logArgs, err := p.Builder.Insert("review_log").
+		Columns("user_id, word, collection_name, grade, scheduler, elapsed, prev_time_diff, next_time_diff, reviewed_at").
+		Select(sq.
+			Select("user_id, word").
+			Column(sq.Expr("?", target)).
+			Columns("grade, scheduler, elapsed, prev_time_diff, next_time_diff, reviewed_at").
+			From("review_log").
+			Where("user_id = ? AND word = ? AND collection_name = ?",
+				collection.UserID, collection.Word, collection.Name).
+			OrderBy("id")).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Word - CopyWord - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, collSQL, collArgs...); err != nil {
+			return fmt.Errorf("Word - CopyWord - Exec: %w", err)
+		}
+		if err := p.checkPair(ctx, tx, pairSQL, pairArgs); err != nil {
+			return fmt.Errorf("Word - CopyWord - p.checkPair: %w", err)
+		}
+
+		var exists int
+		err := tx.QueryRow(ctx, sourceSQL, sourceArgs...).Scan(&exists)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrWordNotInCollection
+		}
+		if err != nil {
+			return fmt.Errorf("Word - CopyWord - Scan: %w", err)
+		}
+
+		tag, err := tx.Exec(ctx, copySQL, copyArgs...)
+		if err != nil {
+			return fmt.Errorf("Word - CopyWord - Exec: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return entity.ErrWordAlreadyInCollection
+		}
+
+		if _, err := tx.Exec(ctx, logSQL, logArgs...); err != nil {
+			return fmt.Errorf("Word - CopyWord - Exec: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Word - CopyWord - BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// Sets language pair of a collection which doesn't have one yet.
+const collectionPairUpsert = `ON CONFLICT (user_id, name) DO UPDATE SET
+	src_lang = COALESCE(NULLIF(collections.src_lang, ''), EXCLUDED.src_lang),
+	trgt_lang = COALESCE(NULLIF(collections.trgt_lang, ''), EXCLUDED.trgt_lang)`
+
+// Creates target collection with the language pair of the word if it doesn't exist.
+func (p *Word) targetCollectionSQL(collection entity.Collection, target string) (string, []interface{}, error) {
+	return p.Builder.Insert("collections").
+		Columns("user_id, name, src_lang, trgt_lang").
+		Select(sq.
+			Select("user_id").
+			Column(sq.Expr("?", target)).
+			Columns("src_lang, trgt_lang").
+			From("user_collection").
+			Where("user_id = ? AND word = ? AND collection_name = ?",
+				collection.UserID, collection.Word, collection.Name)).
+		Suffix(collectionPairUpsert).
+		ToSql()
+}
+
+// Selects a row if language pair of the target collection differs from the word's one.
+func (p *Word) pairMismatchSQL(collection entity.Collection, target string) (string, []interface{}, error) {
+	return p.Builder.Select("1").
+		From("user_collection uc").
+		Join("collections c ON c.user_id = uc.user_id AND c.name = ?", target).
+		Where("uc.user_id = ? AND uc.word = ? AND uc.collection_name = ?",
+			collection.UserID, collection.Word, collection.Name).
+		Where("(c.src_lang, c.trgt_lang) <> (uc.src_lang, uc.trgt_lang)").
+		ToSql()
+}
+
+func (p *Word) checkPair(ctx context.Context, tx pgx.Tx, sql string, args []interface{}) error {
+	var mismatch int
+	err := tx.QueryRow(ctx, sql, args...).Scan(&mismatch)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Word - checkPair - Scan: %w", err)
+	}
+	return entity.ErrLanguagePairMismatch
+}
+
+// LanguagePair returns language pair of the collection, empty when collection doesn't exist or has no pair.
+func (p *Word) LanguagePair(ctx context.Context, collection entity.Collection) (srcLang, trgtLang string, err error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordPostgresql - LanguagePair")
+	defer span.End()
+
+	sql, args, err := p.Builder.Select("src_lang, trgt_lang").
+		From("collections").
+		Where("user_id = ? AND name = ?", collection.UserID, collection.Name).
+		ToSql()
+	if err != nil {
+		return "", "", fmt.Errorf("Word - LanguagePair - ToSql: %w", err)
+	}
+
+	err = p.BeginFunc(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, sql, args...).Scan(&srcLang, &trgtLang)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Word - LanguagePair - Scan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("Word - LanguagePair - BeginFunc: %w", err)
+	}
+
+	return srcLang, trgtLang, nil
+}
+
 func NewWordPostgre(pool *postgres.ConnPool) *Word {
 	return &Word{
 		pool,
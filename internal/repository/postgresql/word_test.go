@@ -2,8 +2,14 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/postgres"
@@ -52,6 +58,36 @@ func Test_UserWords(t *testing.T) {
 	}
 }
 
+func Test_UpdateLearnIntervalResetsScheduler(t *testing.T) {
+	ctx := context.Background()
+	wordRepo := NewWordPostgre(setupContainer(ctx, t, "UpdateLearnIntervalResetsScheduler"))
+
+	coll := entity.Collection{
+		UserID:      "12345",
+		Word:        "test_word",
+		Name:        "test_coll",
+		LastRepeat:  time.Now().UTC().Truncate(time.Second),
+		TimeDiff:    60 * 24 * time.Hour,
+		EaseFactor:  1.8,
+		Repetitions: 7,
+		Stability:   60,
+		Difficulty:  8,
+	}
+	setupReviewedWord(ctx, t, coll, wordRepo)
+
+	coll.TimeDiff = 7 * 24 * time.Hour
+	if err := wordRepo.UpdateLearnInterval(ctx, coll); err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	got, err := wordRepo.Card(ctx, coll)
+	if err != nil {
+		t.Fatalf("wordRepo.Card: %v", err)
+	}
+	if got.TimeDiff != coll.TimeDiff || got.EaseFactor != 2.5 || got.Repetitions != 2 || got.Stability != 0 || got.Difficulty != 0 {
+		t.Fatalf("want scheduler state of the interval but got: %+v", got)
+	}
+}
+
 func Test_UpdateLearnInterval(t *testing.T) {
 	type args struct {
 		coll entity.Collection
@@ -143,10 +179,11 @@ func Test_IsTranslationInDB(t *testing.T) {
 		coll entity.Collection
 	}
 	tests := []struct {
-		name    string
-		want    bool
-		wantErr bool
-		args    args
+		name      string
+		want      bool
+		wantErr   bool
+		otherPair bool
+		args      args
 	}{
 		{
 			name: "Not_existing_trans",
@@ -169,6 +206,19 @@ func Test_IsTranslationInDB(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Trans_of_other_language_pair",
+			args: args{
+				coll: entity.Collection{
+					Name:     "test_coll",
+					Word:     "test_word",
+					UserID:   "12345",
+					SrcLang:  "de",
+					TrgtLang: "es",
+				},
+			},
+			otherPair: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +227,9 @@ func Test_IsTranslationInDB(t *testing.T) {
 		if tt.want {
 			setupAddTranslationToDB(ctx, t, tt.args.coll, wordRepo)
 		}
+		if tt.otherPair {
+			setupAddTranslationToDB(ctx, t, entity.Collection{Word: tt.args.coll.Word, SrcLang: "en", TrgtLang: "ru"}, wordRepo)
+		}
 
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := wordRepo.IsTransInDB(ctx, tt.args.coll)
@@ -228,6 +281,42 @@ func Test_DeleteWord(t *testing.T) {
 	}
 }
 
+func Test_DeleteWordKeepsHistory(t *testing.T) {
+	ctx := context.Background()
+	pool := setupContainer(ctx, t, "DeleteWordKeepsHistory")
+	wordRepo, collRepo := NewWordPostgre(pool), NewCollectionPostgre(pool)
+
+	coll := entity.Collection{UserID: "12345", Word: "test_word", Name: "test_coll", LastRepeat: time.Now().UTC(), TimeDiff: time.Hour}
+	setupReviewedWord(ctx, t, coll, wordRepo)
+	other := entity.Collection{UserID: "12345", Word: "other_word", Name: "other_coll", LastRepeat: time.Now().UTC(), TimeDiff: time.Hour}
+	setupReviewedWord(ctx, t, other, wordRepo)
+
+	if err := wordRepo.DeleteWord(ctx, coll); err != nil {
+		t.Fatalf("wordRepo.DeleteWord: %v", err)
+	}
+	colls, err := collRepo.Collections(ctx, "12345")
+	if err != nil {
+		t.Fatalf("collRepo.Collections: %v", err)
+	}
+	for _, c := range colls {
+		if c.Name == other.Name {
+			if err := collRepo.DeleteCollection(ctx, c, true); err != nil {
+				t.Fatalf("collRepo.DeleteCollection: %v", err)
+			}
+		}
+	}
+
+	for _, c := range []entity.Collection{coll, other} {
+		history, err := wordRepo.WordHistory(ctx, entity.Collection{UserID: c.UserID, Word: c.Word})
+		if err != nil {
+			t.Fatalf("wordRepo.WordHistory: %v", err)
+		}
+		if len(history) != 1 {
+			t.Fatalf("review history of %v must be kept, got: %+v", c.Word, history)
+		}
+	}
+}
+
 func Test_AddWord(t *testing.T) {
 	type args struct {
 		coll entity.Collection
@@ -235,7 +324,11 @@ func Test_AddWord(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		wantErr bool
+		noTrans bool
+		custom  bool
+		// Word is in the collection already.
+		added   bool
+		wantErr error
 	}{
 		{
 			name: "Add_existing_word",
@@ -248,7 +341,7 @@ func Test_AddWord(t *testing.T) {
 			},
 		},
 		{
-			name: "Add_not_existing_word",
+			name: "Add_word_without_shared_translation",
 			args: args{
 				coll: entity.Collection{
 					Name:   "test_coll",
@@ -256,43 +349,135 @@ func Test_AddWord(t *testing.T) {
 					UserID: "12345",
 				},
 			},
-			wantErr: true,
+			noTrans: true,
+			wantErr: entity.ErrTranslationNotFound,
+		},
+		{
+			// Custom cards have only a user translation.
+			name: "Add_custom_word",
+			args: args{
+				coll: entity.Collection{
+					Name:   "test_coll",
+					Word:   "not_exist",
+					UserID: "12345",
+				},
+			},
+			noTrans: true,
+			custom:  true,
+		},
+		{
+			name: "Add_custom_word_twice",
+			args: args{
+				coll: entity.Collection{
+					Name:   "test_coll",
+					Word:   "not_exist",
+					UserID: "12345",
+				},
+			},
+			noTrans: true,
+			custom:  true,
+			added:   true,
+			wantErr: entity.ErrWordAlreadyInCollection,
+		},
+		{
+			name: "Add_word_twice",
+			args: args{
+				coll: entity.Collection{
+					Name:   "test_coll",
+					Word:   "test_word",
+					UserID: "12345",
+				},
+			},
+			added:   true,
+			wantErr: entity.ErrWordAlreadyInCollection,
 		},
 	}
 	for _, tt := range tests {
 		ctx := context.Background()
 		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
-		if !tt.wantErr {
+		if !tt.noTrans {
 			setupAddTranslationToDB(ctx, t, tt.args.coll, wordRepo)
 		}
+		if tt.added {
+			setupAddWordToUser(ctx, t, tt.args.coll, wordRepo)
+		}
 
 		t.Run(tt.name, func(t *testing.T) {
-			err := wordRepo.AddWord(ctx, tt.args.coll)
-			if (err != nil) != tt.wantErr {
-				t.Fatalf("want err but got: %v", err)
+			addWord := wordRepo.AddWord
+			if tt.custom {
+				addWord = wordRepo.AddCustomWord
+			}
+			err := addWord(ctx, tt.args.coll)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
 			}
 		})
 	}
 }
 
+func Test_AddWordWithinTx(t *testing.T) {
+	ctx := context.Background()
+	wordRepo := setupWordRepoContainer(ctx, t, "Test_AddWordWithinTx")
+	coll := entity.Collection{Name: "test_coll", Word: "test_word", UserID: "12345", SrcLang: "en", TrgtLang: "ru"}
+	setupAddTranslationToDB(ctx, t, coll, wordRepo)
+	setupAddWordToUser(ctx, t, coll, wordRepo)
+
+	err := wordRepo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := wordRepo.AddTranslation(ctx, entity.WordTrans{Word: "new_word", SrcLang: "en", TrgtLang: "ru"}); err != nil {
+			return err
+		}
+		return wordRepo.AddWord(ctx, coll)
+	})
+	if !errors.Is(err, entity.ErrWordAlreadyInCollection) {
+		t.Fatalf("want err: %v but got: %v", entity.ErrWordAlreadyInCollection, err)
+	}
+
+	// Translation is rolled back with the failed word.
+	stored, err := wordRepo.IsTransInDB(ctx, entity.Collection{Word: "new_word", SrcLang: "en", TrgtLang: "ru"})
+	if err != nil {
+		t.Fatalf("IsTransInDB: %v", err)
+	}
+	if stored {
+		t.Fatal("want translation rolled back but it is stored")
+	}
+}
+
 func Test_AddTrans(t *testing.T) {
 	type args struct {
 		wordTrans entity.WordTrans
 	}
 	tests := []struct {
-		name    string
-		args    args
+		name string
+		args args
+		// Translation is stored concurrently by another request.
+		stored  bool
 		wantErr bool
 	}{
 		{
 			name: "Add_normal_word",
 			args: args{
 				wordTrans: entity.WordTrans{
-					Word: "test_word",
+					Word:     "test_word",
+					SrcLang:  "en",
+					TrgtLang: "ru",
+					Provider: "google",
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Add_stored_word",
+			args: args{
+				wordTrans: entity.WordTrans{
+					Word:     "test_word",
+					SrcLang:  "en",
+					TrgtLang: "ru",
+					Provider: "google",
+				},
+			},
+			stored:  true,
+			wantErr: false,
+		},
 		{
 			name:    "Add_empty_word",
 			wantErr: true,
@@ -302,6 +487,11 @@ func Test_AddTrans(t *testing.T) {
 	for _, tt := range tests {
 		ctx := context.Background()
 		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		if tt.stored {
+			if err := wordRepo.AddTranslation(ctx, tt.args.wordTrans); err != nil {
+				t.Fatalf("Test_AddTrans - AddTranslation: %v", err)
+			}
+		}
 
 		t.Run(tt.name, func(t *testing.T) {
 			err := wordRepo.AddTranslation(ctx, tt.args.wordTrans)
@@ -312,13 +502,371 @@ func Test_AddTrans(t *testing.T) {
 	}
 }
 
+func Test_Card(t *testing.T) {
+	type args struct {
+		coll entity.Collection
+	}
+	tests := []struct {
+		name    string
+		args    args
+		exists  bool
+		wantErr error
+	}{
+		{
+			name: "Existing_card",
+			args: args{
+				coll: entity.Collection{
+					Name:     "test_coll",
+					Word:     "test_word",
+					UserID:   "12345",
+					TimeDiff: 24 * time.Hour,
+				},
+			},
+			exists: true,
+		},
+		{
+			name: "Not_existing_card",
+			args: args{
+				coll: entity.Collection{
+					Name:   "test_coll",
+					Word:   "not_exist",
+					UserID: "12345",
+				},
+			},
+			wantErr: entity.ErrWordNotInCollection,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		if tt.exists {
+			setupAddTranslationToDB(ctx, t, tt.args.coll, wordRepo)
+			setupAddWordToUser(ctx, t, tt.args.coll, wordRepo)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wordRepo.Card(ctx, tt.args.coll)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			if tt.exists && got.TimeDiff != tt.args.coll.TimeDiff {
+				t.Fatalf("want time diff %v but got: %v", tt.args.coll.TimeDiff, got.TimeDiff)
+			}
+		})
+	}
+}
+
+func Test_SaveReview(t *testing.T) {
+	type args struct {
+		coll entity.Collection
+	}
+	tests := []struct {
+		name    string
+		args    args
+		exists  bool
+		wantErr error
+	}{
+		{
+			name: "Save_review",
+			args: args{
+				coll: entity.Collection{
+					Name:        "test_coll",
+					Word:        "test_word",
+					UserID:      "12345",
+					TimeDiff:    6 * 24 * time.Hour,
+					EaseFactor:  2.6,
+					Repetitions: 2,
+				},
+			},
+			exists: true,
+		},
+		{
+			name: "Save_review_not_existing_card",
+			args: args{
+				coll: entity.Collection{
+					Name:   "test_coll",
+					Word:   "not_exist",
+					UserID: "12345",
+				},
+			},
+			wantErr: entity.ErrWordNotInCollection,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		if tt.exists {
+			setupAddTranslationToDB(ctx, t, tt.args.coll, wordRepo)
+			setupAddWordToUser(ctx, t, entity.Collection{
+				UserID: tt.args.coll.UserID,
+				Word:   tt.args.coll.Word,
+				Name:   tt.args.coll.Name,
+			}, wordRepo)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			reviewLog := entity.ReviewLog{
+				UserID:         tt.args.coll.UserID,
+				Word:           tt.args.coll.Word,
+				CollectionName: tt.args.coll.Name,
+				Grade:          entity.GradeGood,
+				Scheduler:      entity.SchedulerSM2,
+				NextTimeDiff:   tt.args.coll.TimeDiff,
+				ReviewedAt:     time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC),
+			}
+			err := wordRepo.SaveReview(ctx, tt.args.coll, reviewLog)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			if !tt.exists {
+				return
+			}
+			got, err := wordRepo.Card(ctx, tt.args.coll)
+			if err != nil {
+				t.Fatalf("wordRepo.Card: %v", err)
+			}
+			if diff := cmp.Diff(tt.args.coll, got); diff != "" {
+				t.Fatalf("card must be equal diff: %v", diff)
+			}
+			history, err := wordRepo.WordHistory(ctx, tt.args.coll)
+			if err != nil {
+				t.Fatalf("wordRepo.WordHistory: %v", err)
+			}
+			if diff := cmp.Diff([]entity.ReviewLog{reviewLog}, history); diff != "" {
+				t.Fatalf("review log must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
+func Test_WordHistory(t *testing.T) {
+	reviewedAt := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	review := func(collName string, grade entity.Grade, reviewedAt time.Time) entity.ReviewLog {
+		return entity.ReviewLog{
+			UserID:         "12345",
+			Word:           "test_word",
+			CollectionName: collName,
+			Grade:          grade,
+			Scheduler:      entity.SchedulerSM2,
+			NextTimeDiff:   24 * time.Hour,
+			ReviewedAt:     reviewedAt,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		reviews []entity.ReviewLog
+		coll    entity.Collection
+		want    []entity.ReviewLog
+	}{
+		{
+			name: "History_of_collection",
+			reviews: []entity.ReviewLog{
+				review("coll_1", entity.GradeGood, reviewedAt.Add(time.Hour)),
+				review("coll_1", entity.GradeAgain, reviewedAt),
+				review("coll_2", entity.GradeEasy, reviewedAt),
+			},
+			coll: entity.Collection{UserID: "12345", Word: "test_word", Name: "coll_1"},
+			want: []entity.ReviewLog{
+				review("coll_1", entity.GradeAgain, reviewedAt),
+				review("coll_1", entity.GradeGood, reviewedAt.Add(time.Hour)),
+			},
+		},
+		{
+			name: "History_of_all_collections",
+			reviews: []entity.ReviewLog{
+				review("coll_1", entity.GradeGood, reviewedAt.Add(time.Hour)),
+				review("coll_2", entity.GradeEasy, reviewedAt),
+			},
+			coll: entity.Collection{UserID: "12345", Word: "test_word"},
+			want: []entity.ReviewLog{
+				review("coll_2", entity.GradeEasy, reviewedAt),
+				review("coll_1", entity.GradeGood, reviewedAt.Add(time.Hour)),
+			},
+		},
+		{
+			name: "Empty_history",
+			coll: entity.Collection{UserID: "12345", Word: "test_word", Name: "coll_1"},
+			want: []entity.ReviewLog{},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		added := make(map[string]bool)
+		for _, r := range tt.reviews {
+			coll := entity.Collection{UserID: r.UserID, Word: r.Word, Name: r.CollectionName}
+			if !added[r.CollectionName] {
+				if len(added) == 0 {
+					setupAddTranslationToDB(ctx, t, coll, wordRepo)
+				}
+				setupAddWordToUser(ctx, t, coll, wordRepo)
+				added[r.CollectionName] = true
+			}
+			coll.LastRepeat, coll.TimeDiff = r.ReviewedAt, r.NextTimeDiff
+			if err := wordRepo.SaveReview(ctx, coll, r); err != nil {
+				t.Fatalf("wordRepo.SaveReview: %v", err)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wordRepo.WordHistory(ctx, tt.coll)
+			if err != nil {
+				t.Fatalf("wordRepo.WordHistory: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("history must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
+func Test_DueWords(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	reviewed := func(word string, lastRepeat time.Time, timeDiff time.Duration) entity.Collection {
+		return entity.Collection{
+			UserID:      "12345",
+			Name:        "test_coll",
+			Word:        word,
+			LastRepeat:  lastRepeat,
+			TimeDiff:    timeDiff,
+			Repetitions: 1,
+		}
+	}
+	newWord := func(word string) entity.Collection {
+		return entity.Collection{UserID: "12345", Name: "test_coll", Word: word, LastRepeat: now}
+	}
+
+	tests := []struct {
+		name      string
+		reviewed  []entity.Collection
+		new       []entity.Collection
+		query     entity.DueQuery
+		wantWords []string
+		wantNew   []string
+	}{
+		{
+			name: "Due_words_by_overdue",
+			reviewed: []entity.Collection{
+				reviewed("later", now.Add(-48*time.Hour), 24*time.Hour),
+				reviewed("earlier", now.Add(-72*time.Hour), 24*time.Hour),
+				reviewed("not_due", now.Add(-time.Hour), 24*time.Hour),
+			},
+			query: entity.DueQuery{
+				UserID:   "12345",
+				Limit:    10,
+				NewLimit: 10,
+				Now:      now,
+				DayStart: now.Truncate(24 * time.Hour),
+			},
+			wantWords: []string{"earlier", "later"},
+		},
+		{
+			name: "New_words_capped",
+			new:  []entity.Collection{newWord("new_1"), newWord("new_2"), newWord("new_3")},
+			reviewed: []entity.Collection{
+				reviewed("introduced_today", now, 24*time.Hour),
+				reviewed("due", now.Add(-48*time.Hour), 24*time.Hour),
+			},
+			query: entity.DueQuery{
+				UserID:   "12345",
+				Limit:    10,
+				NewLimit: 3,
+				Now:      now,
+				DayStart: now.Truncate(24 * time.Hour),
+			},
+			wantWords: []string{"due"},
+			wantNew:   []string{"new_1", "new_2"},
+		},
+		{
+			name: "Limit_prefers_due_words",
+			new:  []entity.Collection{newWord("new_1")},
+			reviewed: []entity.Collection{
+				reviewed("due_1", now.Add(-72*time.Hour), 24*time.Hour),
+				reviewed("due_2", now.Add(-48*time.Hour), 24*time.Hour),
+			},
+			query: entity.DueQuery{
+				UserID:   "12345",
+				Limit:    2,
+				NewLimit: 5,
+				Now:      now,
+				DayStart: now.Truncate(24 * time.Hour),
+			},
+			wantWords: []string{"due_1", "due_2"},
+		},
+		{
+			name: "New_words_fill_limit",
+			new:  []entity.Collection{newWord("new_1"), newWord("new_2")},
+			reviewed: []entity.Collection{
+				reviewed("due_1", now.Add(-72*time.Hour), 24*time.Hour),
+				reviewed("due_2", now.Add(-48*time.Hour), 24*time.Hour),
+			},
+			query: entity.DueQuery{
+				UserID:   "12345",
+				Limit:    3,
+				NewLimit: 5,
+				Now:      now,
+				DayStart: now.Truncate(24 * time.Hour),
+			},
+			wantWords: []string{"due_1", "due_2"},
+			wantNew:   []string{"new_1"},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		for _, coll := range append(tt.new, tt.reviewed...) {
+			setupAddTranslationToDB(ctx, t, coll, wordRepo)
+			setupAddWordToUser(ctx, t, coll, wordRepo)
+		}
+		for _, coll := range tt.reviewed {
+			reviewLog := entity.ReviewLog{
+				UserID:         coll.UserID,
+				Word:           coll.Word,
+				CollectionName: coll.Name,
+				Grade:          entity.GradeGood,
+				Scheduler:      entity.SchedulerSM2,
+				NextTimeDiff:   coll.TimeDiff,
+				ReviewedAt:     coll.LastRepeat,
+			}
+			if err := wordRepo.SaveReview(ctx, coll, reviewLog); err != nil {
+				t.Fatalf("wordRepo.SaveReview: %v", err)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wordRepo.DueWords(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("want nil but got: %v", err)
+			}
+			var words, newWords []string
+			for _, w := range got.Reviews {
+				words = append(words, w.Word)
+			}
+			for _, w := range got.New {
+				newWords = append(newWords, w.Word)
+			}
+			if diff := cmp.Diff(tt.wantWords, words); diff != "" {
+				t.Fatalf("due words must be equal diff: %v", diff)
+			}
+			if diff := cmp.Diff(tt.wantNew, newWords); diff != "" {
+				t.Fatalf("new words must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
 func setupAddTranslationToDB(ctx context.Context, t *testing.T, coll entity.Collection, wordRepo *Word) {
 	t.Helper()
 
 	// Add translation to DB.
 	sql, args, err := wordRepo.Builder.
-		Insert("word_translation").Columns("word, trans_data").
-		Values(coll.Word, entity.WordTrans{}).
+		Insert("word_translation").Columns("word, src_lang, trgt_lang, trans_data").
+		Values(coll.Word, coll.SrcLang, coll.TrgtLang, entity.WordTrans{Word: coll.Word, SrcLang: coll.SrcLang, TrgtLang: coll.TrgtLang}).
 		ToSql()
 	if err != nil {
 		t.Fatalf("wordRepo.Builder.ToSql: %v", err)
@@ -328,16 +876,38 @@ func setupAddTranslationToDB(ctx context.Context, t *testing.T, coll entity.Coll
 	}
 }
 
+// Creates collection with the language pair of coll if it doesn't exist.
+func setupCollection(ctx context.Context, t *testing.T, coll entity.Collection, wordRepo *Word) {
+	t.Helper()
+
+	sql, args, err := wordRepo.Builder.
+		Insert("collections").
+		Columns("user_id, name, src_lang, trgt_lang").
+		Values(coll.UserID, coll.Name, coll.SrcLang, coll.TrgtLang).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		t.Fatalf("wordRepo.Builder.ToSql: %v", err)
+	}
+	if _, err := wordRepo.Pool.Exec(ctx, sql, args...); err != nil {
+		t.Fatalf("add collection failed: %v", err)
+	}
+}
+
 func setupAddWordToUser(ctx context.Context, t *testing.T, coll entity.Collection, wordRepo *Word) {
 	t.Helper()
 
+	setupCollection(ctx, t, coll, wordRepo)
+
 	sql, args, err := wordRepo.Builder.
 		Insert("user_collection").
-		Columns("user_id, word, collection_name, time_diff, last_repeat").
+		Columns("user_id, word, collection_name, src_lang, trgt_lang, time_diff, last_repeat").
 		Values(
 			coll.UserID,
 			coll.Word,
 			coll.Name,
+			coll.SrcLang,
+			coll.TrgtLang,
 			coll.TimeDiff,
 			coll.LastRepeat,
 		).
@@ -350,8 +920,35 @@ func setupAddWordToUser(ctx context.Context, t *testing.T, coll entity.Collectio
 	}
 }
 
-// Creates new throw away postgres:alpine container.
+// Concatenates all up migrations, so test containers have the same schema as production.
+func migrationsSQL(t *testing.T) string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("migrations", "*.up.sql"))
+	if err != nil {
+		t.Fatalf("migrationsSQL - filepath.Glob: %v", err)
+	}
+	sort.Strings(files)
+
+	var sql strings.Builder
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatalf("migrationsSQL - os.ReadFile: %v", err)
+		}
+		sql.Write(data)
+		sql.WriteString("\n")
+	}
+	return sql.String()
+}
+
 func setupWordRepoContainer(ctx context.Context, t *testing.T, containerName string) *Word {
+	t.Helper()
+	return NewWordPostgre(setupContainer(ctx, t, containerName))
+}
+
+// Creates new throw away postgres:alpine container.
+func setupContainer(ctx context.Context, t *testing.T, containerName string) *postgres.ConnPool {
 	t.Helper()
 	const (
 		db   = "test_db"
@@ -359,23 +956,7 @@ func setupWordRepoContainer(ctx context.Context, t *testing.T, containerName str
 		pass = "password"
 	)
 
-	sql := `
-		CREATE TABLE IF NOT EXISTS word_translation(
-			word                TEXT                                                                NOT NULL CHECK(word != ''),
-			trans_data          JSONB                                                               NOT NULL,
-			PRIMARY KEY (word)
-		);
-		
-		CREATE TABLE IF NOT EXISTS user_collection(
-			user_id                                     TEXT                                        NOT NULL,
-			word                                        TEXT                                        NOT NULL CHECK(word != ''),
-			collection_name                             TEXT                                        NOT NULL,
-			time_diff                                   INTERVAL                                    NOT NULL,
-			last_repeat                                 TIMESTAMP                                   NOT NULL,
-			FOREIGN KEY (word) REFERENCES word_translation(word),
-			UNIQUE(user_id, word, collection_name)
-		);	
-	`
+	sql := migrationsSQL(t)
 
 	t.Log("starting up a psql container")
 	c, err := psqldocker.NewContainer(
@@ -386,18 +967,413 @@ func setupWordRepoContainer(ctx context.Context, t *testing.T, containerName str
 		psqldocker.WithSQL(sql),
 	)
 	if err != nil {
-		t.Fatalf("setupWordRepo - psqldocker.NewContainer: %v", err)
+		t.Fatalf("setupContainer - psqldocker.NewContainer: %v", err)
 	}
 	t.Cleanup(func() {
 		if err := c.Close(); err != nil {
-			t.Fatalf("setupWordRepo - Cleanup - c.Close: %v", err)
+			t.Fatalf("setupContainer - Cleanup - c.Close: %v", err)
 		}
 	})
 
 	connPool, err := postgres.New(ctx, fmt.Sprintf("postgresql://%s:%s@0.0.0.0:%s/%s", user, pass, c.Port(), db), 10)
 	if err != nil {
-		t.Fatalf("setupWordRepo - postgres.New: %v", err)
+		t.Fatalf("setupContainer - postgres.New: %v", err)
+	}
+
+	return connPool
+}
+
+func Test_MoveWord(t *testing.T) {
+	coll := entity.Collection{
+		UserID:      "12345",
+		Word:        "test_word",
+		Name:        "test_coll",
+		LastRepeat:  time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC),
+		TimeDiff:    6 * 24 * time.Hour,
+		EaseFactor:  2.5,
+		Repetitions: 2,
+	}
+	tests := []struct {
+		name        string
+		inTarget    bool
+		otherPair   bool
+		coll        entity.Collection
+		wantErr     error
+		wantHistory int
+	}{
+		{
+			name:        "Move_word",
+			coll:        coll,
+			wantHistory: 1,
+		},
+		{
+			name:     "Move_word_to_collection_with_the_word",
+			inTarget: true,
+			coll:     coll,
+			wantErr:  entity.ErrWordAlreadyInCollection,
+		},
+		{
+			name:      "Move_word_to_collection_of_other_language_pair",
+			otherPair: true,
+			coll:      coll,
+			wantErr:   entity.ErrLanguagePairMismatch,
+		},
+		{
+			name:    "Move_word_not_in_collection",
+			coll:    entity.Collection{UserID: "12345", Word: "test_word", Name: "other_coll"},
+			wantErr: entity.ErrWordNotInCollection,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		setupReviewedWord(ctx, t, coll, wordRepo)
+		target := entity.Collection{UserID: coll.UserID, Word: coll.Word, Name: "target_coll"}
+		if tt.inTarget {
+			setupAddWordToUser(ctx, t, target, wordRepo)
+		}
+		if tt.otherPair {
+			setupCollection(ctx, t, entity.Collection{UserID: target.UserID, Name: target.Name, SrcLang: "de", TrgtLang: "es"}, wordRepo)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := wordRepo.MoveWord(ctx, tt.coll, target.Name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			got, err := wordRepo.Card(ctx, target)
+			if err != nil {
+				t.Fatalf("wordRepo.Card: %v", err)
+			}
+			if got.TimeDiff != coll.TimeDiff || !got.LastRepeat.Equal(coll.LastRepeat) {
+				t.Fatalf("learning progress must be kept but got: %v", got)
+			}
+			if _, err := wordRepo.Card(ctx, coll); !errors.Is(err, entity.ErrWordNotInCollection) {
+				t.Fatalf("word must be removed from source collection but got: %v", err)
+			}
+			history, err := wordRepo.WordHistory(ctx, target)
+			if err != nil {
+				t.Fatalf("wordRepo.WordHistory: %v", err)
+			}
+			if len(history) != tt.wantHistory {
+				t.Fatalf("want %v reviews but got: %v", tt.wantHistory, history)
+			}
+		})
+	}
+}
+
+func Test_CopyWord(t *testing.T) {
+	coll := entity.Collection{
+		UserID:      "12345",
+		Word:        "test_word",
+		Name:        "test_coll",
+		LastRepeat:  time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC),
+		TimeDiff:    6 * 24 * time.Hour,
+		EaseFactor:  2.5,
+		Repetitions: 2,
+	}
+	tests := []struct {
+		name      string
+		inTarget  bool
+		otherPair bool
+		coll      entity.Collection
+		wantErr   error
+	}{
+		{
+			name: "Copy_word",
+			coll: coll,
+		},
+		{
+			name:     "Copy_word_to_collection_with_the_word",
+			inTarget: true,
+			coll:     coll,
+			wantErr:  entity.ErrWordAlreadyInCollection,
+		},
+		{
+			name:      "Copy_word_to_collection_of_other_language_pair",
+			otherPair: true,
+			coll:      coll,
+			wantErr:   entity.ErrLanguagePairMismatch,
+		},
+		{
+			name:    "Copy_word_not_in_collection",
+			coll:    entity.Collection{UserID: "12345", Word: "test_word", Name: "other_coll"},
+			wantErr: entity.ErrWordNotInCollection,
+		},
 	}
 
-	return NewWordPostgre(connPool)
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		setupReviewedWord(ctx, t, coll, wordRepo)
+		target := entity.Collection{UserID: coll.UserID, Word: coll.Word, Name: "target_coll"}
+		if tt.inTarget {
+			setupAddWordToUser(ctx, t, target, wordRepo)
+		}
+		if tt.otherPair {
+			setupCollection(ctx, t, entity.Collection{UserID: target.UserID, Name: target.Name, SrcLang: "de", TrgtLang: "es"}, wordRepo)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := wordRepo.CopyWord(ctx, tt.coll, target.Name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			for _, c := range []entity.Collection{coll, target} {
+				got, err := wordRepo.Card(ctx, c)
+				if err != nil {
+					t.Fatalf("wordRepo.Card: %v", err)
+				}
+				if got.TimeDiff != coll.TimeDiff || got.Repetitions != coll.Repetitions {
+					t.Fatalf("learning progress must be kept but got: %v", got)
+				}
+				history, err := wordRepo.WordHistory(ctx, c)
+				if err != nil {
+					t.Fatalf("wordRepo.WordHistory: %v", err)
+				}
+				if len(history) != 1 {
+					t.Fatalf("want review history in both collections but got: %v", history)
+				}
+			}
+		})
+	}
+}
+
+func Test_LanguagePair(t *testing.T) {
+	tests := []struct {
+		name         string
+		coll         entity.Collection
+		wantSrcLang  string
+		wantTrgtLang string
+	}{
+		{
+			name:         "Pair_of_first_word",
+			coll:         entity.Collection{UserID: "12345", Word: "Geschenk", Name: "deutsch", SrcLang: "de", TrgtLang: "es"},
+			wantSrcLang:  "de",
+			wantTrgtLang: "es",
+		},
+		{
+			name: "Not_existing_collection",
+			coll: entity.Collection{UserID: "12345", Name: "not_exist"},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		if tt.wantSrcLang != "" {
+			setupAddTranslationToDB(ctx, t, tt.coll, wordRepo)
+			if err := wordRepo.AddWord(ctx, tt.coll); err != nil {
+				t.Fatalf("wordRepo.AddWord: %v", err)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			srcLang, trgtLang, err := wordRepo.LanguagePair(ctx, tt.coll)
+			if err != nil {
+				t.Fatalf("want nil but got: %v", err)
+			}
+			if srcLang != tt.wantSrcLang || trgtLang != tt.wantTrgtLang {
+				t.Fatalf("want %v-%v but got: %v-%v", tt.wantSrcLang, tt.wantTrgtLang, srcLang, trgtLang)
+			}
+		})
+	}
+}
+
+// Adds word to user collection and reviews it once.
+func setupReviewedWord(ctx context.Context, t *testing.T, coll entity.Collection, wordRepo *Word) {
+	t.Helper()
+
+	setupAddTranslationToDB(ctx, t, coll, wordRepo)
+	setupAddWordToUser(ctx, t, entity.Collection{UserID: coll.UserID, Word: coll.Word, Name: coll.Name}, wordRepo)
+	reviewLog := entity.ReviewLog{
+		UserID:         coll.UserID,
+		Word:           coll.Word,
+		CollectionName: coll.Name,
+		Grade:          entity.GradeGood,
+		Scheduler:      entity.SchedulerSM2,
+		NextTimeDiff:   coll.TimeDiff,
+		ReviewedAt:     coll.LastRepeat,
+	}
+	if err := wordRepo.SaveReview(ctx, coll, reviewLog); err != nil {
+		t.Fatalf("wordRepo.SaveReview: %v", err)
+	}
+}
+
+func Test_Words(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	due, notDue := true, false
+	tests := []struct {
+		name      string
+		query     entity.WordsQuery
+		wantWords []string
+	}{
+		{
+			name:      "Words_by_word",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortWord, Limit: 10},
+			wantWords: []string{"apple", "bank", "cat", "snap_1"},
+		},
+		{
+			name:      "Words_by_word_desc_page",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortWord, Desc: true, Limit: 2},
+			wantWords: []string{"snap_1", "cat"},
+		},
+		{
+			name: "Words_after_cursor",
+			query: entity.WordsQuery{
+				UserID: "12345",
+				Sort:   entity.WordsSortWord,
+				Limit:  10,
+				After:  &entity.WordsCursor{Sort: entity.WordsSortWord, Word: "bank", Collection: "test_coll"},
+			},
+			wantWords: []string{"cat", "snap_1"},
+		},
+		{
+			name:      "Words_by_last_repeat",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortLastRepeat, Limit: 10},
+			wantWords: []string{"cat", "bank", "apple", "snap_1"},
+		},
+		{
+			name:      "Words_by_part_of_speech",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortWord, PartOfSpeech: "verb", Limit: 10},
+			wantWords: []string{"bank"},
+		},
+		{
+			name:      "Words_by_substring",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortWord, Search: "AP_", Limit: 10},
+			wantWords: []string{"snap_1"},
+		},
+		{
+			name:      "Due_words",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortWord, Due: &due, Now: now, Limit: 10},
+			wantWords: []string{"cat"},
+		},
+		{
+			name:      "Not_due_words",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortWord, Due: &notDue, Now: now, Limit: 10},
+			wantWords: []string{"apple", "bank", "snap_1"},
+		},
+		{
+			name:      "Words_of_collection",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortWord, Collection: "other_coll", Limit: 10},
+			wantWords: []string{"apple"},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		trans := []entity.WordTrans{
+			{Word: "apple", Translations: map[entity.PartOfSpeech][]string{"noun": {"яблоко"}}},
+			{Word: "bank", Translations: map[entity.PartOfSpeech][]string{"noun": {"банк"}, "verb": {"полагаться"}}},
+			{Word: "snap_1"},
+		}
+		for _, tr := range trans {
+			if err := wordRepo.AddTranslation(ctx, tr); err != nil {
+				t.Fatalf("wordRepo.AddTranslation: %v", err)
+			}
+		}
+		setupAddWordToUser(ctx, t, entity.Collection{
+			UserID: "12345", Word: "apple", Name: "other_coll", LastRepeat: now.Add(-time.Hour),
+		}, wordRepo)
+		setupAddWordToUser(ctx, t, entity.Collection{
+			UserID: "12345", Word: "bank", Name: "test_coll", LastRepeat: now.Add(-2 * time.Hour),
+		}, wordRepo)
+		setupAddWordToUser(ctx, t, entity.Collection{
+			UserID: "12345", Word: "snap_1", Name: "test_coll", LastRepeat: now,
+		}, wordRepo)
+		setupReviewedWord(ctx, t, entity.Collection{
+			UserID: "12345", Word: "cat", Name: "test_coll", LastRepeat: now.Add(-48 * time.Hour), TimeDiff: 24 * time.Hour,
+		}, wordRepo)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wordRepo.Words(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("want nil but got: %v", err)
+			}
+			words := make([]string, 0, len(got))
+			for _, w := range got {
+				words = append(words, w.Word)
+			}
+			if diff := cmp.Diff(tt.wantWords, words); diff != "" {
+				t.Fatalf("words must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
+func Test_SaveUserTrans(t *testing.T) {
+	coll := entity.Collection{UserID: "12345", Word: "test_word", Name: "test_coll", SrcLang: "en", TrgtLang: "ru"}
+	tests := []struct {
+		name      string
+		shared    bool
+		edits     []entity.UserTrans
+		wantTrans entity.WordTrans
+	}{
+		{
+			name:   "Edit_shared_translation",
+			shared: true,
+			edits: []entity.UserTrans{
+				{MainTranslation: "дар"},
+				{Examples: []string{"a test word"}},
+			},
+			wantTrans: entity.WordTrans{
+				Word:            "test_word",
+				SrcLang:         "en",
+				TrgtLang:        "ru",
+				MainTranslation: "дар",
+				Examples:        []string{"a test word"},
+			},
+		},
+		{
+			name: "Custom_card",
+			edits: []entity.UserTrans{
+				{MainTranslation: "тестовое слово", Provider: "custom"},
+			},
+			wantTrans: entity.WordTrans{
+				Word:            "test_word",
+				SrcLang:         "en",
+				TrgtLang:        "ru",
+				MainTranslation: "тестовое слово",
+				Provider:        "custom",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		wordRepo := setupWordRepoContainer(ctx, t, tt.name)
+		if tt.shared {
+			setupAddTranslationToDB(ctx, t, coll, wordRepo)
+		}
+		setupAddWordToUser(ctx, t, coll, wordRepo)
+
+		t.Run(tt.name, func(t *testing.T) {
+			for _, edit := range tt.edits {
+				edit.UserID, edit.Word, edit.SrcLang, edit.TrgtLang = coll.UserID, coll.Word, coll.SrcLang, coll.TrgtLang
+				if err := wordRepo.SaveUserTrans(ctx, edit); err != nil {
+					t.Fatalf("want nil but got: %v", err)
+				}
+			}
+
+			userWords, err := wordRepo.UserWords(ctx, coll)
+			if err != nil {
+				t.Fatalf("wordRepo.UserWords: %v", err)
+			}
+			words := userWords.Words[entity.CollectionName(coll.Name)]
+			if len(words) != 1 {
+				t.Fatalf("want one word but got: %v", words)
+			}
+			if diff := cmp.Diff(tt.wantTrans, words[0].WordTrans); diff != "" {
+				t.Fatalf("translation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
 }
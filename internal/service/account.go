@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type AccountRepo interface {
+	// Export returns entity.ErrUserNotFound if user doesn't exist.
+	Export(ctx context.Context, userID string) (entity.Export, error)
+	// RequestDeletion records deletion request of the user, pending request is returned if there is one.
+	RequestDeletion(ctx context.Context, userID string) (entity.AccountDeletion, error)
+	// PendingDeletions returns up to limit deletion requests which aren't completed, oldest first.
+	PendingDeletions(ctx context.Context, limit int) ([]entity.AccountDeletion, error)
+	// DeleteUserData deletes rows of the user from all tables and completes the request in one transaction.
+	DeleteUserData(ctx context.Context, deletion entity.AccountDeletion) (entity.AccountDeletion, error)
+}
+
+// Account exports user data and deletes it on request.
+type Account struct {
+	accountRepo AccountRepo
+	// Deleted users are forgotten, so they are created again on their next request.
+	// Users deleted by another instance are created again when their row is missing.
+	settings  *Settings
+	batchSize int
+}
+
+func (s *Account) Export(ctx context.Context, userID string) (entity.Export, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AccountService - Export")
+	defer span.End()
+
+	export, err := s.accountRepo.Export(ctx, userID)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		if err := s.settings.recreateUser(ctx, userID); err != nil {
+			return entity.Export{}, fmt.Errorf("Account - Export - s.settings.recreateUser: %w", err)
+		}
+		export, err = s.accountRepo.Export(ctx, userID)
+	}
+	if err != nil {
+		return entity.Export{}, fmt.Errorf("Account - Export - s.accountRepo.Export: %w", err)
+	}
+	return export, nil
+}
+
+// RequestDeletion records deletion request, data is deleted later by DeletePending.
+func (s *Account) RequestDeletion(ctx context.Context, userID string) (entity.AccountDeletion, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AccountService - RequestDeletion")
+	defer span.End()
+
+	deletion, err := s.accountRepo.RequestDeletion(ctx, userID)
+	if err != nil {
+		return entity.AccountDeletion{}, fmt.Errorf("Account - RequestDeletion - s.accountRepo.RequestDeletion: %w", err)
+	}
+	return deletion, nil
+}
+
+// DeletePending deletes data of one batch of pending requests and returns the number of completed ones.
+func (s *Account) DeletePending(ctx context.Context) (int, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "AccountService - DeletePending")
+	defer span.End()
+
+	deletions, err := s.accountRepo.PendingDeletions(ctx, s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("Account - DeletePending - s.accountRepo.PendingDeletions: %w", err)
+	}
+
+	completed := 0
+	for _, deletion := range deletions {
+		if err := ctx.Err(); err != nil {
+			return completed, err
+		}
+
+		if _, err := s.accountRepo.DeleteUserData(ctx, deletion); err != nil {
+			return completed, fmt.Errorf("Account - DeletePending - s.accountRepo.DeleteUserData: %w", err)
+		}
+		s.settings.ForgetUser(deletion.UserID)
+		completed++
+	}
+	return completed, nil
+}
+
+func NewAccountService(accountRepo AccountRepo, settings *Settings, batchSize int) *Account {
+	return &Account{
+		accountRepo: accountRepo,
+		settings:    settings,
+		batchSize:   batchSize,
+	}
+}
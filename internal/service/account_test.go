@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_DeletePending(t *testing.T) {
+	errDB := errors.New("db is down")
+	first := entity.AccountDeletion{ID: 1, UserID: "12345"}
+	second := entity.AccountDeletion{ID: 2, UserID: "67890"}
+	completedAt := time.Date(2023, 5, 10, 15, 4, 5, 0, time.UTC)
+	completed := func(deletion entity.AccountDeletion) entity.AccountDeletion {
+		deletion.CompletedAt = &completedAt
+		return deletion
+	}
+	tests := []struct {
+		name      string
+		setupMock func(accountMock *repomock.AccountRepo)
+		want      int
+		wantErr   error
+	}{
+		{
+			name: "Delete pending",
+			setupMock: func(accountMock *repomock.AccountRepo) {
+				accountMock.On("PendingDeletions", mock.Anything, 10).Once().Return([]entity.AccountDeletion{first, second}, nil)
+				accountMock.On("DeleteUserData", mock.Anything, first).Once().Return(completed(first), nil)
+				accountMock.On("DeleteUserData", mock.Anything, second).Once().Return(completed(second), nil)
+			},
+			want: 2,
+		},
+		{
+			name: "Failed deletion stops the batch",
+			setupMock: func(accountMock *repomock.AccountRepo) {
+				accountMock.On("PendingDeletions", mock.Anything, 10).Once().Return([]entity.AccountDeletion{first, second}, nil)
+				accountMock.On("DeleteUserData", mock.Anything, first).Once().Return(entity.AccountDeletion{}, errDB)
+			},
+			wantErr: errDB,
+		},
+		{
+			name: "Failed pending deletions query",
+			setupMock: func(accountMock *repomock.AccountRepo) {
+				accountMock.On("PendingDeletions", mock.Anything, 10).Once().Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		accountMock := repomock.NewAccountRepo(t)
+		accountService := NewAccountService(accountMock, NewSettingsService(repomock.NewSettingsRepo(t)), 10)
+		tt.setupMock(accountMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := accountService.DeletePending(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Fatalf("want: %v deleted but got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_DeletePendingForgetsUser(t *testing.T) {
+	ctx := context.Background()
+	accountMock, stMock := repomock.NewAccountRepo(t), repomock.NewSettingsRepo(t)
+	settingsService := NewSettingsService(stMock)
+	accountService := NewAccountService(accountMock, settingsService, 10)
+
+	deletion := entity.AccountDeletion{ID: 1, UserID: "12345"}
+	accountMock.On("PendingDeletions", mock.Anything, 10).Once().Return([]entity.AccountDeletion{deletion}, nil)
+	accountMock.On("DeleteUserData", mock.Anything, deletion).Once().Return(deletion, nil)
+	// User is created before and after the deletion.
+	stMock.On("CreateUser", mock.Anything, "12345").Twice().Return(nil)
+
+	if err := settingsService.RegisterUser(ctx, "12345"); err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	if _, err := accountService.DeletePending(ctx); err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	if err := settingsService.RegisterUser(ctx, "12345"); err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+}
+
+func Test_ExportUserDeletedByOtherInstance(t *testing.T) {
+	ctx := context.Background()
+	accountMock, stMock := repomock.NewAccountRepo(t), repomock.NewSettingsRepo(t)
+	accountService := NewAccountService(accountMock, NewSettingsService(stMock), 10)
+
+	accountMock.On("Export", mock.Anything, "12345").Once().Return(entity.Export{}, entity.ErrUserNotFound)
+	stMock.On("CreateUser", mock.Anything, "12345").Once().Return(nil)
+	accountMock.On("Export", mock.Anything, "12345").Once().Return(entity.Export{User: entity.User{ID: "12345"}}, nil)
+
+	got, err := accountService.Export(ctx, "12345")
+	if err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	if got.User.ID != "12345" {
+		t.Fatalf("want export of user 12345 but got: %+v", got.User)
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+// Provider of translations edited by admins, translations of unknown providers are never refreshed.
+const adminProvider = "admin"
+
+type AdminRepo interface {
+	// Users returns up to query.Limit users with IDs greater than query.After, ordered by ID.
+	Users(ctx context.Context, query entity.UsersQuery) ([]entity.UserSummary, error)
+	CountUsers(ctx context.Context) (int, error)
+	// Translations returns shared translations of the word, empty language matches any language.
+	Translations(ctx context.Context, word, srcLang, trgtLang string) ([]entity.StoredTranslation, error)
+	// UpdateTranslation replaces data of the stored translation, entity.ErrTranslationNotFound is returned if it doesn't exist.
+	UpdateTranslation(ctx context.Context, wordTrans entity.WordTrans) (entity.StoredTranslation, error)
+	// DeleteTranslation returns entity.ErrTranslationNotFound if translation doesn't exist
+	// and entity.ErrTranslationInUse if user cards have it.
+	DeleteTranslation(ctx context.Context, word, srcLang, trgtLang string) error
+}
+
+// Admin lets operators inspect users and fix shared translations.
+type Admin struct {
+	adminRepo AdminRepo
+	providers TransProviders
+}
+
+func (s *Admin) Users(ctx context.Context, query entity.UsersQuery) (*entity.UsersPage, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminService - Users")
+	defer span.End()
+
+	if query.Cursor != "" {
+		after, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+		if err != nil || len(after) == 0 {
+			return nil, entity.ErrInvalidCursor
+		}
+		query.After = string(after)
+	}
+
+	// One extra user tells if there is a next page.
+	limit := query.Limit
+	query.Limit++
+	users, err := s.adminRepo.Users(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Admin - Users - s.adminRepo.Users: %w", err)
+	}
+	total, err := s.adminRepo.CountUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Admin - Users - s.adminRepo.CountUsers: %w", err)
+	}
+
+	page := &entity.UsersPage{Users: users, Total: total}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(page.Users[limit-1].ID))
+	}
+	return page, nil
+}
+
+func (s *Admin) Translations(ctx context.Context, word, srcLang, trgtLang string) ([]entity.StoredTranslation, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminService - Translations")
+	defer span.End()
+
+	translations, err := s.adminRepo.Translations(ctx, word, srcLang, trgtLang)
+	if err != nil {
+		return nil, fmt.Errorf("Admin - Translations - s.adminRepo.Translations: %w", err)
+	}
+	return translations, nil
+}
+
+// EditTranslation replaces stored translation, edited translation isn't refreshed until it's translated again by RefreshWord.
+func (s *Admin) EditTranslation(ctx context.Context, wordTrans entity.WordTrans) (entity.StoredTranslation, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminService - EditTranslation")
+	defer span.End()
+
+	wordTrans.Provider, wordTrans.ProviderVersion = adminProvider, 0
+	translation, err := s.adminRepo.UpdateTranslation(ctx, wordTrans)
+	if err != nil {
+		return entity.StoredTranslation{}, fmt.Errorf("Admin - EditTranslation - s.adminRepo.UpdateTranslation: %w", err)
+	}
+	return translation, nil
+}
+
+func (s *Admin) DeleteTranslation(ctx context.Context, word, srcLang, trgtLang string) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminService - DeleteTranslation")
+	defer span.End()
+
+	if err := s.adminRepo.DeleteTranslation(ctx, word, srcLang, trgtLang); err != nil {
+		return fmt.Errorf("Admin - DeleteTranslation - s.adminRepo.DeleteTranslation: %w", err)
+	}
+	return nil
+}
+
+// ProviderStats returns request counters of translation providers since the start of the app.
+func (s *Admin) ProviderStats(ctx context.Context) []entity.ProviderStats {
+	_, span := otel.Tracer(otelName).Start(ctx, "AdminService - ProviderStats")
+	defer span.End()
+
+	return s.providers.Stats()
+}
+
+func NewAdminService(adminRepo AdminRepo, providers TransProviders) *Admin {
+	return &Admin{
+		adminRepo: adminRepo,
+		providers: providers,
+	}
+}
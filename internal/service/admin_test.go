@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_AdminUsers(t *testing.T) {
+	errDB := errors.New("db is down")
+	users := []entity.UserSummary{
+		{User: entity.User{ID: "1"}},
+		{User: entity.User{ID: "2"}},
+		{User: entity.User{ID: "3"}},
+	}
+	cursor := base64.RawURLEncoding.EncodeToString([]byte("2"))
+	tests := []struct {
+		name      string
+		query     entity.UsersQuery
+		setupMock func(adminMock *repomock.AdminRepo)
+		want      *entity.UsersPage
+		wantErr   error
+	}{
+		{
+			name:  "First page",
+			query: entity.UsersQuery{Limit: 2},
+			setupMock: func(adminMock *repomock.AdminRepo) {
+				adminMock.On("Users", mock.Anything, entity.UsersQuery{Limit: 3}).Once().Return(users, nil)
+				adminMock.On("CountUsers", mock.Anything).Once().Return(3, nil)
+			},
+			want: &entity.UsersPage{Users: users[:2], Total: 3, NextCursor: cursor},
+		},
+		{
+			name:  "Last page",
+			query: entity.UsersQuery{Limit: 2, Cursor: cursor},
+			setupMock: func(adminMock *repomock.AdminRepo) {
+				adminMock.On("Users", mock.Anything, entity.UsersQuery{Limit: 3, Cursor: cursor, After: "2"}).Once().Return(users[2:], nil)
+				adminMock.On("CountUsers", mock.Anything).Once().Return(3, nil)
+			},
+			want: &entity.UsersPage{Users: users[2:], Total: 3},
+		},
+		{
+			name:      "Invalid cursor",
+			query:     entity.UsersQuery{Limit: 2, Cursor: "!"},
+			setupMock: func(adminMock *repomock.AdminRepo) {},
+			wantErr:   entity.ErrInvalidCursor,
+		},
+		{
+			name:  "Failed users query",
+			query: entity.UsersQuery{Limit: 2},
+			setupMock: func(adminMock *repomock.AdminRepo) {
+				adminMock.On("Users", mock.Anything, entity.UsersQuery{Limit: 3}).Once().Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		adminMock := repomock.NewAdminRepo(t)
+		adminService := NewAdminService(adminMock, nil)
+		tt.setupMock(adminMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := adminService.Users(ctx, tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("page mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_AdminEditTranslation(t *testing.T) {
+	ctx := context.Background()
+	adminMock := repomock.NewAdminRepo(t)
+	adminService := NewAdminService(adminMock, nil)
+
+	// Edited translation is marked as admin's one so the refresher doesn't overwrite it.
+	edited := entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "de", MainTranslation: "Gabe", Provider: adminProvider}
+	adminMock.On("UpdateTranslation", mock.Anything, edited).Once().Return(entity.StoredTranslation{WordTrans: edited}, nil)
+
+	got, err := adminService.EditTranslation(ctx, entity.WordTrans{
+		Word: "gift", SrcLang: "en", TrgtLang: "de", MainTranslation: "Gabe", Provider: "google", ProviderVersion: 2,
+	})
+	if err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	if diff := cmp.Diff(edited, got.WordTrans); diff != "" {
+		t.Fatalf("translation mismatch (-want +got):\n%s", diff)
+	}
+}
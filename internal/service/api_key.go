@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+// Random bytes of a key, the key is base64 encoded.
+const apiKeyBytes = 32
+
+// Length of key prefix which is stored in plain text, prefix of entity.APIKeyPrefix included.
+const apiKeyShownLen = 8
+
+type (
+	APIKeyRepo interface {
+		// CreateAPIKey returns key with ID and CreatedAt set.
+		CreateAPIKey(ctx context.Context, key entity.APIKey) (entity.APIKey, error)
+		APIKeys(ctx context.Context, userID string) ([]entity.APIKey, error)
+		DeleteAPIKey(ctx context.Context, userID string, id int64) error
+		// UseAPIKey finds not expired key by hash and sets its last use time.
+		UseAPIKey(ctx context.Context, hash []byte) (entity.APIKey, error)
+	}
+)
+
+type APIKey struct {
+	apiKeyRepo APIKeyRepo
+}
+
+// CreateAPIKey generates a key of the user, plain key is returned only once.
+func (s *APIKey) CreateAPIKey(ctx context.Context, key entity.APIKey) (entity.NewAPIKey, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "APIKeyService - CreateAPIKey")
+	defer span.End()
+
+	secret := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return entity.NewAPIKey{}, fmt.Errorf("APIKey - CreateAPIKey - rand.Read: %w", err)
+	}
+	plain := entity.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	key.Prefix = plain[:apiKeyShownLen]
+	key.Hash = hashAPIKey(plain)
+
+	created, err := s.apiKeyRepo.CreateAPIKey(ctx, key)
+	if err != nil {
+		return entity.NewAPIKey{}, fmt.Errorf("APIKey - CreateAPIKey - s.apiKeyRepo.CreateAPIKey: %w", err)
+	}
+	return entity.NewAPIKey{APIKey: created, Key: plain}, nil
+}
+
+func (s *APIKey) APIKeys(ctx context.Context, userID string) (*entity.APIKeys, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "APIKeyService - APIKeys")
+	defer span.End()
+
+	keys, err := s.apiKeyRepo.APIKeys(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("APIKey - APIKeys - s.apiKeyRepo.APIKeys: %w", err)
+	}
+	return &entity.APIKeys{Keys: keys}, nil
+}
+
+// RevokeAPIKey deletes the key, requests with it are rejected right away.
+func (s *APIKey) RevokeAPIKey(ctx context.Context, userID string, id int64) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "APIKeyService - RevokeAPIKey")
+	defer span.End()
+
+	if err := s.apiKeyRepo.DeleteAPIKey(ctx, userID, id); err != nil {
+		return fmt.Errorf("APIKey - RevokeAPIKey - s.apiKeyRepo.DeleteAPIKey: %w", err)
+	}
+	return nil
+}
+
+// AuthenticateAPIKey returns the key with plain value, entity.ErrAPIKeyNotFound is returned
+// for unknown, revoked and expired keys.
+func (s *APIKey) AuthenticateAPIKey(ctx context.Context, plain string) (entity.APIKey, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "APIKeyService - AuthenticateAPIKey")
+	defer span.End()
+
+	key, err := s.apiKeyRepo.UseAPIKey(ctx, hashAPIKey(plain))
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("APIKey - AuthenticateAPIKey - s.apiKeyRepo.UseAPIKey: %w", err)
+	}
+	return key, nil
+}
+
+// Keys are random, so a fast hash is enough.
+func hashAPIKey(plain string) []byte {
+	sum := sha256.Sum256([]byte(plain))
+	return sum[:]
+}
+
+func NewAPIKeyService(apiKeyRepo APIKeyRepo) *APIKey {
+	return &APIKey{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_CreateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	keyRepo := repomock.NewAPIKeyRepo(t)
+	apiKeyService := NewAPIKeyService(keyRepo)
+	key := entity.APIKey{UserID: "12345", Name: "import", Scopes: []entity.APIKeyScope{entity.ScopeWordsWrite}}
+
+	var stored entity.APIKey
+	keyRepo.On("CreateAPIKey", mock.Anything, mock.Anything).Twice().Return(
+		func(_ context.Context, k entity.APIKey) entity.APIKey {
+			stored = k
+			k.ID, k.CreatedAt = 1, time.Now()
+			return k
+		},
+		nil,
+	)
+
+	got, err := apiKeyService.CreateAPIKey(ctx, key)
+	if err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	if !strings.HasPrefix(got.Key, entity.APIKeyPrefix) || !strings.HasPrefix(got.Key, got.Prefix) {
+		t.Fatalf("key %q must start with %q and prefix %q", got.Key, entity.APIKeyPrefix, got.Prefix)
+	}
+	if diff := cmp.Diff(hashAPIKey(got.Key), stored.Hash); diff != "" {
+		t.Fatalf("hash of the key must be stored diff: %v", diff)
+	}
+	if got.ID != 1 || stored.Name != key.Name {
+		t.Fatalf("wrong stored key: %+v", stored)
+	}
+	if diff := cmp.Diff(key.Scopes, stored.Scopes); diff != "" {
+		t.Fatalf("scopes must be stored diff: %v", diff)
+	}
+
+	other, err := apiKeyService.CreateAPIKey(ctx, key)
+	if err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	if other.Key == got.Key {
+		t.Fatalf("keys must be different but got: %q twice", got.Key)
+	}
+}
+
+func Test_AuthenticateAPIKey(t *testing.T) {
+	const plain = entity.APIKeyPrefix + "secret"
+	tests := []struct {
+		name      string
+		setupMock func(keyRepo *repomock.APIKeyRepo)
+		want      entity.APIKey
+		wantErr   error
+	}{
+		{
+			name: "Valid key",
+			setupMock: func(keyRepo *repomock.APIKeyRepo) {
+				keyRepo.On("UseAPIKey", mock.Anything, hashAPIKey(plain)).Once().
+					Return(entity.APIKey{ID: 1, UserID: "12345"}, nil)
+			},
+			want: entity.APIKey{ID: 1, UserID: "12345"},
+		},
+		{
+			name: "Unknown key",
+			setupMock: func(keyRepo *repomock.APIKeyRepo) {
+				keyRepo.On("UseAPIKey", mock.Anything, hashAPIKey(plain)).Once().
+					Return(entity.APIKey{}, entity.ErrAPIKeyNotFound)
+			},
+			wantErr: entity.ErrAPIKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		keyRepo := repomock.NewAPIKeyRepo(t)
+		apiKeyService := NewAPIKeyService(keyRepo)
+		tt.setupMock(keyRepo)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := apiKeyService.AuthenticateAPIKey(ctx, plain)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("keys must be equal diff: %v", diff)
+			}
+		})
+	}
+}
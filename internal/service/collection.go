@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type (
+	CollectionRepo interface {
+		// CreateCollection returns collection with ID and CreatedAt set.
+		CreateCollection(ctx context.Context, collection entity.CollectionInfo) (entity.CollectionInfo, error)
+		Collections(ctx context.Context, userID string) ([]entity.CollectionInfo, error)
+		UpdateCollection(ctx context.Context, collection entity.CollectionInfo) error
+		DeleteCollection(ctx context.Context, collection entity.CollectionInfo, force bool) error
+	}
+)
+
+type Collection struct {
+	collectionRepo CollectionRepo
+}
+
+func (s *Collection) CreateCollection(ctx context.Context, collection entity.CollectionInfo) (entity.CollectionInfo, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "CollectionService - CreateCollection")
+	defer span.End()
+
+	created, err := s.collectionRepo.CreateCollection(ctx, collection)
+	if err != nil {
+		return entity.CollectionInfo{}, fmt.Errorf("Collection - CreateCollection - s.collectionRepo.CreateCollection: %w", err)
+	}
+	return created, nil
+}
+
+func (s *Collection) Collections(ctx context.Context, userID string) (*entity.CollectionsInfo, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "CollectionService - Collections")
+	defer span.End()
+
+	collections, err := s.collectionRepo.Collections(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Collection - Collections - s.collectionRepo.Collections: %w", err)
+	}
+	return &entity.CollectionsInfo{Collections: collections}, nil
+}
+
+// UpdateCollection renames collection and sets its description.
+func (s *Collection) UpdateCollection(ctx context.Context, collection entity.CollectionInfo) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "CollectionService - UpdateCollection")
+	defer span.End()
+
+	err := s.collectionRepo.UpdateCollection(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("Collection - UpdateCollection - s.collectionRepo.UpdateCollection: %w", err)
+	}
+	return nil
+}
+
+// DeleteCollection deletes an empty collection, words are deleted too if force is set.
+func (s *Collection) DeleteCollection(ctx context.Context, collection entity.CollectionInfo, force bool) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "CollectionService - DeleteCollection")
+	defer span.End()
+
+	err := s.collectionRepo.DeleteCollection(ctx, collection, force)
+	if err != nil {
+		return fmt.Errorf("Collection - DeleteCollection - s.collectionRepo.DeleteCollection: %w", err)
+	}
+	return nil
+}
+
+func NewCollectionService(collectionRepo CollectionRepo) *Collection {
+	return &Collection{
+		collectionRepo: collectionRepo,
+	}
+}
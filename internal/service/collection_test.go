@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_Collections(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		setupMock func(crMock *repomock.CollectionRepo, userID string)
+		want      *entity.CollectionsInfo
+		wantErr   bool
+	}{
+		{
+			name:   "User collections",
+			userID: "12345",
+			setupMock: func(crMock *repomock.CollectionRepo, userID string) {
+				crMock.On("Collections", mock.Anything, userID).Once().
+					Return([]entity.CollectionInfo{{ID: 1, Name: "coll"}}, nil)
+			},
+			want: &entity.CollectionsInfo{Collections: []entity.CollectionInfo{{ID: 1, Name: "coll"}}},
+		},
+		{
+			name:   "Repo error",
+			userID: "12345",
+			setupMock: func(crMock *repomock.CollectionRepo, userID string) {
+				crMock.On("Collections", mock.Anything, userID).Once().Return(nil, errors.New("some repo error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		crMock := repomock.NewCollectionRepo(t)
+		collectionService := NewCollectionService(crMock)
+		tt.setupMock(crMock, tt.userID)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := collectionService.Collections(ctx, tt.userID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("collections must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
+func Test_DeleteCollection(t *testing.T) {
+	type args struct {
+		coll  entity.CollectionInfo
+		force bool
+	}
+	tests := []struct {
+		name      string
+		args      args
+		setupMock func(crMock *repomock.CollectionRepo, args args)
+		wantErr   error
+	}{
+		{
+			name: "Delete collection",
+			args: args{coll: entity.CollectionInfo{ID: 1, UserID: "12345"}},
+			setupMock: func(crMock *repomock.CollectionRepo, args args) {
+				crMock.On("DeleteCollection", mock.Anything, args.coll, false).Once().Return(nil)
+			},
+		},
+		{
+			name: "Delete not empty collection",
+			args: args{coll: entity.CollectionInfo{ID: 1, UserID: "12345"}},
+			setupMock: func(crMock *repomock.CollectionRepo, args args) {
+				crMock.On("DeleteCollection", mock.Anything, args.coll, false).Once().
+					Return(entity.ErrCollectionNotEmpty)
+			},
+			wantErr: entity.ErrCollectionNotEmpty,
+		},
+		{
+			name: "Force delete not empty collection",
+			args: args{coll: entity.CollectionInfo{ID: 1, UserID: "12345"}, force: true},
+			setupMock: func(crMock *repomock.CollectionRepo, args args) {
+				crMock.On("DeleteCollection", mock.Anything, args.coll, true).Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		crMock := repomock.NewCollectionRepo(t)
+		collectionService := NewCollectionService(crMock)
+		tt.setupMock(crMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := collectionService.DeleteCollection(ctx, tt.args.coll, tt.args.force)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+)
+
+// FSRS implements Free Spaced Repetition Scheduler v4 with default weights,
+// see https://github.com/open-spaced-repetition/fsrs4anki/wiki/The-Algorithm.
+type FSRS struct {
+	w [17]float64
+	// Probability of recall at the moment of the next review.
+	requestRetention float64
+	// In days.
+	maximumInterval float64
+}
+
+const (
+	fsrsDefaultRetention   = 0.9
+	fsrsDefaultMaxInterval = 36500
+	fsrsMinDifficulty      = 1
+	fsrsMaxDifficulty      = 10
+)
+
+var fsrsDefaultWeights = [17]float64{
+	0.4, 0.6, 2.4, 5.8, 4.93, 0.94, 0.86, 0.01, 1.49, 0.14, 0.94, 2.18, 0.05, 0.34, 1.26, 0.29, 2.61,
+}
+
+var fsrsRating = map[entity.Grade]float64{
+	entity.GradeAgain: 1,
+	entity.GradeHard:  2,
+	entity.GradeGood:  3,
+	entity.GradeEasy:  4,
+}
+
+func (f *FSRS) Schedule(card entity.Collection, grade entity.Grade, reviewedAt time.Time) entity.Collection {
+	g := fsrsRating[grade]
+	if card.Stability <= 0 {
+		card = f.seed(card)
+	}
+
+	if card.Stability <= 0 {
+		card.Stability = f.w[int(g)-1]
+		card.Difficulty = f.initDifficulty(g)
+	} else {
+		elapsed := math.Max(reviewedAt.Sub(card.LastRepeat).Hours()/24, 0)
+		r := f.retrievability(elapsed, card.Stability)
+		if grade == entity.GradeAgain {
+			card.Stability = f.forgetStability(card.Difficulty, card.Stability, r)
+		} else {
+			card.Stability = f.recallStability(card.Difficulty, card.Stability, r, g)
+		}
+		card.Difficulty = f.nextDifficulty(card.Difficulty, g)
+	}
+
+	if grade == entity.GradeAgain {
+		card.Repetitions = 0
+	} else {
+		card.Repetitions++
+	}
+	card.TimeDiff = f.interval(card.Stability)
+	card.LastRepeat = reviewedAt
+
+	return card
+}
+
+// Seeds memory state of cards which were scheduled by other algorithms,
+// current interval is used as stability and ease factor as difficulty.
+// Migration 000003 uses the same formulas.
+func (f *FSRS) seed(card entity.Collection) entity.Collection {
+	if card.TimeDiff <= 0 {
+		return card
+	}
+
+	card.Stability = card.TimeDiff.Hours() / 24
+	card.Difficulty = f.w[4]
+	if card.EaseFactor >= sm2MinEase {
+		card.Difficulty = f.clampDifficulty(f.w[4] - (card.EaseFactor-sm2DefaultEase)*4.225)
+	}
+	return card
+}
+
+func (f *FSRS) retrievability(elapsedDays, stability float64) float64 {
+	return math.Pow(1+elapsedDays/(9*stability), -1)
+}
+
+func (f *FSRS) interval(stability float64) time.Duration {
+	days := math.Round(9 * stability * (1/f.requestRetention - 1))
+	days = math.Min(math.Max(days, 1), f.maximumInterval)
+	return time.Duration(days) * day
+}
+
+func (f *FSRS) initDifficulty(g float64) float64 {
+	return f.clampDifficulty(f.w[4] - (g-3)*f.w[5])
+}
+
+func (f *FSRS) nextDifficulty(d, g float64) float64 {
+	next := d - f.w[6]*(g-3)
+	// Mean reversion to the difficulty of a new card answered with good.
+	return f.clampDifficulty(f.w[7]*f.initDifficulty(3) + (1-f.w[7])*next)
+}
+
+func (f *FSRS) recallStability(d, s, r, g float64) float64 {
+	hardPenalty, easyBonus := 1.0, 1.0
+	if g == fsrsRating[entity.GradeHard] {
+		hardPenalty = f.w[15]
+	}
+	if g == fsrsRating[entity.GradeEasy] {
+		easyBonus = f.w[16]
+	}
+
+	return s * (1 + math.Exp(f.w[8])*
+		(11-d)*
+		math.Pow(s, -f.w[9])*
+		(math.Exp((1-r)*f.w[10])-1)*
+		hardPenalty*
+		easyBonus)
+}
+
+func (f *FSRS) forgetStability(d, s, r float64) float64 {
+	return f.w[11] *
+		math.Pow(d, -f.w[12]) *
+		(math.Pow(s+1, f.w[13]) - 1) *
+		math.Exp((1-r)*f.w[14])
+}
+
+func (f *FSRS) clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, fsrsMinDifficulty), fsrsMaxDifficulty)
+}
+
+// NewFSRS creates FSRS scheduler, requestRetention must be in (0, 1)
+// and maximumInterval is in days, otherwise defaults are used.
+func NewFSRS(requestRetention float64, maximumInterval int) *FSRS {
+	f := &FSRS{
+		w:                fsrsDefaultWeights,
+		requestRetention: requestRetention,
+		maximumInterval:  float64(maximumInterval),
+	}
+	if requestRetention <= 0 || requestRetention >= 1 {
+		f.requestRetention = fsrsDefaultRetention
+	}
+	if maximumInterval <= 0 {
+		f.maximumInterval = fsrsDefaultMaxInterval
+	}
+	return f
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_FSRSSchedule(t *testing.T) {
+	reviewedAt := time.Date(2023, 5, 11, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		card  entity.Collection
+		grade entity.Grade
+	}
+	tests := []struct {
+		name string
+		args args
+		want entity.Collection
+	}{
+		{
+			name: "New card good",
+			args: args{
+				card:  entity.Collection{},
+				grade: entity.GradeGood,
+			},
+			want: entity.Collection{
+				LastRepeat:  reviewedAt,
+				TimeDiff:    2 * day,
+				Repetitions: 1,
+				Stability:   2.4,
+				Difficulty:  4.93,
+			},
+		},
+		{
+			name: "New card again",
+			args: args{
+				card:  entity.Collection{},
+				grade: entity.GradeAgain,
+			},
+			want: entity.Collection{
+				LastRepeat: reviewedAt,
+				TimeDiff:   day,
+				Stability:  0.4,
+				Difficulty: 6.81,
+			},
+		},
+		{
+			name: "Recall",
+			args: args{
+				card: entity.Collection{
+					LastRepeat:  reviewedAt.Add(-10 * day),
+					TimeDiff:    10 * day,
+					Repetitions: 3,
+					Stability:   10,
+					Difficulty:  5,
+				},
+				grade: entity.GradeGood,
+			},
+			want: entity.Collection{
+				LastRepeat:  reviewedAt,
+				TimeDiff:    29 * day,
+				Repetitions: 4,
+				Stability:   29.008576880107007,
+				Difficulty:  4.9993,
+			},
+		},
+		{
+			name: "Lapse",
+			args: args{
+				card: entity.Collection{
+					LastRepeat:  reviewedAt.Add(-10 * day),
+					TimeDiff:    10 * day,
+					Repetitions: 3,
+					Stability:   10,
+					Difficulty:  5,
+				},
+				grade: entity.GradeAgain,
+			},
+			want: entity.Collection{
+				LastRepeat: reviewedAt,
+				TimeDiff:   3 * day,
+				Stability:  2.874332436209694,
+				Difficulty: 6.7021,
+			},
+		},
+		{
+			name: "Seeded from SM-2 interval",
+			args: args{
+				card: entity.Collection{
+					LastRepeat:  reviewedAt.Add(-10 * day),
+					TimeDiff:    10 * day,
+					EaseFactor:  2.5,
+					Repetitions: 3,
+				},
+				grade: entity.GradeGood,
+			},
+			want: entity.Collection{
+				LastRepeat:  reviewedAt,
+				TimeDiff:    29 * day,
+				EaseFactor:  2.5,
+				Repetitions: 4,
+				Stability:   29.230343610374923,
+				Difficulty:  4.93,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s := NewFSRS(0.9, 36500)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Schedule(tt.args.card, tt.args.grade, reviewedAt)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0.01, 0)); diff != "" {
+				t.Fatalf("wanted: %v but got %v diff: %v", tt.want, got, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type (
+	ImportRepo interface {
+		// ImportOffset returns zero if the source wasn't imported before.
+		ImportOffset(ctx context.Context, source string) (int64, error)
+		// ImportTranslations upserts translations and saves offset of the source atomically.
+		ImportTranslations(ctx context.Context, source string, words []entity.WordTrans, offset int64) error
+	}
+
+	DumpRepo interface {
+		// Words calls fn with translation of each word starting from offset and offset of the next word,
+		// returns offset of the dump end.
+		Words(ctx context.Context, offset int64, fn func(wordTrans entity.WordTrans, next int64) error) (int64, error)
+	}
+)
+
+type Importer struct {
+	importRepo ImportRepo
+	batchSize  int
+}
+
+// Import stores translations of the dump in batches, import of a source resumes after the last stored batch.
+// Progress is called after each batch.
+func (s *Importer) Import(ctx context.Context, source string, dump DumpRepo, progress func(entity.ImportProgress)) error {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "ImporterService - Import")
+	defer span.End()
+
+	offset, err := s.importRepo.ImportOffset(ctx, source)
+	if err != nil {
+		return fmt.Errorf("Importer - Import - s.importRepo.ImportOffset: %w", err)
+	}
+
+	state := entity.ImportProgress{Source: source, Offset: offset}
+	batch := make([]entity.WordTrans, 0, s.batchSize)
+	flush := func(next int64) error {
+		if err := s.importRepo.ImportTranslations(ctx, source, batch, next); err != nil {
+			return fmt.Errorf("Importer - Import - s.importRepo.ImportTranslations: %w", err)
+		}
+		state.Offset, state.Words = next, state.Words+len(batch)
+		batch = batch[:0]
+		progress(state)
+		return nil
+	}
+
+	end, err := dump.Words(ctx, offset, func(wordTrans entity.WordTrans, next int64) error {
+		batch = append(batch, wordTrans)
+		if len(batch) < s.batchSize {
+			return nil
+		}
+		return flush(next)
+	})
+	if err != nil {
+		return fmt.Errorf("Importer - Import - dump.Words: %w", err)
+	}
+
+	// The rest of the dump can have no words but its offset is saved anyway.
+	if len(batch) > 0 || end > state.Offset {
+		return flush(end)
+	}
+	return nil
+}
+
+func NewImporterService(importRepo ImportRepo, batchSize int) *Importer {
+	return &Importer{
+		importRepo: importRepo,
+		batchSize:  batchSize,
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+)
+
+// Dump of three words, each word takes 10 bytes.
+func setupDump(dumpMock *repomock.DumpRepo, offset int64, err error) {
+	dumpMock.On("Words", mock.Anything, offset, mock.Anything).Once().
+		Return(func(ctx context.Context, offset int64, fn func(entity.WordTrans, int64) error) int64 {
+			for _, word := range []string{"apple", "bank", "cat"}[offset/10:] {
+				offset += 10
+				if err := fn(entity.WordTrans{Word: word}, offset); err != nil {
+					return offset
+				}
+			}
+			return offset
+		}, err)
+}
+
+func Test_Import(t *testing.T) {
+	errDB := errors.New("db is down")
+	words := func(words ...string) []entity.WordTrans {
+		trans := make([]entity.WordTrans, 0, len(words))
+		for _, w := range words {
+			trans = append(trans, entity.WordTrans{Word: w})
+		}
+		return trans
+	}
+	tests := []struct {
+		name         string
+		setupMock    func(importMock *repomock.ImportRepo, dumpMock *repomock.DumpRepo)
+		wantProgress []entity.ImportProgress
+		wantErr      error
+	}{
+		{
+			name: "Import in batches",
+			setupMock: func(importMock *repomock.ImportRepo, dumpMock *repomock.DumpRepo) {
+				importMock.On("ImportOffset", mock.Anything, "dump").Once().Return(int64(0), nil)
+				setupDump(dumpMock, 0, nil)
+				importMock.On("ImportTranslations", mock.Anything, "dump", words("apple", "bank"), int64(20)).Once().Return(nil)
+				importMock.On("ImportTranslations", mock.Anything, "dump", words("cat"), int64(30)).Once().Return(nil)
+			},
+			wantProgress: []entity.ImportProgress{
+				{Source: "dump", Offset: 20, Words: 2},
+				{Source: "dump", Offset: 30, Words: 3},
+			},
+		},
+		{
+			name: "Resume import",
+			setupMock: func(importMock *repomock.ImportRepo, dumpMock *repomock.DumpRepo) {
+				importMock.On("ImportOffset", mock.Anything, "dump").Once().Return(int64(20), nil)
+				setupDump(dumpMock, 20, nil)
+				importMock.On("ImportTranslations", mock.Anything, "dump", words("cat"), int64(30)).Once().Return(nil)
+			},
+			wantProgress: []entity.ImportProgress{
+				{Source: "dump", Offset: 30, Words: 1},
+			},
+		},
+		{
+			name: "Imported dump",
+			setupMock: func(importMock *repomock.ImportRepo, dumpMock *repomock.DumpRepo) {
+				importMock.On("ImportOffset", mock.Anything, "dump").Once().Return(int64(30), nil)
+				setupDump(dumpMock, 30, nil)
+			},
+		},
+		{
+			name: "Failed batch",
+			setupMock: func(importMock *repomock.ImportRepo, dumpMock *repomock.DumpRepo) {
+				importMock.On("ImportOffset", mock.Anything, "dump").Once().Return(int64(0), nil)
+				setupDump(dumpMock, 0, errDB)
+				importMock.On("ImportTranslations", mock.Anything, "dump", words("apple", "bank"), int64(20)).Once().Return(errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		importMock, dumpMock := repomock.NewImportRepo(t), repomock.NewDumpRepo(t)
+		importer := NewImporterService(importMock, 2)
+		tt.setupMock(importMock, dumpMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			var progress []entity.ImportProgress
+			err := importer.Import(ctx, "dump", dumpMock, func(p entity.ImportProgress) {
+				progress = append(progress, p)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.wantProgress, progress); diff != "" {
+				t.Fatalf("progress mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type RefreshRepo interface {
+	// StaleTranslations returns keys and provider of stale translations, least recently fetched first.
+	StaleTranslations(ctx context.Context, query entity.StaleQuery) ([]entity.WordTrans, error)
+	// UpdateTranslation replaces stored translation and marks it fetched now,
+	// entity.ErrTranslationNotFound is returned if translation was deleted.
+	UpdateTranslation(ctx context.Context, wordTrans entity.WordTrans) error
+	// SaveTranslation is UpdateTranslation which creates a missing translation.
+	SaveTranslation(ctx context.Context, wordTrans entity.WordTrans) error
+	// PostponeRefresh marks translation fetched now keeping its data, so failing translations don't block others.
+	PostponeRefresh(ctx context.Context, wordTrans entity.WordTrans) error
+}
+
+// Refresher translates stored translations again when they get old or were made by an older provider version.
+type Refresher struct {
+	refreshRepo RefreshRepo
+	providers   TransProviders
+	maxAge      time.Duration
+	batchSize   int
+}
+
+// Refresh translates one batch of stale translations, a failed translation keeps its data.
+func (s *Refresher) Refresh(ctx context.Context) (entity.RefreshStats, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "RefresherService - Refresh")
+	defer span.End()
+
+	query := entity.StaleQuery{
+		Versions: s.providers.Versions(),
+		Limit:    s.batchSize,
+	}
+	if s.maxAge > 0 {
+		query.FetchedBefore = time.Now().UTC().Add(-s.maxAge)
+	}
+
+	stale, err := s.refreshRepo.StaleTranslations(ctx, query)
+	if err != nil {
+		return entity.RefreshStats{}, fmt.Errorf("Refresher - Refresh - s.refreshRepo.StaleTranslations: %w", err)
+	}
+
+	var stats entity.RefreshStats
+	for _, old := range stale {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		_, err := s.refresh(ctx, old.Word, old.SrcLang, old.TrgtLang, false)
+		// Translation was deleted by an admin during the batch.
+		if errors.Is(err, entity.ErrTranslationNotFound) {
+			continue
+		}
+		if err != nil {
+			stats.Failed++
+			if err := s.refreshRepo.PostponeRefresh(ctx, old); err != nil {
+				return stats, fmt.Errorf("Refresher - Refresh - s.refreshRepo.PostponeRefresh: %w", err)
+			}
+			continue
+		}
+		stats.Refreshed++
+	}
+	return stats, nil
+}
+
+// RefreshWord translates the word again regardless of its age.
+func (s *Refresher) RefreshWord(ctx context.Context, word, srcLang, trgtLang string) (entity.WordTrans, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "RefresherService - RefreshWord")
+	defer span.End()
+
+	wordTrans, err := s.refresh(ctx, word, srcLang, trgtLang, true)
+	if err != nil {
+		return entity.WordTrans{}, fmt.Errorf("Refresher - RefreshWord - s.refresh: %w", err)
+	}
+	return wordTrans, nil
+}
+
+// Translates the word and stores the translation, missing translation is created only if create is set.
+func (s *Refresher) refresh(ctx context.Context, word, srcLang, trgtLang string, create bool) (entity.WordTrans, error) {
+	wordTrans, err := s.providers.Translate(ctx, word, srcLang, trgtLang)
+	if err != nil {
+		return entity.WordTrans{}, fmt.Errorf("Refresher - refresh - s.providers.Translate: %w", err)
+	}
+
+	// Translation is stored under the same key as the word service stores it.
+	wordTrans.Word, wordTrans.SrcLang, wordTrans.TrgtLang = word, srcLang, trgtLang
+	if create {
+		if err := s.refreshRepo.SaveTranslation(ctx, wordTrans); err != nil {
+			return entity.WordTrans{}, fmt.Errorf("Refresher - refresh - s.refreshRepo.SaveTranslation: %w", err)
+		}
+		return wordTrans, nil
+	}
+	if err := s.refreshRepo.UpdateTranslation(ctx, wordTrans); err != nil {
+		return entity.WordTrans{}, fmt.Errorf("Refresher - refresh - s.refreshRepo.UpdateTranslation: %w", err)
+	}
+	return wordTrans, nil
+}
+
+// NewRefresherService refreshes at most batchSize translations per run,
+// zero maxAge refreshes only translations of older provider versions.
+func NewRefresherService(refreshRepo RefreshRepo, providers TransProviders, maxAge time.Duration, batchSize int) *Refresher {
+	return &Refresher{
+		refreshRepo: refreshRepo,
+		providers:   providers,
+		maxAge:      maxAge,
+		batchSize:   batchSize,
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_Refresh(t *testing.T) {
+	errDB := errors.New("db is down")
+	gift := entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "ru", Provider: "google"}
+	bank := entity.WordTrans{Word: "bank", SrcLang: "en", TrgtLang: "ru", Provider: "google"}
+	// Stale query of providers with versions and max age of a day.
+	staleQuery := mock.MatchedBy(func(q entity.StaleQuery) bool {
+		age := time.Since(q.FetchedBefore)
+		return q.Limit == 10 && cmp.Equal(q.Versions, map[string]int{"google": 2}) && age >= 24*time.Hour && age < 25*time.Hour
+	})
+	tests := []struct {
+		name      string
+		setupMock func(refreshMock *repomock.RefreshRepo, trMock *repomock.TransRepo)
+		wantStats entity.RefreshStats
+		wantErr   error
+	}{
+		{
+			name: "Refresh stale translations",
+			setupMock: func(refreshMock *repomock.RefreshRepo, trMock *repomock.TransRepo) {
+				refreshMock.On("StaleTranslations", mock.Anything, staleQuery).Once().Return([]entity.WordTrans{gift}, nil)
+				trMock.On("Translate", mock.Anything, "gift", "en", "ru").Once().
+					Return(entity.WordTrans{Word: "Gift", SrcLang: "auto", TrgtLang: "ru", MainTranslation: "подарок"}, nil)
+				refreshMock.On("UpdateTranslation", mock.Anything, entity.WordTrans{
+					Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "подарок", Provider: "google", ProviderVersion: 2,
+				}).Once().Return(nil)
+			},
+			wantStats: entity.RefreshStats{Refreshed: 1},
+		},
+		{
+			name: "Postpone failed translation",
+			setupMock: func(refreshMock *repomock.RefreshRepo, trMock *repomock.TransRepo) {
+				refreshMock.On("StaleTranslations", mock.Anything, staleQuery).Once().Return([]entity.WordTrans{gift, bank}, nil)
+				trMock.On("Translate", mock.Anything, "gift", "en", "ru").Once().Return(entity.WordTrans{}, errors.New("upstream is down"))
+				refreshMock.On("PostponeRefresh", mock.Anything, gift).Once().Return(nil)
+				trMock.On("Translate", mock.Anything, "bank", "en", "ru").Once().
+					Return(entity.WordTrans{MainTranslation: "банк"}, nil)
+				refreshMock.On("UpdateTranslation", mock.Anything, mock.Anything).Once().Return(nil)
+			},
+			wantStats: entity.RefreshStats{Refreshed: 1, Failed: 1},
+		},
+		{
+			name: "Skip translation deleted during the batch",
+			setupMock: func(refreshMock *repomock.RefreshRepo, trMock *repomock.TransRepo) {
+				refreshMock.On("StaleTranslations", mock.Anything, staleQuery).Once().Return([]entity.WordTrans{gift}, nil)
+				trMock.On("Translate", mock.Anything, "gift", "en", "ru").Once().
+					Return(entity.WordTrans{MainTranslation: "подарок"}, nil)
+				refreshMock.On("UpdateTranslation", mock.Anything, mock.Anything).Once().Return(entity.ErrTranslationNotFound)
+			},
+			wantStats: entity.RefreshStats{},
+		},
+		{
+			name: "Failed stale translations query",
+			setupMock: func(refreshMock *repomock.RefreshRepo, trMock *repomock.TransRepo) {
+				refreshMock.On("StaleTranslations", mock.Anything, staleQuery).Once().Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		refreshMock, trMock := repomock.NewRefreshRepo(t), repomock.NewTransRepo(t)
+		refresher := NewRefresherService(refreshMock, TransProviders{{Name: "google", Version: 2, Repo: trMock}}, 24*time.Hour, 10)
+		tt.setupMock(refreshMock, trMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := refresher.Refresh(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.wantStats, stats); diff != "" {
+				t.Fatalf("stats mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_RefreshWord(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(refreshMock *repomock.RefreshRepo, trMock *repomock.TransRepo)
+		want      entity.WordTrans
+		wantErr   error
+	}{
+		{
+			name: "Refresh word",
+			setupMock: func(refreshMock *repomock.RefreshRepo, trMock *repomock.TransRepo) {
+				trMock.On("Translate", mock.Anything, "gift", "en", "ru").Once().
+					Return(entity.WordTrans{MainTranslation: "подарок"}, nil)
+				refreshMock.On("SaveTranslation", mock.Anything, entity.WordTrans{
+					Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "подарок", Provider: "google", ProviderVersion: 2,
+				}).Once().Return(nil)
+			},
+			want: entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "подарок", Provider: "google", ProviderVersion: 2},
+		},
+		{
+			name: "Refresh not supported word",
+			setupMock: func(refreshMock *repomock.RefreshRepo, trMock *repomock.TransRepo) {
+				trMock.On("Translate", mock.Anything, "gift", "en", "ru").Once().Return(entity.WordTrans{}, entity.ErrWordNotSupported)
+			},
+			wantErr: entity.ErrWordNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		refreshMock, trMock := repomock.NewRefreshRepo(t), repomock.NewTransRepo(t)
+		refresher := NewRefresherService(refreshMock, TransProviders{{Name: "google", Version: 2, Repo: trMock}}, 24*time.Hour, 10)
+		tt.setupMock(refreshMock, trMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := refresher.RefreshWord(ctx, "gift", "en", "ru")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("translation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
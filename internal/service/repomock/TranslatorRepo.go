@@ -3,8 +3,8 @@
 package repomock
 
 import (
-	"context"
-	
+	context "context"
+
 	entity "github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,23 +14,23 @@ type TransRepo struct {
 	mock.Mock
 }
 
-// Translate provides a mock function with given fields: ctx, word
-func (_m *TransRepo) Translate(ctx context.Context, word string) (entity.WordTrans, error) {
-	ret := _m.Called(ctx, word)
+// Translate provides a mock function with given fields: ctx, word, srcLang, trgtLang
+func (_m *TransRepo) Translate(ctx context.Context, word string, srcLang string, trgtLang string) (entity.WordTrans, error) {
+	ret := _m.Called(ctx, word, srcLang, trgtLang)
 
 	var r0 entity.WordTrans
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.WordTrans, error)); ok {
-		return rf(ctx, word)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (entity.WordTrans, error)); ok {
+		return rf(ctx, word, srcLang, trgtLang)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) entity.WordTrans); ok {
-		r0 = rf(ctx, word)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) entity.WordTrans); ok {
+		r0 = rf(ctx, word, srcLang, trgtLang)
 	} else {
 		r0 = ret.Get(0).(entity.WordTrans)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, word)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, word, srcLang, trgtLang)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,4 +51,4 @@ func NewTransRepo(t mockConstructorTestingTNewTransRepo) *TransRepo {
 	t.Cleanup(func() { mock.AssertExpectations(t) })
 
 	return mock
-}
\ No newline at end of file
+}
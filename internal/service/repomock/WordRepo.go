@@ -14,6 +14,20 @@ type WordRepo struct {
 	mock.Mock
 }
 
+// AddCustomWord provides a mock function with given fields: ctx, collection
+func (_m *WordRepo) AddCustomWord(ctx context.Context, collection entity.Collection) error {
+	ret := _m.Called(ctx, collection)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) error); ok {
+		r0 = rf(ctx, collection)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddTranslation provides a mock function with given fields: ctx, wordTrans
 func (_m *WordRepo) AddTranslation(ctx context.Context, wordTrans entity.WordTrans) error {
 	ret := _m.Called(ctx, wordTrans)
@@ -42,6 +56,44 @@ func (_m *WordRepo) AddWord(ctx context.Context, collection entity.Collection) e
 	return r0
 }
 
+// Card provides a mock function with given fields: ctx, collection
+func (_m *WordRepo) Card(ctx context.Context, collection entity.Collection) (entity.Collection, error) {
+	ret := _m.Called(ctx, collection)
+
+	var r0 entity.Collection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) (entity.Collection, error)); ok {
+		return rf(ctx, collection)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) entity.Collection); ok {
+		r0 = rf(ctx, collection)
+	} else {
+		r0 = ret.Get(0).(entity.Collection)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Collection) error); ok {
+		r1 = rf(ctx, collection)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CopyWord provides a mock function with given fields: ctx, collection, target
+func (_m *WordRepo) CopyWord(ctx context.Context, collection entity.Collection, target string) error {
+	ret := _m.Called(ctx, collection, target)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, string) error); ok {
+		r0 = rf(ctx, collection, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteWord provides a mock function with given fields: ctx, collection
 func (_m *WordRepo) DeleteWord(ctx context.Context, collection entity.Collection) error {
 	ret := _m.Called(ctx, collection)
@@ -56,6 +108,32 @@ func (_m *WordRepo) DeleteWord(ctx context.Context, collection entity.Collection
 	return r0
 }
 
+// DueWords provides a mock function with given fields: ctx, query
+func (_m *WordRepo) DueWords(ctx context.Context, query entity.DueQuery) (*entity.DueWords, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 *entity.DueWords
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DueQuery) (*entity.DueWords, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DueQuery) *entity.DueWords); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DueWords)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.DueQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsTransInDB provides a mock function with given fields: ctx, collection
 func (_m *WordRepo) IsTransInDB(ctx context.Context, collection entity.Collection) (bool, error) {
 	ret := _m.Called(ctx, collection)
@@ -104,6 +182,79 @@ func (_m *WordRepo) IsWordInCollection(ctx context.Context, collection entity.Co
 	return r0, r1
 }
 
+// LanguagePair provides a mock function with given fields: ctx, collection
+func (_m *WordRepo) LanguagePair(ctx context.Context, collection entity.Collection) (string, string, error) {
+	ret := _m.Called(ctx, collection)
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) (string, string, error)); ok {
+		return rf(ctx, collection)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) string); ok {
+		r0 = rf(ctx, collection)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Collection) string); ok {
+		r1 = rf(ctx, collection)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Collection) error); ok {
+		r2 = rf(ctx, collection)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MoveWord provides a mock function with given fields: ctx, collection, target
+func (_m *WordRepo) MoveWord(ctx context.Context, collection entity.Collection, target string) error {
+	ret := _m.Called(ctx, collection, target)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, string) error); ok {
+		r0 = rf(ctx, collection, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveReview provides a mock function with given fields: ctx, collection, reviewLog
+func (_m *WordRepo) SaveReview(ctx context.Context, collection entity.Collection, reviewLog entity.ReviewLog) error {
+	ret := _m.Called(ctx, collection, reviewLog)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection, entity.ReviewLog) error); ok {
+		r0 = rf(ctx, collection, reviewLog)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUserTrans provides a mock function with given fields: ctx, userTrans
+func (_m *WordRepo) SaveUserTrans(ctx context.Context, userTrans entity.UserTrans) error {
+	ret := _m.Called(ctx, userTrans)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserTrans) error); ok {
+		r0 = rf(ctx, userTrans)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateLearnInterval provides a mock function with given fields: ctx, collection
 func (_m *WordRepo) UpdateLearnInterval(ctx context.Context, collection entity.Collection) error {
 	ret := _m.Called(ctx, collection)
@@ -144,6 +295,58 @@ func (_m *WordRepo) UserWords(ctx context.Context, collection entity.Collection)
 	return r0, r1
 }
 
+// WordHistory provides a mock function with given fields: ctx, collection
+func (_m *WordRepo) WordHistory(ctx context.Context, collection entity.Collection) ([]entity.ReviewLog, error) {
+	ret := _m.Called(ctx, collection)
+
+	var r0 []entity.ReviewLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) ([]entity.ReviewLog, error)); ok {
+		return rf(ctx, collection)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Collection) []entity.ReviewLog); ok {
+		r0 = rf(ctx, collection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReviewLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Collection) error); ok {
+		r1 = rf(ctx, collection)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Words provides a mock function with given fields: ctx, query
+func (_m *WordRepo) Words(ctx context.Context, query entity.WordsQuery) ([]entity.ListedWord, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []entity.ListedWord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WordsQuery) ([]entity.ListedWord, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WordsQuery) []entity.ListedWord); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ListedWord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.WordsQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewWordRepo interface {
 	mock.TestingT
 	Cleanup(func())
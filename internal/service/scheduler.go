@@ -0,0 +1,32 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+)
+
+const (
+	day = 24 * time.Hour
+
+	defaultScheduler = entity.SchedulerSM2
+)
+
+type (
+	// Scheduler computes the next learn interval of a card after a review.
+	Scheduler interface {
+		// Schedule returns card with updated LastRepeat, TimeDiff and scheduler state.
+		Schedule(card entity.Collection, grade entity.Grade, reviewedAt time.Time) entity.Collection
+	}
+
+	// Schedulers maps algorithms users can choose from to their implementations.
+	Schedulers map[entity.SchedulerName]Scheduler
+)
+
+// Get returns scheduler by name, falls back to the default one.
+func (s Schedulers) Get(name entity.SchedulerName) (entity.SchedulerName, Scheduler) {
+	if scheduler, ok := s[name]; ok {
+		return name, scheduler
+	}
+	return defaultScheduler, s[defaultScheduler]
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type (
+	SettingsRepo interface {
+		// Settings returns default settings if user doesn't exist yet.
+		Settings(ctx context.Context, userID string) (entity.Settings, error)
+		// CreateUser adds user with default settings, existing user is kept.
+		CreateUser(ctx context.Context, userID string) error
+		// User returns entity.ErrUserNotFound if user doesn't exist.
+		User(ctx context.Context, userID string) (entity.User, error)
+		// PatchSettings saves set fields of the patch, user is created if it doesn't exist.
+		PatchSettings(ctx context.Context, patch entity.SettingsPatch) (entity.User, error)
+	}
+)
+
+// Location of the user time zone, UTC is used for an unknown zone.
+func userLocation(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+type Settings struct {
+	settingsRepo SettingsRepo
+	// IDs of users known to exist, so users aren't created on each request.
+	registered sync.Map
+}
+
+// RegisterUser creates user on the first request.
+func (s *Settings) RegisterUser(ctx context.Context, userID string) error {
+	if _, ok := s.registered.Load(userID); ok {
+		return nil
+	}
+
+	_, span := otel.Tracer(otelName).Start(ctx, "SettingsService - RegisterUser")
+	defer span.End()
+
+	if err := s.settingsRepo.CreateUser(ctx, userID); err != nil {
+		return fmt.Errorf("Settings - RegisterUser - s.settingsRepo.CreateUser: %w", err)
+	}
+	s.registered.Store(userID, struct{}{})
+	return nil
+}
+
+// ForgetUser makes the next RegisterUser create the user again, it's called when user data is deleted.
+func (s *Settings) ForgetUser(userID string) {
+	s.registered.Delete(userID)
+}
+
+// Creates a user whose data was deleted while it was registered, other instances
+// don't forget users deleted by DeletePending of this one.
+func (s *Settings) recreateUser(ctx context.Context, userID string) error {
+	s.ForgetUser(userID)
+	return s.RegisterUser(ctx, userID)
+}
+
+func (s *Settings) User(ctx context.Context, userID string) (entity.User, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "SettingsService - User")
+	defer span.End()
+
+	user, err := s.settingsRepo.User(ctx, userID)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		if err := s.recreateUser(ctx, userID); err != nil {
+			return entity.User{}, fmt.Errorf("Settings - User - s.recreateUser: %w", err)
+		}
+		user, err = s.settingsRepo.User(ctx, userID)
+	}
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Settings - User - s.settingsRepo.User: %w", err)
+	}
+	return user, nil
+}
+
+// PatchSettings saves set fields of the patch and returns the updated user.
+func (s *Settings) PatchSettings(ctx context.Context, patch entity.SettingsPatch) (entity.User, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "SettingsService - PatchSettings")
+	defer span.End()
+
+	// Empty name and Local are valid for LoadLocation but they aren't time zones of the user.
+	if patch.Timezone != nil {
+		if _, err := time.LoadLocation(*patch.Timezone); err != nil || *patch.Timezone == "" || *patch.Timezone == "Local" {
+			return entity.User{}, fmt.Errorf("Settings - PatchSettings - time.LoadLocation: %w: %q", entity.ErrUnknownTimezone, *patch.Timezone)
+		}
+	}
+
+	user, err := s.settingsRepo.PatchSettings(ctx, patch)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Settings - PatchSettings - s.settingsRepo.PatchSettings: %w", err)
+	}
+	return user, nil
+}
+
+func NewSettingsService(settingsRepo SettingsRepo) *Settings {
+	return &Settings{
+		settingsRepo: settingsRepo,
+	}
+}
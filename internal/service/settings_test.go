@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_PatchSettings(t *testing.T) {
+	berlin, unknown, local := "Europe/Berlin", "Mars/Olympus", "Local"
+	tests := []struct {
+		name      string
+		patch     entity.SettingsPatch
+		setupMock func(stMock *repomock.SettingsRepo, patch entity.SettingsPatch)
+		wantErr   error
+	}{
+		{
+			name:  "Update time zone",
+			patch: entity.SettingsPatch{UserID: "12345", Timezone: &berlin},
+			setupMock: func(stMock *repomock.SettingsRepo, patch entity.SettingsPatch) {
+				stMock.On("PatchSettings", mock.Anything, patch).Once().Return(entity.User{ID: "12345"}, nil)
+			},
+		},
+		{
+			name:      "Unknown time zone",
+			patch:     entity.SettingsPatch{UserID: "12345", Timezone: &unknown},
+			setupMock: func(stMock *repomock.SettingsRepo, patch entity.SettingsPatch) {},
+			wantErr:   entity.ErrUnknownTimezone,
+		},
+		{
+			name:      "Local time zone",
+			patch:     entity.SettingsPatch{UserID: "12345", Timezone: &local},
+			setupMock: func(stMock *repomock.SettingsRepo, patch entity.SettingsPatch) {},
+			wantErr:   entity.ErrUnknownTimezone,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		stMock := repomock.NewSettingsRepo(t)
+		settingsService := NewSettingsService(stMock)
+		tt.setupMock(stMock, tt.patch)
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := settingsService.PatchSettings(ctx, tt.patch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_RegisterUser(t *testing.T) {
+	ctx := context.Background()
+	stMock := repomock.NewSettingsRepo(t)
+	settingsService := NewSettingsService(stMock)
+
+	stMock.On("CreateUser", mock.Anything, "failed").Once().Return(errors.New("some repo error"))
+	stMock.On("CreateUser", mock.Anything, "failed").Once().Return(nil)
+	// User is created once.
+	stMock.On("CreateUser", mock.Anything, "12345").Once().Return(nil)
+
+	if err := settingsService.RegisterUser(ctx, "failed"); err == nil {
+		t.Fatalf("want err but got nil")
+	}
+	for _, userID := range []string{"failed", "12345", "12345"} {
+		if err := settingsService.RegisterUser(ctx, userID); err != nil {
+			t.Fatalf("want nil but got: %v", err)
+		}
+	}
+}
+
+func Test_UserDeletedByOtherInstance(t *testing.T) {
+	ctx := context.Background()
+	stMock := repomock.NewSettingsRepo(t)
+	settingsService := NewSettingsService(stMock)
+
+	// User is registered, then its row is deleted by another instance.
+	stMock.On("CreateUser", mock.Anything, "12345").Twice().Return(nil)
+	stMock.On("User", mock.Anything, "12345").Once().Return(entity.User{}, entity.ErrUserNotFound)
+	stMock.On("User", mock.Anything, "12345").Once().Return(entity.User{ID: "12345"}, nil)
+
+	if err := settingsService.RegisterUser(ctx, "12345"); err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	user, err := settingsService.User(ctx, "12345")
+	if err != nil {
+		t.Fatalf("want nil but got: %v", err)
+	}
+	if user.ID != "12345" {
+		t.Fatalf("want user 12345 but got: %+v", user)
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+)
+
+// SM2 implements SuperMemo 2 algorithm,
+// see https://super-memory.com/english/ol/sm2.htm.
+type SM2 struct{}
+
+const (
+	sm2DefaultEase = 2.5
+	sm2MinEase     = 1.3
+)
+
+// Maps grades to SM-2 response quality (0-5), everything below 3 is a lapse.
+var sm2Quality = map[entity.Grade]float64{
+	entity.GradeAgain: 2,
+	entity.GradeHard:  3,
+	entity.GradeGood:  4,
+	entity.GradeEasy:  5,
+}
+
+func (s *SM2) Schedule(card entity.Collection, grade entity.Grade, reviewedAt time.Time) entity.Collection {
+	q := sm2Quality[grade]
+	if card.EaseFactor < sm2MinEase {
+		card.EaseFactor = sm2DefaultEase
+	}
+
+	switch {
+	case q < 3:
+		card.Repetitions = 0
+		card.TimeDiff = day
+	case card.Repetitions == 0:
+		card.Repetitions++
+		card.TimeDiff = day
+	case card.Repetitions == 1:
+		card.Repetitions++
+		card.TimeDiff = 6 * day
+	default:
+		card.Repetitions++
+		days := math.Round(card.TimeDiff.Hours() / 24 * card.EaseFactor)
+		card.TimeDiff = time.Duration(math.Max(days, 1)) * day
+	}
+
+	card.EaseFactor += 0.1 - (5-q)*(0.08+(5-q)*0.02)
+	if card.EaseFactor < sm2MinEase {
+		card.EaseFactor = sm2MinEase
+	}
+	card.LastRepeat = reviewedAt
+	// FSRS memory state is outdated now, FSRS will seed it again from TimeDiff.
+	card.Stability, card.Difficulty = 0, 0
+
+	return card
+}
+
+func NewSM2() *SM2 {
+	return &SM2{}
+}
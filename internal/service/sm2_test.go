@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_SM2Schedule(t *testing.T) {
+	reviewedAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		card  entity.Collection
+		grade entity.Grade
+	}
+	tests := []struct {
+		name string
+		args args
+		want entity.Collection
+	}{
+		{
+			name: "New card good",
+			args: args{
+				card:  entity.Collection{},
+				grade: entity.GradeGood,
+			},
+			want: entity.Collection{
+				LastRepeat:  reviewedAt,
+				TimeDiff:    day,
+				EaseFactor:  2.5,
+				Repetitions: 1,
+			},
+		},
+		{
+			name: "Second repetition easy",
+			args: args{
+				card:  entity.Collection{TimeDiff: day, EaseFactor: 2.5, Repetitions: 1},
+				grade: entity.GradeEasy,
+			},
+			want: entity.Collection{
+				LastRepeat:  reviewedAt,
+				TimeDiff:    6 * day,
+				EaseFactor:  2.6,
+				Repetitions: 2,
+			},
+		},
+		{
+			name: "Mature card hard",
+			args: args{
+				card:  entity.Collection{TimeDiff: 10 * day, EaseFactor: 2.5, Repetitions: 3},
+				grade: entity.GradeHard,
+			},
+			want: entity.Collection{
+				LastRepeat:  reviewedAt,
+				TimeDiff:    25 * day,
+				EaseFactor:  2.36,
+				Repetitions: 4,
+			},
+		},
+		{
+			name: "Lapse resets repetitions",
+			args: args{
+				card:  entity.Collection{TimeDiff: 30 * day, EaseFactor: 1.4, Repetitions: 5},
+				grade: entity.GradeAgain,
+			},
+			want: entity.Collection{
+				LastRepeat:  reviewedAt,
+				TimeDiff:    day,
+				EaseFactor:  1.3,
+				Repetitions: 0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s := NewSM2()
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Schedule(tt.args.card, tt.args.grade, reviewedAt)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Fatalf("wanted: %v but got %v diff: %v", tt.want, got, diff)
+			}
+		})
+	}
+}
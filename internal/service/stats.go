@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+const forecastDays = 30
+
+type (
+	StatsRepo interface {
+		// Stats returns retention without rates and forecast without empty days.
+		Stats(ctx context.Context, query entity.StatsQuery) (*entity.Stats, error)
+	}
+)
+
+type Stats struct {
+	statsRepo    StatsRepo
+	settingsRepo SettingsRepo
+}
+
+// Stats returns learning statistics of the last heatmapDays days and a forecast for the next 30 days.
+func (s *Stats) Stats(ctx context.Context, userID, collection string, heatmapDays int) (*entity.Stats, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "StatsService - Stats")
+	defer span.End()
+
+	settings, err := s.settingsRepo.Settings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Stats - Stats - s.settingsRepo.Settings: %w", err)
+	}
+
+	// Days start at midnight of the user time zone.
+	loc := userLocation(settings.Timezone)
+	y, m, d := time.Now().In(loc).Date()
+	query := entity.StatsQuery{
+		UserID:       userID,
+		Collection:   collection,
+		From:         time.Date(y, m, d-(heatmapDays-1), 0, 0, 0, 0, loc).UTC(),
+		DayStart:     time.Date(y, m, d, 0, 0, 0, 0, loc).UTC(),
+		Timezone:     loc.String(),
+		ForecastDays: forecastDays,
+	}
+
+	stats, err := s.statsRepo.Stats(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Stats - Stats - s.statsRepo.Stats: %w", err)
+	}
+
+	for i := range stats.Retention {
+		if stats.Retention[i].Reviews > 0 {
+			stats.Retention[i].Retention = float64(stats.Retention[i].Passed) / float64(stats.Retention[i].Reviews)
+		}
+	}
+	stats.Forecast = s.fillDays(stats.Forecast, query.DayStart, query.ForecastDays, loc)
+
+	return stats, nil
+}
+
+// Returns a count for every day of loc starting from the given one, missing days have zero count.
+func (s *Stats) fillDays(counts []entity.DayCount, from time.Time, days int, loc *time.Location) []entity.DayCount {
+	byDay := make(map[time.Time]int, len(counts))
+	for _, c := range counts {
+		byDay[c.Day.UTC()] += c.Count
+	}
+
+	// Days aren't always 24 hours long because of DST.
+	y, m, d := from.In(loc).Date()
+	filled := make([]entity.DayCount, days)
+	for i := range filled {
+		dayStart := time.Date(y, m, d+i, 0, 0, 0, 0, loc).UTC()
+		filled[i] = entity.DayCount{Day: dayStart, Count: byDay[dayStart]}
+	}
+	return filled
+}
+
+func NewStatsService(statsRepo StatsRepo, settingsRepo SettingsRepo) *Stats {
+	return &Stats{
+		statsRepo:    statsRepo,
+		settingsRepo: settingsRepo,
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_Stats(t *testing.T) {
+	dayStart := time.Now().UTC().Truncate(day)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("time.LoadLocation: %v", err)
+	}
+	y, m, d := time.Now().In(tokyo).Date()
+	tokyoDayStart := time.Date(y, m, d, 0, 0, 0, 0, tokyo).UTC()
+	tests := []struct {
+		name      string
+		timezone  string
+		setupMock func(stMock *repomock.StatsRepo)
+		check     func(t *testing.T, stats *entity.Stats)
+		wantErr   bool
+	}{
+		{
+			name:     "Stats with rates and filled forecast",
+			timezone: "UTC",
+			setupMock: func(stMock *repomock.StatsRepo) {
+				stMock.On("Stats", mock.Anything, mock.MatchedBy(func(q entity.StatsQuery) bool {
+					return q.UserID == "12345" && q.Collection == "coll" && q.ForecastDays == forecastDays &&
+						q.DayStart.Equal(dayStart) && q.From.Equal(dayStart.Add(-6*day))
+				})).Once().Return(&entity.Stats{
+					Retention: []entity.CollectionRetention{
+						{CollectionName: "coll", Reviews: 4, Passed: 3},
+						{CollectionName: "empty"},
+					},
+					Forecast: []entity.DayCount{
+						{Day: dayStart, Count: 2},
+						{Day: dayStart.Add(2 * day), Count: 5},
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, stats *entity.Stats) {
+				if stats.Retention[0].Retention != 0.75 || stats.Retention[1].Retention != 0 {
+					t.Fatalf("wrong retention: %v", stats.Retention)
+				}
+				if len(stats.Forecast) != forecastDays {
+					t.Fatalf("want %v forecast days but got: %v", forecastDays, len(stats.Forecast))
+				}
+				counts := []int{stats.Forecast[0].Count, stats.Forecast[1].Count, stats.Forecast[2].Count}
+				if counts[0] != 2 || counts[1] != 0 || counts[2] != 5 {
+					t.Fatalf("wrong forecast counts: %v", counts)
+				}
+				if !stats.Forecast[1].Day.Equal(dayStart.Add(day)) {
+					t.Fatalf("wrong forecast day: %v", stats.Forecast[1].Day)
+				}
+			},
+		},
+		{
+			name:     "Days of user time zone",
+			timezone: "Asia/Tokyo",
+			setupMock: func(stMock *repomock.StatsRepo) {
+				stMock.On("Stats", mock.Anything, mock.MatchedBy(func(q entity.StatsQuery) bool {
+					return q.Timezone == "Asia/Tokyo" && q.DayStart.Equal(tokyoDayStart) && q.From.Equal(tokyoDayStart.Add(-6*day))
+				})).Once().Return(&entity.Stats{
+					Forecast: []entity.DayCount{{Day: tokyoDayStart.Add(day), Count: 3}},
+				}, nil)
+			},
+			check: func(t *testing.T, stats *entity.Stats) {
+				if !stats.Forecast[0].Day.Equal(tokyoDayStart) || stats.Forecast[1].Count != 3 {
+					t.Fatalf("wrong forecast: %v", stats.Forecast[:2])
+				}
+			},
+		},
+		{
+			name: "Repo error",
+			setupMock: func(stMock *repomock.StatsRepo) {
+				stMock.On("Stats", mock.Anything, mock.Anything).Once().Return(nil, errors.New("some repo error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		stMock, settingsMock := repomock.NewStatsRepo(t), repomock.NewSettingsRepo(t)
+		statsService := NewStatsService(stMock, settingsMock)
+		settingsMock.On("Settings", mock.Anything, "12345").Once().Return(entity.Settings{Timezone: tt.timezone}, nil)
+		tt.setupMock(stMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := statsService.Stats(ctx, "12345", "coll", 7)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// TransProvider is a named source of translations.
+	TransProvider struct {
+		Name string
+		// Version of the adapter, it is bumped when the adapter starts producing better translations.
+		Version int
+		Repo    TransRepo
+	}
+
+	// TransProviders are translation providers in priority order.
+	TransProviders []TransProvider
+)
+
+// Translate returns translation of the first provider which has one,
+// provider name and version are recorded in WordTrans.Provider and WordTrans.ProviderVersion.
+// Provider is skipped on error or empty result, ErrWordNotSupported is returned only if no provider failed.
+func (p TransProviders) Translate(ctx context.Context, word, srcLang, trgtLang string) (entity.WordTrans, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "TransProviders - Translate")
+	defer span.End()
+
+	var lastErr error
+	for _, provider := range p {
+		wordTrans, err := provider.Repo.Translate(ctx, word, srcLang, trgtLang)
+		if err == nil && (len(wordTrans.Translations) != 0 || wordTrans.MainTranslation != "") {
+			wordTrans.Provider, wordTrans.ProviderVersion = provider.Name, provider.Version
+			span.SetAttributes(attribute.String("provider", provider.Name))
+			return wordTrans, nil
+		}
+
+		if err != nil && !errors.Is(err, entity.ErrWordNotSupported) {
+			lastErr = fmt.Errorf("TransProviders - Translate - %s: %w", provider.Name, err)
+			span.RecordError(lastErr, trace.WithAttributes(attribute.String("provider", provider.Name)))
+		}
+	}
+
+	if lastErr != nil {
+		return entity.WordTrans{}, lastErr
+	}
+	return entity.WordTrans{}, entity.ErrWordNotSupported
+}
+
+// Versions returns current version of each provider.
+func (p TransProviders) Versions() map[string]int {
+	versions := make(map[string]int, len(p))
+	for _, provider := range p {
+		versions[provider.Name] = provider.Version
+	}
+	return versions
+}
+
+// Stats returns counters of providers with CountingRepo.
+func (p TransProviders) Stats() []entity.ProviderStats {
+	stats := make([]entity.ProviderStats, 0, len(p))
+	for _, provider := range p {
+		counter, ok := provider.Repo.(*CountingRepo)
+		if !ok {
+			continue
+		}
+		s := counter.Stats()
+		s.Name = provider.Name
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// CountingRepo counts translate requests and failures of the wrapped provider.
+type CountingRepo struct {
+	repo  TransRepo
+	mu    sync.Mutex
+	stats entity.ProviderStats
+}
+
+func (r *CountingRepo) Translate(ctx context.Context, word, srcLang, trgtLang string) (entity.WordTrans, error) {
+	wordTrans, err := r.repo.Translate(ctx, word, srcLang, trgtLang)
+	// Requests canceled by the caller, e.g. when time budget of a bulk add is exceeded, tell nothing about the provider.
+	if ctx.Err() != nil {
+		return wordTrans, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.Requests++
+	if err != nil && !errors.Is(err, entity.ErrWordNotSupported) {
+		now := time.Now().UTC()
+		r.stats.Errors++
+		r.stats.LastError, r.stats.LastErrorAt = err.Error(), &now
+	}
+	return wordTrans, err
+}
+
+// Stats returns counters without provider name.
+func (r *CountingRepo) Stats() entity.ProviderStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.stats
+	if stats.Requests > 0 {
+		stats.ErrorRate = float64(stats.Errors) / float64(stats.Requests)
+	}
+	return stats
+}
+
+func NewCountingRepo(repo TransRepo) *CountingRepo {
+	return &CountingRepo{
+		repo: repo,
+	}
+}
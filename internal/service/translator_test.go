@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
+	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_TransProvidersTranslate(t *testing.T) {
+	errUpstream := errors.New("upstream is down")
+	trans := entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "de", MainTranslation: "Geschenk"}
+	tests := []struct {
+		name      string
+		setupMock func(first, second *repomock.TransRepo)
+		want      entity.WordTrans
+		wantErr   error
+	}{
+		{
+			name: "First provider",
+			setupMock: func(first, second *repomock.TransRepo) {
+				first.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(trans, nil)
+			},
+			want: entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "de", MainTranslation: "Geschenk", Provider: "first"},
+		},
+		{
+			name: "Fallback on error",
+			setupMock: func(first, second *repomock.TransRepo) {
+				first.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(entity.WordTrans{}, errUpstream)
+				second.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(trans, nil)
+			},
+			want: entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "de", MainTranslation: "Geschenk", Provider: "second"},
+		},
+		{
+			name: "Fallback on empty result",
+			setupMock: func(first, second *repomock.TransRepo) {
+				first.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(entity.WordTrans{Word: "gift"}, nil)
+				second.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(trans, nil)
+			},
+			want: entity.WordTrans{Word: "gift", SrcLang: "en", TrgtLang: "de", MainTranslation: "Geschenk", Provider: "second"},
+		},
+		{
+			name: "Not supported by any provider",
+			setupMock: func(first, second *repomock.TransRepo) {
+				first.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(entity.WordTrans{}, entity.ErrWordNotSupported)
+				second.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(entity.WordTrans{}, nil)
+			},
+			wantErr: entity.ErrWordNotSupported,
+		},
+		{
+			name: "Provider failed",
+			setupMock: func(first, second *repomock.TransRepo) {
+				first.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(entity.WordTrans{}, errUpstream)
+				second.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(entity.WordTrans{}, entity.ErrWordNotSupported)
+			},
+			wantErr: errUpstream,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		first, second := repomock.NewTransRepo(t), repomock.NewTransRepo(t)
+		providers := TransProviders{{Name: "first", Repo: first}, {Name: "second", Repo: second}}
+		tt.setupMock(first, second)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := providers.Translate(ctx, "gift", "en", "de")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("translation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_TransProvidersStats(t *testing.T) {
+	ctx := context.Background()
+	trMock := repomock.NewTransRepo(t)
+	providers := TransProviders{{Name: "google", Repo: NewCountingRepo(trMock)}, {Name: "plain", Repo: repomock.NewTransRepo(t)}}
+
+	trMock.On("Translate", mock.Anything, "gift", "en", "de").Once().Return(entity.WordTrans{MainTranslation: "Geschenk"}, nil)
+	trMock.On("Translate", mock.Anything, "qwerty", "en", "de").Once().Return(entity.WordTrans{}, entity.ErrWordNotSupported)
+	trMock.On("Translate", mock.Anything, "house", "en", "de").Once().Return(entity.WordTrans{}, errors.New("upstream is down"))
+	for _, word := range []string{"gift", "qwerty", "house"} {
+		_, _ = providers[0].Repo.Translate(ctx, word, "en", "de")
+	}
+	// Canceled request isn't counted.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	trMock.On("Translate", mock.Anything, "tree", "en", "de").Once().Return(entity.WordTrans{}, context.Canceled)
+	_, _ = providers[0].Repo.Translate(canceled, "tree", "en", "de")
+
+	stats := providers.Stats()
+	if len(stats) != 1 || stats[0].LastErrorAt == nil {
+		t.Fatalf("want stats of one provider with last error but got: %+v", stats)
+	}
+	want := entity.ProviderStats{Name: "google", Requests: 3, Errors: 1, ErrorRate: float64(1) / 3, LastError: "upstream is down"}
+	stats[0].LastErrorAt = nil
+	if diff := cmp.Diff(want, stats[0]); diff != "" {
+		t.Fatalf("stats mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -3,33 +3,77 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/singleflight"
 )
 
-const otelName = "github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+const (
+	otelName = "github.com/Kin-dza-dzaa/flash_cards_api/internal/service"
+
+	// Provider name of custom card translations.
+	customProvider = "custom"
+)
 
 type (
 	WordRepo interface {
 		IsWordInCollection(ctx context.Context, collection entity.Collection) (bool, error)
 		IsTransInDB(ctx context.Context, collection entity.Collection) (bool, error)
 		AddTranslation(ctx context.Context, wordTrans entity.WordTrans) error
+		// SaveUserTrans merges the edit into the previous user translation of the word.
+		SaveUserTrans(ctx context.Context, userTrans entity.UserTrans) error
+		// AddWord returns ErrTranslationNotFound if the shared translation of the word doesn't exist.
 		AddWord(ctx context.Context, collection entity.Collection) error
+		// AddCustomWord adds word which has only the user translation.
+		AddCustomWord(ctx context.Context, collection entity.Collection) error
+		// UpdateLearnInterval resets scheduler state of the card to match the interval.
 		UpdateLearnInterval(ctx context.Context, collection entity.Collection) error
 		DeleteWord(ctx context.Context, collection entity.Collection) error
 		UserWords(ctx context.Context, collection entity.Collection) (*entity.UserWords, error)
+		// Words returns at most query.Limit words after query.After.
+		Words(ctx context.Context, query entity.WordsQuery) ([]entity.ListedWord, error)
+		// Card locks the card until the end of the transaction if ctx is of Transactor.WithinTx.
+		Card(ctx context.Context, collection entity.Collection) (entity.Collection, error)
+		SaveReview(ctx context.Context, collection entity.Collection, reviewLog entity.ReviewLog) error
+		WordHistory(ctx context.Context, collection entity.Collection) ([]entity.ReviewLog, error)
+		MoveWord(ctx context.Context, collection entity.Collection, target string) error
+		CopyWord(ctx context.Context, collection entity.Collection, target string) error
+		// DueWords returns due reviews, most overdue first, and fills the rest of query.Limit with new words.
+		DueWords(ctx context.Context, query entity.DueQuery) (*entity.DueWords, error)
+		// LanguagePair returns empty languages if collection doesn't exist or has no language pair.
+		LanguagePair(ctx context.Context, collection entity.Collection) (srcLang, trgtLang string, err error)
 	}
 
 	TransRepo interface {
-		Translate(ctx context.Context, word string) (entity.WordTrans, error)
+		Translate(ctx context.Context, word, srcLang, trgtLang string) (entity.WordTrans, error)
+	}
+
+	// Transactor runs fn atomically, repository calls made with ctx of fn are part of the transaction.
+	Transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 	}
 )
 
 type Word struct {
-	wordRepo  WordRepo
-	transRepo TransRepo
+	wordRepo        WordRepo
+	transactor      Transactor
+	providers       TransProviders
+	settingsRepo    SettingsRepo
+	schedulers      Schedulers
+	defaultSrcLang  string
+	defaultTrgtLang string
+	// Words of a batch are translated by at most batchWorkers workers within batchBudget.
+	batchWorkers int
+	batchBudget  time.Duration
+	// Concurrent translations of the same word are coalesced into one.
+	transFlight singleflight.Group
 }
 
 func (s *Word) DeleteWord(ctx context.Context, collection entity.Collection) error {
@@ -43,6 +87,30 @@ func (s *Word) DeleteWord(ctx context.Context, collection entity.Collection) err
 	return nil
 }
 
+// MoveWord moves word to the target collection keeping its learning progress and review history.
+func (s *Word) MoveWord(ctx context.Context, collection entity.Collection, target string) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordService - MoveWord")
+	defer span.End()
+
+	err := s.wordRepo.MoveWord(ctx, collection, target)
+	if err != nil {
+		return fmt.Errorf("Word - MoveWord - s.wordRepo.MoveWord: %w", err)
+	}
+	return nil
+}
+
+// CopyWord copies word to the target collection with its learning progress and review history.
+func (s *Word) CopyWord(ctx context.Context, collection entity.Collection, target string) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordService - CopyWord")
+	defer span.End()
+
+	err := s.wordRepo.CopyWord(ctx, collection, target)
+	if err != nil {
+		return fmt.Errorf("Word - CopyWord - s.wordRepo.CopyWord: %w", err)
+	}
+	return nil
+}
+
 func (s *Word) UpdateLearnInterval(ctx context.Context, collection entity.Collection) error {
 	_, span := otel.Tracer(otelName).Start(ctx, "WordService - UpdateLearnInterval")
 	defer span.End()
@@ -54,6 +122,126 @@ func (s *Word) UpdateLearnInterval(ctx context.Context, collection entity.Collec
 	return nil
 }
 
+// Review schedules next repeat of a word in collection according to the grade.
+func (s *Word) Review(ctx context.Context, collection entity.Collection, grade entity.Grade) (entity.Collection, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordService - Review")
+	defer span.End()
+
+	// Card is locked until the review is saved, so concurrent reviews of the card are scheduled one after another.
+	var next entity.Collection
+	err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		card, err := s.wordRepo.Card(ctx, collection)
+		if err != nil {
+			return fmt.Errorf("Word - Review - s.wordRepo.Card: %w", err)
+		}
+
+		settings, err := s.settingsRepo.Settings(ctx, collection.UserID)
+		if err != nil {
+			return fmt.Errorf("Word - Review - s.settingsRepo.Settings: %w", err)
+		}
+
+		reviewedAt := time.Now().UTC()
+		schedulerName, scheduler := s.schedulers.Get(settings.Scheduler)
+		next = scheduler.Schedule(card, grade, reviewedAt)
+
+		reviewLog := entity.ReviewLog{
+			UserID:         card.UserID,
+			Word:           card.Word,
+			CollectionName: card.Name,
+			Grade:          grade,
+			Scheduler:      schedulerName,
+			Elapsed:        reviewedAt.Sub(card.LastRepeat),
+			PrevTimeDiff:   card.TimeDiff,
+			NextTimeDiff:   next.TimeDiff,
+			ReviewedAt:     reviewedAt,
+		}
+		if err := s.wordRepo.SaveReview(ctx, next, reviewLog); err != nil {
+			return fmt.Errorf("Word - Review - s.wordRepo.SaveReview: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("Word - Review - s.transactor.WithinTx: %w", err)
+	}
+	return next, nil
+}
+
+// WordHistory returns all reviews of a word, across all user collections if collection name is empty.
+func (s *Word) WordHistory(ctx context.Context, collection entity.Collection) (*entity.WordHistory, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordService - WordHistory")
+	defer span.End()
+
+	if collection.Name != "" {
+		inCol, err := s.wordRepo.IsWordInCollection(ctx, collection)
+		if err != nil {
+			return nil, fmt.Errorf("Word - WordHistory - s.wordRepo.IsWordInCollection: %w", err)
+		}
+		if !inCol {
+			return nil, entity.ErrWordNotInCollection
+		}
+	}
+
+	reviews, err := s.wordRepo.WordHistory(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("Word - WordHistory - s.wordRepo.WordHistory: %w", err)
+	}
+	return &entity.WordHistory{Reviews: reviews}, nil
+}
+
+// DueWords returns words to review now, new words are spread evenly across the queue.
+func (s *Word) DueWords(ctx context.Context, query entity.DueQuery) (*entity.ReviewQueue, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordService - DueWords")
+	defer span.End()
+
+	settings, err := s.settingsRepo.Settings(ctx, query.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("Word - DueWords - s.settingsRepo.Settings: %w", err)
+	}
+	if query.NewLimit == entity.UserNewLimit {
+		query.NewLimit = settings.DailyNewLimit
+	}
+
+	query.Now = time.Now().UTC()
+	query.DayStart = s.dayStart(query.Now, settings.Timezone)
+
+	dueWords, err := s.wordRepo.DueWords(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Word - DueWords - s.wordRepo.DueWords: %w", err)
+	}
+
+	return &entity.ReviewQueue{
+		Words: s.interleave(dueWords.Reviews, dueWords.New),
+	}, nil
+}
+
+// Start of the day of now in the time zone, UTC is used for an unknown zone.
+func (s *Word) dayStart(now time.Time, timezone string) time.Time {
+	loc := userLocation(timezone)
+	y, m, d := now.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc).UTC()
+}
+
+// Puts a new word after every len(reviews)/len(newWords) reviews.
+func (s *Word) interleave(reviews, newWords []entity.DueWord) []entity.DueWord {
+	words := make([]entity.DueWord, 0, len(reviews)+len(newWords))
+	if len(newWords) == 0 {
+		return append(words, reviews...)
+	}
+
+	every := len(reviews)/len(newWords) + 1
+	r, n := 0, 0
+	for r < len(reviews) || n < len(newWords) {
+		if n < len(newWords) && (len(words)%every == every-1 || r == len(reviews)) {
+			words = append(words, newWords[n])
+			n++
+			continue
+		}
+		words = append(words, reviews[r])
+		r++
+	}
+	return words
+}
+
 func (s *Word) UserWords(ctx context.Context, collection entity.Collection) (*entity.UserWords, error) {
 	_, span := otel.Tracer(otelName).Start(ctx, "WordService - UserWords")
 	defer span.End()
@@ -65,6 +253,70 @@ func (s *Word) UserWords(ctx context.Context, collection entity.Collection) (*en
 	return userWords, nil
 }
 
+// Words returns a page of user words and a cursor of the next page.
+func (s *Word) Words(ctx context.Context, query entity.WordsQuery) (*entity.WordsPage, error) {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordService - Words")
+	defer span.End()
+
+	if query.Cursor != "" {
+		after, err := s.decodeCursor(query.Cursor)
+		if err != nil || after.Sort != query.Sort || after.Desc != query.Desc {
+			return nil, entity.ErrInvalidCursor
+		}
+		query.After = &after
+	}
+	query.Now = time.Now().UTC()
+
+	// One extra word tells if there is a next page.
+	limit := query.Limit
+	query.Limit++
+	words, err := s.wordRepo.Words(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Word - Words - s.wordRepo.Words: %w", err)
+	}
+
+	page := &entity.WordsPage{Words: words}
+	if len(words) > limit {
+		page.Words = words[:limit]
+		last := page.Words[limit-1]
+		page.NextCursor = s.encodeCursor(entity.WordsCursor{
+			Sort:       query.Sort,
+			Desc:       query.Desc,
+			Time:       s.cursorTime(query.Sort, last),
+			Word:       last.Word,
+			Collection: string(last.CollectionName),
+		})
+	}
+	return page, nil
+}
+
+// Value of a time sort key, zero when words are sorted alphabetically.
+func (s *Word) cursorTime(sort entity.WordsSort, word entity.ListedWord) time.Time {
+	switch sort {
+	case entity.WordsSortLastRepeat:
+		return word.LastRepeat
+	case entity.WordsSortWord:
+		return time.Time{}
+	default:
+		return word.AddedAt
+	}
+}
+
+func (s *Word) encodeCursor(cursor entity.WordsCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (s *Word) decodeCursor(cursor string) (entity.WordsCursor, error) {
+	var c entity.WordsCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
 func (s *Word) AddWord(ctx context.Context, collection entity.Collection) error {
 	_, span := otel.Tracer(otelName).Start(ctx, "WordService - AddWord")
 	defer span.End()
@@ -74,38 +326,295 @@ func (s *Word) AddWord(ctx context.Context, collection entity.Collection) error
 		return fmt.Errorf("Word - AddWord - s.wordRepo.IsWordInCollection: %w", err)
 	}
 	if inCol {
-		return nil
+		return entity.ErrWordAlreadyInCollection
+	}
+
+	collection, err = s.languagePair(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("Word - AddWord - s.languagePair: %w", err)
 	}
 
+	err = s.addWord(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("Word - AddWord - s.addWord: %w", err)
+	}
+	return nil
+}
+
+// AddWords adds words to the collection, missing translations are fetched concurrently.
+// Result of each distinct word is returned in order of words, words which
+// don't fit in the time budget of the batch fail with ErrBudgetExceeded.
+func (s *Word) AddWords(ctx context.Context, collection entity.Collection, words []string) ([]entity.AddResult, error) {
+	ctx, span := otel.Tracer(otelName).Start(ctx, "WordService - AddWords")
+	defer span.End()
+
+	collection, err := s.languagePair(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("Word - AddWords - s.languagePair: %w", err)
+	}
+
+	results := make([]entity.AddResult, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			results = append(results, entity.AddResult{Word: word})
+		}
+	}
+
+	if s.batchBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.batchBudget)
+		defer cancel()
+	}
+
+	workers := s.batchWorkers
+	if workers > len(results) {
+		workers = len(results)
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				collection := collection
+				collection.Word = results[i].Word
+				results[i] = s.addBatchWord(ctx, collection)
+			}
+		}()
+	}
+	for i := range results {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+func (s *Word) addBatchWord(ctx context.Context, collection entity.Collection) entity.AddResult {
+	result := entity.AddResult{Word: collection.Word}
+	if ctx.Err() != nil {
+		result.Status, result.Err = entity.AddStatusFailed, entity.ErrBudgetExceeded
+		return result
+	}
+
+	inCol, err := s.wordRepo.IsWordInCollection(ctx, collection)
+	if err == nil && inCol {
+		result.Status = entity.AddStatusAlreadyPresent
+		return result
+	}
+	if err == nil {
+		err = s.addWord(ctx, collection)
+	}
+
+	switch {
+	case err == nil:
+		result.Status = entity.AddStatusAdded
+	case errors.Is(err, entity.ErrWordAlreadyInCollection):
+		result.Status = entity.AddStatusAlreadyPresent
+	case errors.Is(err, entity.ErrWordNotSupported):
+		result.Status = entity.AddStatusNotSupported
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil:
+		result.Status, result.Err = entity.AddStatusFailed, entity.ErrBudgetExceeded
+	default:
+		result.Status, result.Err = entity.AddStatusFailed, fmt.Errorf("Word - addBatchWord: %w", err)
+	}
+	return result
+}
+
+// Adds word with resolved language pair, the word is translated unless its translation is stored.
+// Translation is fetched before the transaction so no connection is held while waiting for translator,
+// the translation and the word are stored atomically.
+func (s *Word) addWord(ctx context.Context, collection entity.Collection) error {
 	transInDB, err := s.wordRepo.IsTransInDB(ctx, collection)
 	if err != nil {
-		return fmt.Errorf("Word - AddWord - s.wordRepo.IsTransInDB: %w", err)
+		return fmt.Errorf("Word - addWord - s.wordRepo.IsTransInDB: %w", err)
 	}
+	var wordTrans *entity.WordTrans
 	if !transInDB {
-		if err := s.addTrans(ctx, collection.Word); err != nil {
-			return fmt.Errorf("Word - AddWord - s.addTrans: %w", err)
+		fetched, err := s.translate(ctx, collection)
+		if err != nil {
+			return fmt.Errorf("Word - addWord - s.translate: %w", err)
+		}
+		wordTrans = &fetched
+	}
+
+	save := func(ctx context.Context) error {
+		if wordTrans != nil {
+			if err := s.wordRepo.AddTranslation(ctx, *wordTrans); err != nil {
+				return fmt.Errorf("Word - addWord - s.wordRepo.AddTranslation: %w", err)
+			}
 		}
+		if err := s.wordRepo.AddWord(ctx, collection); err != nil {
+			return fmt.Errorf("Word - addWord - s.wordRepo.AddWord: %w", err)
+		}
+		return nil
+	}
+	err = s.transactor.WithinTx(ctx, save)
+	// Shared translation was deleted by an admin after the check, the word is translated again.
+	if errors.Is(err, entity.ErrTranslationNotFound) && wordTrans == nil {
+		var fetched entity.WordTrans
+		fetched, err = s.translate(ctx, collection)
+		if err != nil {
+			return fmt.Errorf("Word - addWord - s.translate: %w", err)
+		}
+		wordTrans = &fetched
+		err = s.transactor.WithinTx(ctx, save)
+	}
+	if err != nil {
+		return fmt.Errorf("Word - addWord - s.transactor.WithinTx: %w", err)
+	}
+	return nil
+}
+
+// EditTranslation stores user edit of the word translation,
+// the edit applies to the word in all user collections of the same language pair.
+func (s *Word) EditTranslation(ctx context.Context, collection entity.Collection, userTrans entity.UserTrans) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordService - EditTranslation")
+	defer span.End()
+
+	card, err := s.wordRepo.Card(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("Word - EditTranslation - s.wordRepo.Card: %w", err)
 	}
 
-	err = s.wordRepo.AddWord(ctx, collection)
+	userTrans.UserID, userTrans.Word = card.UserID, card.Word
+	userTrans.SrcLang, userTrans.TrgtLang = card.SrcLang, card.TrgtLang
+	err = s.wordRepo.SaveUserTrans(ctx, userTrans)
 	if err != nil {
-		return fmt.Errorf("Word - AddWord - s.wordRepo.AddWord: %w", err)
+		return fmt.Errorf("Word - EditTranslation - s.wordRepo.SaveUserTrans: %w", err)
 	}
 	return nil
 }
 
-func (s *Word) addTrans(ctx context.Context, word string) error {
-	wordTrans, err := s.transRepo.Translate(ctx, word)
+// AddCustomWord adds a word with the user translation, translation providers aren't used.
+func (s *Word) AddCustomWord(ctx context.Context, collection entity.Collection, userTrans entity.UserTrans) error {
+	_, span := otel.Tracer(otelName).Start(ctx, "WordService - AddCustomWord")
+	defer span.End()
+
+	inCol, err := s.wordRepo.IsWordInCollection(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("Word - AddCustomWord - s.wordRepo.IsWordInCollection: %w", err)
+	}
+	if inCol {
+		return entity.ErrWordAlreadyInCollection
+	}
+
+	collection, err = s.languagePair(ctx, collection)
 	if err != nil {
-		return fmt.Errorf("Word - addTrans - s.googleTranslator.Translate: %w", err)
+		return fmt.Errorf("Word - AddCustomWord - s.languagePair: %w", err)
 	}
 
-	return s.wordRepo.AddTranslation(ctx, wordTrans)
+	userTrans.UserID, userTrans.Word = collection.UserID, collection.Word
+	userTrans.SrcLang, userTrans.TrgtLang = collection.SrcLang, collection.TrgtLang
+	userTrans.Provider = customProvider
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.wordRepo.SaveUserTrans(ctx, userTrans); err != nil {
+			return fmt.Errorf("Word - AddCustomWord - s.wordRepo.SaveUserTrans: %w", err)
+		}
+		if err := s.wordRepo.AddCustomWord(ctx, collection); err != nil {
+			return fmt.Errorf("Word - AddCustomWord - s.wordRepo.AddCustomWord: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Word - AddCustomWord - s.transactor.WithinTx: %w", err)
+	}
+	return nil
 }
 
-func NewWordService(wordRepo WordRepo, translatorRepo TransRepo) *Word {
+// Resolves language pair of the word: requested languages, then the collection pair, then languages
+// of user settings, then the defaults. Words of a collection share its language pair.
+func (s *Word) languagePair(ctx context.Context, collection entity.Collection) (entity.Collection, error) {
+	srcLang, trgtLang, err := s.wordRepo.LanguagePair(ctx, collection)
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("Word - languagePair - s.wordRepo.LanguagePair: %w", err)
+	}
+
+	if (collection.SrcLang != "" && srcLang != "" && collection.SrcLang != srcLang) ||
+		(collection.TrgtLang != "" && trgtLang != "" && collection.TrgtLang != trgtLang) {
+		return entity.Collection{}, entity.ErrLanguagePairMismatch
+	}
+
+	collection.SrcLang = s.firstLang(collection.SrcLang, srcLang)
+	collection.TrgtLang = s.firstLang(collection.TrgtLang, trgtLang)
+	if collection.SrcLang != "" && collection.TrgtLang != "" {
+		return collection, nil
+	}
+
+	settings, err := s.settingsRepo.Settings(ctx, collection.UserID)
+	if err != nil {
+		return entity.Collection{}, fmt.Errorf("Word - languagePair - s.settingsRepo.Settings: %w", err)
+	}
+	collection.SrcLang = s.firstLang(collection.SrcLang, settings.SrcLang, s.defaultSrcLang)
+	collection.TrgtLang = s.firstLang(collection.TrgtLang, settings.TrgtLang, s.defaultTrgtLang)
+	return collection, nil
+}
+
+func (s *Word) firstLang(langs ...string) string {
+	for _, lang := range langs {
+		if lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// Translates the word, concurrent calls for the same word and language pair share one upstream request.
+// Shared call runs with context of the first caller, so when it is canceled the other callers
+// translate the word themselves. Returned translation is shared, it must not be modified.
+func (s *Word) translate(ctx context.Context, collection entity.Collection) (entity.WordTrans, error) {
+	key := collection.Word + "\x00" + collection.SrcLang + "\x00" + collection.TrgtLang
+	wordTrans, err, shared := s.transFlight.Do(key, func() (interface{}, error) {
+		return s.fetchTrans(ctx, collection)
+	})
+	if shared && ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		return s.fetchTrans(ctx, collection)
+	}
+	if err != nil {
+		return entity.WordTrans{}, err
+	}
+	return wordTrans.(entity.WordTrans), nil
+}
+
+func (s *Word) fetchTrans(ctx context.Context, collection entity.Collection) (entity.WordTrans, error) {
+	wordTrans, err := s.providers.Translate(ctx, collection.Word, collection.SrcLang, collection.TrgtLang)
+	if err != nil {
+		return entity.WordTrans{}, fmt.Errorf("Word - fetchTrans - s.providers.Translate: %w", err)
+	}
+
+	// Translation is cached under the requested word and language pair,
+	// translator may report a normalized word or a detected language.
+	wordTrans.Word, wordTrans.SrcLang, wordTrans.TrgtLang = collection.Word, collection.SrcLang, collection.TrgtLang
+	return wordTrans, nil
+}
+
+func NewWordService(
+	wordRepo WordRepo,
+	transactor Transactor,
+	providers TransProviders,
+	settingsRepo SettingsRepo,
+	schedulers Schedulers,
+	defaultSrcLang, defaultTrgtLang string,
+	batchWorkers int,
+	batchBudget time.Duration,
+) *Word {
+	// Bulk add would block without a worker to take the words.
+	if batchWorkers < 1 {
+		batchWorkers = 1
+	}
 	return &Word{
-		wordRepo:  wordRepo,
-		transRepo: translatorRepo,
+		wordRepo:        wordRepo,
+		transactor:      transactor,
+		providers:       providers,
+		settingsRepo:    settingsRepo,
+		schedulers:      schedulers,
+		defaultSrcLang:  defaultSrcLang,
+		defaultTrgtLang: defaultTrgtLang,
+		batchWorkers:    batchWorkers,
+		batchBudget:     batchBudget,
 	}
 }
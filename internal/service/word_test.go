@@ -2,31 +2,66 @@ package service
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/entity"
 	"github.com/Kin-dza-dzaa/flash_cards_api/internal/service/repomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/mock"
 )
 
-func setupWordService(t *testing.T) (*repomock.WordRepo, *repomock.TransRepo) {
+func setupWordService(t *testing.T) (*repomock.WordRepo, *repomock.TransRepo, *repomock.SettingsRepo) {
 	t.Helper()
 	db := repomock.NewWordRepo(t)
 	tr := repomock.NewTransRepo(t)
+	st := repomock.NewSettingsRepo(t)
 
-	return db, tr
+	return db, tr, st
+}
+
+type txCtxKey struct{}
+
+// Marks ctx of fn so calls made in the transaction can be matched.
+type txStub struct{}
+
+func (txStub) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txCtxKey{}, true))
+}
+
+func inTx(ctx context.Context) bool {
+	return ctx.Value(txCtxKey{}) != nil
+}
+
+// User without preferred languages, language pairs are resolved to the defaults of the service.
+func withoutUserLangs(stMock *repomock.SettingsRepo) {
+	stMock.On("Settings", mock.Anything, mock.Anything).Maybe().Return(entity.DefaultSettings(""), nil)
+}
+
+func setupSchedulers() Schedulers {
+	return Schedulers{
+		entity.SchedulerSM2:  NewSM2(),
+		entity.SchedulerFSRS: NewFSRS(0.9, 36500),
+	}
 }
 
 func Test_AddWord(t *testing.T) {
 	type args struct {
 		coll entity.Collection
 	}
+	// Collection with resolved language pair.
+	withPair := func(coll entity.Collection, srcLang, trgtLang string) entity.Collection {
+		coll.SrcLang, coll.TrgtLang = srcLang, trgtLang
+		return coll
+	}
 	tests := []struct {
 		name      string
 		args      args
 		setupMock func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args)
-		wantErr   bool
+		wantErr   error
 	}{
 		{
 			name: "Add new word",
@@ -37,13 +72,59 @@ func Test_AddWord(t *testing.T) {
 				},
 			},
 			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				coll := withPair(args.coll, "en", "ru")
 				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(false, nil)
-				dbMock.On("IsTransInDB", mock.Anything, args.coll).Once().Return(false, nil)
-				trMock.On("Translate", mock.Anything, args.coll.Word).Once().
-					Return(entity.WordTrans{}, nil)
-				dbMock.On("AddTranslation", mock.Anything, mock.Anything).Once().
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("", "", nil)
+				dbMock.On("IsTransInDB", mock.Anything, coll).Once().Return(false, nil)
+				trMock.On("Translate", mock.Anything, args.coll.Word, "en", "ru").Once().
+					Return(entity.WordTrans{Word: "some_words", SrcLang: "auto", TrgtLang: "ru", MainTranslation: "какие-то слова"}, nil)
+				dbMock.On("AddTranslation", mock.MatchedBy(inTx), entity.WordTrans{
+					Word: "Some_words", SrcLang: "en", TrgtLang: "ru", MainTranslation: "какие-то слова", Provider: "google",
+				}).Once().
 					Return(nil)
-				dbMock.On("AddWord", mock.Anything, args.coll).Once().Return(nil)
+				dbMock.On("AddWord", mock.MatchedBy(inTx), coll).Once().Return(nil)
+			},
+		},
+		{
+			name: "Word added concurrently",
+			args: args{
+				coll: entity.Collection{
+					Name:   "some_name",
+					UserID: "12345",
+					Word:   "Some_words",
+				},
+			},
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				coll := withPair(args.coll, "en", "ru")
+				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("", "", nil)
+				dbMock.On("IsTransInDB", mock.Anything, coll).Once().Return(true, nil)
+				dbMock.On("AddWord", mock.MatchedBy(inTx), coll).Once().Return(entity.ErrWordAlreadyInCollection)
+			},
+			wantErr: entity.ErrWordAlreadyInCollection,
+		},
+		{
+			name: "Translation deleted after the check",
+			args: args{
+				coll: entity.Collection{
+					Name:   "some_name",
+					UserID: "12345",
+					Word:   "Some_words",
+				},
+			},
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				coll := withPair(args.coll, "en", "ru")
+				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("", "", nil)
+				dbMock.On("IsTransInDB", mock.Anything, coll).Once().Return(true, nil)
+				dbMock.On("AddWord", mock.MatchedBy(inTx), coll).Once().Return(entity.ErrTranslationNotFound)
+				trMock.On("Translate", mock.Anything, args.coll.Word, "en", "ru").Once().
+					Return(entity.WordTrans{Word: "Some_words", MainTranslation: "какие-то слова"}, nil)
+				dbMock.On("AddTranslation", mock.MatchedBy(inTx), entity.WordTrans{
+					Word: "Some_words", SrcLang: "en", TrgtLang: "ru", MainTranslation: "какие-то слова", Provider: "google",
+				}).Once().
+					Return(nil)
+				dbMock.On("AddWord", mock.MatchedBy(inTx), coll).Once().Return(nil)
 			},
 		},
 		{
@@ -58,6 +139,7 @@ func Test_AddWord(t *testing.T) {
 			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
 				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(true, nil)
 			},
+			wantErr: entity.ErrWordAlreadyInCollection,
 		},
 		{
 			name: "Add word that in DB",
@@ -69,31 +151,321 @@ func Test_AddWord(t *testing.T) {
 				},
 			},
 			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				coll := withPair(args.coll, "en", "ru")
 				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("", "", nil)
+				dbMock.On("IsTransInDB", mock.Anything, coll).Once().Return(true, nil)
+				dbMock.On("AddWord", mock.Anything, coll).Once().Return(nil)
+			},
+		},
+		{
+			name: "Add word with collection language pair",
+			args: args{
+				coll: entity.Collection{
+					Name:   "deutsch",
+					UserID: "12345",
+					Word:   "Geschenk",
+				},
+			},
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				coll := withPair(args.coll, "de", "es")
+				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("de", "es", nil)
+				dbMock.On("IsTransInDB", mock.Anything, coll).Once().Return(false, nil)
+				trMock.On("Translate", mock.Anything, "Geschenk", "de", "es").Once().
+					Return(entity.WordTrans{Word: "Geschenk", SrcLang: "de", TrgtLang: "es", MainTranslation: "regalo"}, nil)
+				dbMock.On("AddTranslation", mock.Anything, entity.WordTrans{
+					Word: "Geschenk", SrcLang: "de", TrgtLang: "es", MainTranslation: "regalo", Provider: "google",
+				}).Once().
+					Return(nil)
+				dbMock.On("AddWord", mock.Anything, coll).Once().Return(nil)
+			},
+		},
+		{
+			name: "Add word with requested language pair",
+			args: args{
+				coll: entity.Collection{
+					Name:     "spanish",
+					UserID:   "12345",
+					Word:     "regalo",
+					SrcLang:  "es",
+					TrgtLang: "en",
+				},
+			},
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("", "", nil)
 				dbMock.On("IsTransInDB", mock.Anything, args.coll).Once().Return(true, nil)
 				dbMock.On("AddWord", mock.Anything, args.coll).Once().Return(nil)
 			},
 		},
+		{
+			name: "Add word with language pair of another collection",
+			args: args{
+				coll: entity.Collection{
+					Name:     "deutsch",
+					UserID:   "12345",
+					Word:     "regalo",
+					SrcLang:  "es",
+					TrgtLang: "es",
+				},
+			},
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("de", "es", nil)
+			},
+			wantErr: entity.ErrLanguagePairMismatch,
+		},
+		{
+			name: "Add unsupported word",
+			args: args{
+				coll: entity.Collection{
+					Name:   "some_name",
+					UserID: "12345",
+					Word:   "Some_words",
+				},
+			},
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				coll := withPair(args.coll, "en", "ru")
+				dbMock.On("IsWordInCollection", mock.Anything, args.coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("", "", nil)
+				dbMock.On("IsTransInDB", mock.Anything, coll).Once().Return(false, nil)
+				trMock.On("Translate", mock.Anything, args.coll.Word, "en", "ru").Once().
+					Return(entity.WordTrans{}, entity.ErrWordNotSupported)
+			},
+			wantErr: entity.ErrWordNotSupported,
+		},
 	}
 
 	for _, tt := range tests {
 		ctx := context.Background()
-		dbMock, trMock := setupWordService(t)
-		wordService := NewWordService(dbMock, trMock)
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
 		tt.setupMock(dbMock, trMock, tt.args)
+		withoutUserLangs(stMock)
 
 		t.Run(tt.name, func(t *testing.T) {
 			err := wordService.AddWord(ctx, tt.args.coll)
-			if tt.wantErr && err == nil {
-				t.Fatalf("want err but got: %v", err)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
 			}
-			if !tt.wantErr && err != nil {
-				t.Fatalf("want nil but got: %v", err)
+		})
+	}
+}
+
+func Test_AddWords(t *testing.T) {
+	type args struct {
+		coll  entity.Collection
+		words []string
+	}
+	errSome := errors.New("some error")
+	// Collection of the word with resolved language pair.
+	withWord := func(coll entity.Collection, word string) entity.Collection {
+		coll.Word, coll.SrcLang, coll.TrgtLang = word, "en", "ru"
+		return coll
+	}
+	tests := []struct {
+		name        string
+		args        args
+		budget      time.Duration
+		setupMock   func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args)
+		wantResults []entity.AddResult
+		wantErr     error
+	}{
+		{
+			name: "Result of each distinct word",
+			args: args{
+				coll:  entity.Collection{Name: "some_name", UserID: "12345"},
+				words: []string{"lead", "run", "lead", "qwzx", "gift", "break"},
+			},
+			budget: time.Second,
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("", "", nil)
+
+				lead := withWord(args.coll, "lead")
+				dbMock.On("IsWordInCollection", mock.Anything, lead).Once().Return(false, nil)
+				dbMock.On("IsTransInDB", mock.Anything, lead).Once().Return(true, nil)
+				dbMock.On("AddWord", mock.Anything, lead).Once().Return(nil)
+
+				run := withWord(args.coll, "run")
+				dbMock.On("IsWordInCollection", mock.Anything, run).Once().Return(true, nil)
+
+				qwzx := withWord(args.coll, "qwzx")
+				dbMock.On("IsWordInCollection", mock.Anything, qwzx).Once().Return(false, nil)
+				dbMock.On("IsTransInDB", mock.Anything, qwzx).Once().Return(false, nil)
+				trMock.On("Translate", mock.Anything, "qwzx", "en", "ru").Once().Return(entity.WordTrans{}, entity.ErrWordNotSupported)
+
+				gift := withWord(args.coll, "gift")
+				dbMock.On("IsWordInCollection", mock.Anything, gift).Once().Return(false, nil)
+				dbMock.On("IsTransInDB", mock.Anything, gift).Once().Return(false, nil)
+				trMock.On("Translate", mock.Anything, "gift", "en", "ru").Once().
+					Return(entity.WordTrans{Word: "gift", MainTranslation: "подарок"}, nil)
+				dbMock.On("AddTranslation", mock.Anything, entity.WordTrans{
+					Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "подарок", Provider: "google",
+				}).Once().Return(nil)
+				dbMock.On("AddWord", mock.Anything, gift).Once().Return(nil)
+
+				dbMock.On("IsWordInCollection", mock.Anything, withWord(args.coll, "break")).Once().Return(false, errSome)
+			},
+			wantResults: []entity.AddResult{
+				{Word: "lead", Status: entity.AddStatusAdded},
+				{Word: "run", Status: entity.AddStatusAlreadyPresent},
+				{Word: "qwzx", Status: entity.AddStatusNotSupported},
+				{Word: "gift", Status: entity.AddStatusAdded},
+				{Word: "break", Status: entity.AddStatusFailed, Err: errSome},
+			},
+		},
+		{
+			name: "Translation out of budget",
+			args: args{
+				coll:  entity.Collection{Name: "some_name", UserID: "12345"},
+				words: []string{"lead"},
+			},
+			budget: 10 * time.Millisecond,
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				lead := withWord(args.coll, "lead")
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("", "", nil)
+				dbMock.On("IsWordInCollection", mock.Anything, lead).Once().Return(false, nil)
+				dbMock.On("IsTransInDB", mock.Anything, lead).Once().Return(false, nil)
+				trMock.On("Translate", mock.Anything, "lead", "en", "ru").Once().
+					Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+					Return(entity.WordTrans{}, context.DeadlineExceeded)
+			},
+			wantResults: []entity.AddResult{
+				{Word: "lead", Status: entity.AddStatusFailed, Err: entity.ErrBudgetExceeded},
+			},
+		},
+		{
+			name: "Language pair mismatch",
+			args: args{
+				coll:  entity.Collection{Name: "some_name", UserID: "12345", SrcLang: "en"},
+				words: []string{"lead"},
+			},
+			budget: time.Second,
+			setupMock: func(dbMock *repomock.WordRepo, trMock *repomock.TransRepo, args args) {
+				dbMock.On("LanguagePair", mock.Anything, args.coll).Once().Return("de", "es", nil)
+			},
+			wantErr: entity.ErrLanguagePairMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, tt.budget)
+		tt.setupMock(dbMock, trMock, tt.args)
+		withoutUserLangs(stMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			gotResults, err := wordService.AddWords(ctx, tt.args.coll, tt.args.words)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.wantResults, gotResults, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("want results: %v but got: %v diff: %v", tt.wantResults, gotResults, diff)
 			}
 		})
 	}
 }
 
+func Test_AddWordsWithoutWorkers(t *testing.T) {
+	dbMock, trMock, stMock := setupWordService(t)
+	wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 0, time.Second)
+	withoutUserLangs(stMock)
+
+	coll := entity.Collection{Name: "some_name", UserID: "12345"}
+	lead := coll
+	lead.Word, lead.SrcLang, lead.TrgtLang = "lead", "en", "ru"
+	dbMock.On("LanguagePair", mock.Anything, coll).Once().Return("", "", nil)
+	dbMock.On("IsWordInCollection", mock.Anything, lead).Once().Return(false, nil)
+	dbMock.On("IsTransInDB", mock.Anything, lead).Once().Return(true, nil)
+	dbMock.On("AddWord", mock.MatchedBy(inTx), lead).Once().Return(nil)
+
+	done := make(chan struct{})
+	var gotResults []entity.AddResult
+	var err error
+	go func() {
+		defer close(done)
+		gotResults, err = wordService.AddWords(context.Background(), coll, []string{"lead"})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("AddWords didn't return without batch workers")
+	}
+	if err != nil {
+		t.Fatalf("want no err but got: %v", err)
+	}
+	want := []entity.AddResult{{Word: "lead", Status: entity.AddStatusAdded}}
+	if diff := cmp.Diff(want, gotResults, cmpopts.EquateErrors()); diff != "" {
+		t.Fatalf("want results: %v but got: %v diff: %v", want, gotResults, diff)
+	}
+}
+
+func Test_AddWordUserLanguages(t *testing.T) {
+	dbMock, trMock, stMock := setupWordService(t)
+	wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+
+	coll := entity.Collection{Name: "deutsch", UserID: "12345", Word: "Geschenk"}
+	withPair := coll
+	withPair.SrcLang, withPair.TrgtLang = "de", "ru"
+	dbMock.On("IsWordInCollection", mock.Anything, coll).Once().Return(false, nil)
+	dbMock.On("LanguagePair", mock.Anything, coll).Once().Return("", "", nil)
+	// Only source language is preferred, target one is the default.
+	stMock.On("Settings", mock.Anything, "12345").Once().Return(entity.Settings{UserID: "12345", SrcLang: "de"}, nil)
+	dbMock.On("IsTransInDB", mock.Anything, withPair).Once().Return(true, nil)
+	dbMock.On("AddWord", mock.MatchedBy(inTx), withPair).Once().Return(nil)
+
+	if err := wordService.AddWord(context.Background(), coll); err != nil {
+		t.Fatalf("want no err but got: %v", err)
+	}
+}
+
+func Test_AddWordConcurrent(t *testing.T) {
+	dbMock, trMock, stMock := setupWordService(t)
+	wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+
+	colls := []entity.Collection{
+		{Name: "some_name", UserID: "12345", Word: "lead"},
+		{Name: "other_name", UserID: "54321", Word: "lead"},
+	}
+	release := make(chan struct{})
+	var checked sync.WaitGroup
+	checked.Add(len(colls))
+	for _, coll := range colls {
+		withPair := coll
+		withPair.SrcLang, withPair.TrgtLang = "en", "ru"
+		dbMock.On("IsWordInCollection", mock.Anything, coll).Once().Return(false, nil)
+		dbMock.On("LanguagePair", mock.Anything, coll).Once().Return("", "", nil)
+		dbMock.On("IsTransInDB", mock.Anything, withPair).Once().Run(func(mock.Arguments) { checked.Done() }).Return(false, nil)
+		dbMock.On("AddWord", mock.Anything, withPair).Once().Return(nil)
+	}
+	withoutUserLangs(stMock)
+	// Only one upstream request is made, it is in flight until both adds wait for it.
+	trMock.On("Translate", mock.Anything, "lead", "en", "ru").Once().
+		Run(func(args mock.Arguments) { <-release }).
+		Return(entity.WordTrans{Word: "lead", MainTranslation: "вести"}, nil)
+	dbMock.On("AddTranslation", mock.Anything, entity.WordTrans{
+		Word: "lead", SrcLang: "en", TrgtLang: "ru", MainTranslation: "вести", Provider: "google",
+	}).Twice().Return(nil)
+
+	errs := make(chan error, len(colls))
+	for _, coll := range colls {
+		go func(coll entity.Collection) {
+			errs <- wordService.AddWord(context.Background(), coll)
+		}(coll)
+	}
+	checked.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for range colls {
+		if err := <-errs; err != nil {
+			t.Fatalf("want no err but got: %v", err)
+		}
+	}
+}
+
 func Test_UserWords(t *testing.T) {
 	type args struct {
 		coll entity.Collection
@@ -125,8 +497,8 @@ func Test_UserWords(t *testing.T) {
 
 	for _, tt := range tests {
 		ctx := context.Background()
-		dbMock, trMock := setupWordService(t)
-		wordService := NewWordService(dbMock, trMock)
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
 		tt.setupMock(dbMock, trMock, tt.args)
 
 		t.Run(tt.name, func(t *testing.T) {
@@ -173,8 +545,8 @@ func Test_UpdateLearnInterval(t *testing.T) {
 
 	for _, tt := range tests {
 		ctx := context.Background()
-		dbMock, trMock := setupWordService(t)
-		wordService := NewWordService(dbMock, trMock)
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
 		tt.setupMock(dbMock, trMock, tt.args)
 
 		t.Run(tt.name, func(t *testing.T) {
@@ -189,6 +561,83 @@ func Test_UpdateLearnInterval(t *testing.T) {
 	}
 }
 
+func Test_Words(t *testing.T) {
+	listed := func(word string) entity.ListedWord {
+		return entity.ListedWord{
+			WordData:       entity.WordData{WordTrans: entity.WordTrans{Word: word}},
+			CollectionName: "coll",
+			AddedAt:        time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC),
+		}
+	}
+	nextCursor := (&Word{}).encodeCursor(entity.WordsCursor{
+		Sort:       entity.WordsSortAdded,
+		Time:       time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC),
+		Word:       "b",
+		Collection: "coll",
+	})
+
+	tests := []struct {
+		name      string
+		query     entity.WordsQuery
+		setupMock func(dbMock *repomock.WordRepo)
+		want      *entity.WordsPage
+		wantErr   error
+	}{
+		{
+			name:  "First page",
+			query: entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortAdded, Limit: 2},
+			setupMock: func(dbMock *repomock.WordRepo) {
+				dbMock.On("Words", mock.Anything, mock.MatchedBy(func(q entity.WordsQuery) bool {
+					return q.Limit == 3 && q.After == nil
+				})).Once().Return([]entity.ListedWord{listed("a"), listed("b"), listed("c")}, nil)
+			},
+			want: &entity.WordsPage{
+				Words:      []entity.ListedWord{listed("a"), listed("b")},
+				NextCursor: nextCursor,
+			},
+		},
+		{
+			name:  "Last page",
+			query: entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortAdded, Limit: 2, Cursor: nextCursor},
+			setupMock: func(dbMock *repomock.WordRepo) {
+				dbMock.On("Words", mock.Anything, mock.MatchedBy(func(q entity.WordsQuery) bool {
+					return q.After != nil && q.After.Word == "b" && q.After.Collection == "coll"
+				})).Once().Return([]entity.ListedWord{listed("c")}, nil)
+			},
+			want: &entity.WordsPage{Words: []entity.ListedWord{listed("c")}},
+		},
+		{
+			name:      "Malformed cursor",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortAdded, Limit: 2, Cursor: "abc"},
+			setupMock: func(dbMock *repomock.WordRepo) {},
+			wantErr:   entity.ErrInvalidCursor,
+		},
+		{
+			name:      "Cursor of another sort",
+			query:     entity.WordsQuery{UserID: "12345", Sort: entity.WordsSortWord, Limit: 2, Cursor: nextCursor},
+			setupMock: func(dbMock *repomock.WordRepo) {},
+			wantErr:   entity.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+		tt.setupMock(dbMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wordService.Words(ctx, tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("page must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
 func Test_DeleteWord(t *testing.T) {
 	type args struct {
 		coll entity.Collection
@@ -218,8 +667,8 @@ func Test_DeleteWord(t *testing.T) {
 
 	for _, tt := range tests {
 		ctx := context.Background()
-		dbMock, trMock := setupWordService(t)
-		wordService := NewWordService(dbMock, trMock)
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
 		tt.setupMock(dbMock, trMock, tt.args)
 
 		t.Run(tt.name, func(t *testing.T) {
@@ -233,3 +682,434 @@ func Test_DeleteWord(t *testing.T) {
 		})
 	}
 }
+
+func Test_MoveWord(t *testing.T) {
+	type args struct {
+		coll   entity.Collection
+		target string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		setupMock func(dbMock *repomock.WordRepo, args args)
+		wantErr   error
+	}{
+		{
+			name: "Move word",
+			args: args{
+				coll:   entity.Collection{Word: "some_word", UserID: "12345", Name: "some_coll"},
+				target: "other_coll",
+			},
+			setupMock: func(dbMock *repomock.WordRepo, args args) {
+				dbMock.On("MoveWord", mock.Anything, args.coll, args.target).Once().Return(nil)
+			},
+		},
+		{
+			name: "Move word to collection with the word",
+			args: args{
+				coll:   entity.Collection{Word: "some_word", UserID: "12345", Name: "some_coll"},
+				target: "other_coll",
+			},
+			setupMock: func(dbMock *repomock.WordRepo, args args) {
+				dbMock.On("MoveWord", mock.Anything, args.coll, args.target).Once().
+					Return(entity.ErrWordAlreadyInCollection)
+			},
+			wantErr: entity.ErrWordAlreadyInCollection,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+		tt.setupMock(dbMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := wordService.MoveWord(ctx, tt.args.coll, tt.args.target)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_Review(t *testing.T) {
+	type args struct {
+		coll  entity.Collection
+		grade entity.Grade
+	}
+	tests := []struct {
+		name      string
+		args      args
+		setupMock func(dbMock *repomock.WordRepo, stMock *repomock.SettingsRepo, args args)
+		wantErr   error
+	}{
+		{
+			name: "Review word",
+			args: args{
+				coll: entity.Collection{
+					Word:   "some_word",
+					UserID: "12345",
+					Name:   "some_coll",
+				},
+				grade: entity.GradeGood,
+			},
+			setupMock: func(dbMock *repomock.WordRepo, stMock *repomock.SettingsRepo, args args) {
+				dbMock.On("Card", mock.Anything, args.coll).Once().Return(args.coll, nil)
+				stMock.On("Settings", mock.Anything, args.coll.UserID).Once().Return(entity.Settings{}, nil)
+				dbMock.On("SaveReview", mock.Anything, mock.MatchedBy(func(c entity.Collection) bool {
+					return c.Repetitions == 1 && c.TimeDiff == day && c.Stability == 0
+				}), mock.MatchedBy(func(l entity.ReviewLog) bool {
+					return l.Scheduler == entity.SchedulerSM2 && l.Grade == entity.GradeGood &&
+						l.NextTimeDiff == day && l.Word == args.coll.Word
+				})).Once().Return(nil)
+			},
+		},
+		{
+			name: "Review word with FSRS",
+			args: args{
+				coll: entity.Collection{
+					Word:   "some_word",
+					UserID: "12345",
+					Name:   "some_coll",
+				},
+				grade: entity.GradeGood,
+			},
+			setupMock: func(dbMock *repomock.WordRepo, stMock *repomock.SettingsRepo, args args) {
+				dbMock.On("Card", mock.Anything, args.coll).Once().Return(args.coll, nil)
+				stMock.On("Settings", mock.Anything, args.coll.UserID).Once().
+					Return(entity.Settings{Scheduler: entity.SchedulerFSRS}, nil)
+				dbMock.On("SaveReview", mock.Anything, mock.MatchedBy(func(c entity.Collection) bool {
+					return c.Repetitions == 1 && c.Stability > 0
+				}), mock.MatchedBy(func(l entity.ReviewLog) bool {
+					return l.Scheduler == entity.SchedulerFSRS && l.CollectionName == args.coll.Name
+				})).Once().Return(nil)
+			},
+		},
+		{
+			name: "Review word not in collection",
+			args: args{
+				coll: entity.Collection{
+					Word:   "some_word",
+					UserID: "12345",
+					Name:   "some_coll",
+				},
+				grade: entity.GradeGood,
+			},
+			setupMock: func(dbMock *repomock.WordRepo, stMock *repomock.SettingsRepo, args args) {
+				dbMock.On("Card", mock.Anything, args.coll).Once().
+					Return(entity.Collection{}, entity.ErrWordNotInCollection)
+			},
+			wantErr: entity.ErrWordNotInCollection,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+		tt.setupMock(dbMock, stMock, tt.args)
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := wordService.Review(ctx, tt.args.coll, tt.args.grade)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_WordHistory(t *testing.T) {
+	tests := []struct {
+		name      string
+		coll      entity.Collection
+		setupMock func(dbMock *repomock.WordRepo, coll entity.Collection)
+		want      *entity.WordHistory
+		wantErr   error
+	}{
+		{
+			name: "History of word in collection",
+			coll: entity.Collection{Word: "some_word", UserID: "12345", Name: "some_coll"},
+			setupMock: func(dbMock *repomock.WordRepo, coll entity.Collection) {
+				dbMock.On("IsWordInCollection", mock.Anything, coll).Once().Return(true, nil)
+				dbMock.On("WordHistory", mock.Anything, coll).Once().
+					Return([]entity.ReviewLog{{Word: coll.Word, Grade: entity.GradeGood}}, nil)
+			},
+			want: &entity.WordHistory{Reviews: []entity.ReviewLog{{Word: "some_word", Grade: entity.GradeGood}}},
+		},
+		{
+			name: "History of word in all collections",
+			coll: entity.Collection{Word: "some_word", UserID: "12345"},
+			setupMock: func(dbMock *repomock.WordRepo, coll entity.Collection) {
+				dbMock.On("WordHistory", mock.Anything, coll).Once().Return([]entity.ReviewLog{}, nil)
+			},
+			want: &entity.WordHistory{Reviews: []entity.ReviewLog{}},
+		},
+		{
+			name: "History of word not in collection",
+			coll: entity.Collection{Word: "some_word", UserID: "12345", Name: "some_coll"},
+			setupMock: func(dbMock *repomock.WordRepo, coll entity.Collection) {
+				dbMock.On("IsWordInCollection", mock.Anything, coll).Once().Return(false, nil)
+			},
+			wantErr: entity.ErrWordNotInCollection,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+		tt.setupMock(dbMock, tt.coll)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wordService.WordHistory(ctx, tt.coll)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("history must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
+func Test_DueWords(t *testing.T) {
+	review := func(word string) entity.DueWord {
+		return entity.DueWord{WordData: entity.WordData{WordTrans: entity.WordTrans{Word: word}}}
+	}
+	newWord := func(word string) entity.DueWord {
+		w := review(word)
+		w.New = true
+		return w
+	}
+
+	tests := []struct {
+		name     string
+		query    entity.DueQuery
+		settings entity.Settings
+		// NewLimit of the repo query.
+		wantNewLimit int
+		dueWords     *entity.DueWords
+		repoErr      error
+		wantQueue    *entity.ReviewQueue
+		wantErr      bool
+	}{
+		{
+			name:     "Only reviews",
+			query:    entity.DueQuery{UserID: "12345", Limit: 10},
+			dueWords: &entity.DueWords{Reviews: []entity.DueWord{review("a"), review("b")}},
+			wantQueue: &entity.ReviewQueue{
+				Words: []entity.DueWord{review("a"), review("b")},
+			},
+		},
+		{
+			name:         "New words are spread",
+			query:        entity.DueQuery{UserID: "12345", Limit: 10, NewLimit: 2},
+			wantNewLimit: 2,
+			dueWords: &entity.DueWords{
+				Reviews: []entity.DueWord{review("a"), review("b"), review("c"), review("d")},
+				New:     []entity.DueWord{newWord("x"), newWord("y")},
+			},
+			wantQueue: &entity.ReviewQueue{
+				Words: []entity.DueWord{
+					review("a"), review("b"), newWord("x"), review("c"), review("d"), newWord("y"),
+				},
+			},
+		},
+		{
+			name:         "Only new words",
+			query:        entity.DueQuery{UserID: "12345", Limit: 10, NewLimit: 2},
+			wantNewLimit: 2,
+			dueWords: &entity.DueWords{
+				New: []entity.DueWord{newWord("x"), newWord("y")},
+			},
+			wantQueue: &entity.ReviewQueue{
+				Words: []entity.DueWord{newWord("x"), newWord("y")},
+			},
+		},
+		{
+			name:         "Daily limit of user settings",
+			query:        entity.DueQuery{UserID: "12345", Limit: 10, NewLimit: entity.UserNewLimit},
+			settings:     entity.Settings{UserID: "12345", DailyNewLimit: 7, Timezone: "Europe/Berlin"},
+			wantNewLimit: 7,
+			dueWords: &entity.DueWords{
+				New: []entity.DueWord{newWord("x")},
+			},
+			wantQueue: &entity.ReviewQueue{
+				Words: []entity.DueWord{newWord("x")},
+			},
+		},
+		{
+			name:    "Repo error",
+			query:   entity.DueQuery{UserID: "12345", Limit: 10},
+			repoErr: errors.New("some repo error"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+		stMock.On("Settings", mock.Anything, tt.query.UserID).Once().Return(tt.settings, nil)
+		dbMock.On("DueWords", mock.Anything, mock.MatchedBy(func(q entity.DueQuery) bool {
+			return q.UserID == tt.query.UserID && q.NewLimit == tt.wantNewLimit && !q.Now.IsZero() && !q.DayStart.After(q.Now)
+		})).Once().Return(tt.dueWords, tt.repoErr)
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wordService.DueWords(ctx, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.wantQueue, got); diff != "" {
+				t.Fatalf("queue must be equal diff: %v", diff)
+			}
+		})
+	}
+}
+
+func Test_dayStart(t *testing.T) {
+	now := time.Date(2023, 3, 1, 23, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		timezone string
+		want     time.Time
+	}{
+		{
+			name:     "UTC",
+			timezone: "UTC",
+			want:     time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Next day in the zone",
+			timezone: "Europe/Berlin",
+			want:     time.Date(2023, 3, 1, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Previous day in the zone",
+			timezone: "America/New_York",
+			want:     time.Date(2023, 3, 1, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Unknown zone",
+			timezone: "Mars/Olympus",
+			want:     time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	wordService := NewWordService(nil, txStub{}, nil, nil, setupSchedulers(), "en", "ru", 2, time.Second)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wordService.dayStart(now, tt.timezone); !got.Equal(tt.want) {
+				t.Fatalf("want: %v but got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_EditTranslation(t *testing.T) {
+	coll := entity.Collection{Word: "gift", UserID: "12345", Name: "some_coll"}
+	edit := entity.UserTrans{MainTranslation: "дар"}
+	tests := []struct {
+		name      string
+		setupMock func(dbMock *repomock.WordRepo)
+		wantErr   error
+	}{
+		{
+			name: "Edit translation",
+			setupMock: func(dbMock *repomock.WordRepo) {
+				card := coll
+				card.SrcLang, card.TrgtLang = "en", "ru"
+				dbMock.On("Card", mock.Anything, coll).Once().Return(card, nil)
+				dbMock.On("SaveUserTrans", mock.Anything, entity.UserTrans{
+					UserID: "12345", Word: "gift", SrcLang: "en", TrgtLang: "ru", MainTranslation: "дар",
+				}).Once().Return(nil)
+			},
+		},
+		{
+			name: "Edit translation of word not in collection",
+			setupMock: func(dbMock *repomock.WordRepo) {
+				dbMock.On("Card", mock.Anything, coll).Once().Return(entity.Collection{}, entity.ErrWordNotInCollection)
+			},
+			wantErr: entity.ErrWordNotInCollection,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+		tt.setupMock(dbMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := wordService.EditTranslation(ctx, coll, edit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_AddCustomWord(t *testing.T) {
+	coll := entity.Collection{Word: "break a leg", UserID: "12345", Name: "idioms"}
+	custom := entity.UserTrans{MainTranslation: "ни пуха ни пера"}
+	tests := []struct {
+		name      string
+		setupMock func(dbMock *repomock.WordRepo)
+		wantErr   error
+	}{
+		{
+			name: "Add custom word",
+			setupMock: func(dbMock *repomock.WordRepo) {
+				withPair := coll
+				withPair.SrcLang, withPair.TrgtLang = "en", "ru"
+				dbMock.On("IsWordInCollection", mock.Anything, coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, coll).Once().Return("", "", nil)
+				dbMock.On("SaveUserTrans", mock.Anything, entity.UserTrans{
+					UserID:          "12345",
+					Word:            "break a leg",
+					SrcLang:         "en",
+					TrgtLang:        "ru",
+					MainTranslation: "ни пуха ни пера",
+					Provider:        "custom",
+				}).Once().Return(nil)
+				dbMock.On("AddCustomWord", mock.Anything, withPair).Once().Return(nil)
+			},
+		},
+		{
+			name: "Add custom word that in collection",
+			setupMock: func(dbMock *repomock.WordRepo) {
+				dbMock.On("IsWordInCollection", mock.Anything, coll).Once().Return(true, nil)
+			},
+			wantErr: entity.ErrWordAlreadyInCollection,
+		},
+		{
+			name: "Add custom word with collection language pair",
+			setupMock: func(dbMock *repomock.WordRepo) {
+				withPair := coll
+				withPair.SrcLang, withPair.TrgtLang = "de", "es"
+				dbMock.On("IsWordInCollection", mock.Anything, coll).Once().Return(false, nil)
+				dbMock.On("LanguagePair", mock.Anything, coll).Once().Return("de", "es", nil)
+				dbMock.On("SaveUserTrans", mock.Anything, mock.MatchedBy(func(ut entity.UserTrans) bool {
+					return ut.SrcLang == "de" && ut.TrgtLang == "es"
+				})).Once().Return(nil)
+				dbMock.On("AddCustomWord", mock.Anything, withPair).Once().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		dbMock, trMock, stMock := setupWordService(t)
+		wordService := NewWordService(dbMock, txStub{}, TransProviders{{Name: "google", Repo: trMock}}, stMock, setupSchedulers(), "en", "ru", 2, time.Second)
+		tt.setupMock(dbMock)
+		withoutUserLangs(stMock)
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := wordService.AddCustomWord(ctx, coll, custom)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want err %v but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Package auth verifies bearer tokens of API users.
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidToken is returned for tokens which aren't signed by a trusted key, are expired or
+// issued by another issuer or for another audience.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Identity of a token owner.
+type Identity struct {
+	Subject string
+	// All claims of the token.
+	Claims map[string]interface{}
+}
+
+type Authenticator interface {
+	// Authenticate verifies token and returns identity of its owner.
+	Authenticate(ctx context.Context, token string) (Identity, error)
+}
+
+// Options of claims checks, empty values aren't checked.
+type Options struct {
+	// Expected iss claim.
+	Issuer string
+	// Value which aud claim must contain, required by Google and JWKS authenticators.
+	Audience string
+}
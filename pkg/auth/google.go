@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/idtoken"
+)
+
+// Google verifies Google ID tokens, public keys are fetched from Google and cached.
+type Google struct {
+	opts Options
+}
+
+func (g *Google) Authenticate(ctx context.Context, token string) (Identity, error) {
+	// Issuer is always checked to be Google.
+	p, err := idtoken.Validate(ctx, token, g.opts.Audience)
+	if err != nil {
+		return Identity{}, fmt.Errorf("Google - Authenticate - idtoken.Validate: %w: %v", ErrInvalidToken, err)
+	}
+	if g.opts.Issuer != "" && p.Issuer != g.opts.Issuer {
+		return Identity{}, fmt.Errorf("Google - Authenticate: %w: issuer %q", ErrInvalidToken, p.Issuer)
+	}
+	if p.Subject == "" {
+		return Identity{}, fmt.Errorf("Google - Authenticate: %w: empty subject", ErrInvalidToken)
+	}
+
+	return Identity{Subject: p.Subject, Claims: p.Claims}, nil
+}
+
+// NewGoogle requires audience, any Google client could get a valid ID token without it.
+func NewGoogle(opts Options) (*Google, error) {
+	if opts.Audience == "" {
+		return nil, fmt.Errorf("auth - NewGoogle: empty audience")
+	}
+	return &Google{opts: opts}, nil
+}
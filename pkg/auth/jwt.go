@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+// JWT verifies signed JWTs, e.g. access tokens of an OIDC provider or tokens signed with a shared secret.
+type JWT struct {
+	opts Options
+	// Parse options which verify the signature.
+	verify func(ctx context.Context) ([]jwt.ParseOption, error)
+}
+
+func (j *JWT) Authenticate(ctx context.Context, token string) (Identity, error) {
+	verify, err := j.verify(ctx)
+	if err != nil {
+		return Identity{}, fmt.Errorf("JWT - Authenticate - verify: %w", err)
+	}
+
+	parseOpts := make([]jwt.ParseOption, 0, len(verify)+6)
+	parseOpts = append(
+		parseOpts,
+		jwt.WithValidate(true),
+		jwt.WithContext(ctx),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithRequiredClaim(jwt.SubjectKey),
+	)
+	parseOpts = append(parseOpts, verify...)
+	if j.opts.Issuer != "" {
+		parseOpts = append(parseOpts, jwt.WithIssuer(j.opts.Issuer))
+	}
+	if j.opts.Audience != "" {
+		parseOpts = append(parseOpts, jwt.WithAudience(j.opts.Audience))
+	}
+
+	t, err := jwt.Parse([]byte(token), parseOpts...)
+	if err != nil {
+		return Identity{}, fmt.Errorf("JWT - Authenticate - jwt.Parse: %w: %v", ErrInvalidToken, err)
+	}
+	claims, err := t.AsMap(ctx)
+	if err != nil {
+		return Identity{}, fmt.Errorf("JWT - Authenticate - t.AsMap: %w", err)
+	}
+
+	return Identity{Subject: t.Subject(), Claims: claims}, nil
+}
+
+// NewHS256 verifies tokens signed with HMAC SHA-256 and secret.
+func NewHS256(secret []byte, opts Options) (*JWT, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("auth - NewHS256: empty secret")
+	}
+
+	verify := []jwt.ParseOption{jwt.WithVerify(jwa.HS256, secret)}
+	return &JWT{
+		opts: opts,
+		verify: func(context.Context) ([]jwt.ParseOption, error) {
+			return verify, nil
+		},
+	}, nil
+}
+
+// NewJWKSFile verifies tokens with public keys of JWK set in file, audience is required.
+func NewJWKSFile(path string, opts Options) (*JWT, error) {
+	if opts.Audience == "" {
+		return nil, fmt.Errorf("auth - NewJWKSFile: empty audience")
+	}
+
+	set, err := jwk.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth - NewJWKSFile - jwk.ReadFile: %w", err)
+	}
+
+	verify := keySet(set)
+	return &JWT{
+		opts: opts,
+		verify: func(context.Context) ([]jwt.ParseOption, error) {
+			return verify, nil
+		},
+	}, nil
+}
+
+// NewJWKSURL verifies tokens with public keys of JWK set published at url, e.g. jwks_uri of OIDC provider.
+// The set is fetched again every refresh in background until ctx is done. Audience is required,
+// keys of an OIDC provider sign tokens of all its clients.
+func NewJWKSURL(ctx context.Context, url string, refresh time.Duration, opts Options) (*JWT, error) {
+	if opts.Audience == "" {
+		return nil, fmt.Errorf("auth - NewJWKSURL: empty audience")
+	}
+
+	ar := jwk.NewAutoRefresh(ctx)
+	ar.Configure(url, jwk.WithRefreshInterval(refresh))
+	// Fail on start-up if the set can't be fetched.
+	if _, err := ar.Refresh(ctx, url); err != nil {
+		return nil, fmt.Errorf("auth - NewJWKSURL - ar.Refresh: %w", err)
+	}
+
+	return &JWT{
+		opts: opts,
+		verify: func(ctx context.Context) ([]jwt.ParseOption, error) {
+			set, err := ar.Fetch(ctx, url)
+			if err != nil {
+				return nil, fmt.Errorf("ar.Fetch: %w", err)
+			}
+			return keySet(set), nil
+		},
+	}, nil
+}
+
+// Algorithm is inferred from the key type when it isn't set in JWK, the only key is used when a token has no kid.
+func keySet(set jwk.Set) []jwt.ParseOption {
+	return []jwt.ParseOption{
+		jwt.WithKeySet(set),
+		jwt.InferAlgorithmFromKey(true),
+		jwt.UseDefaultKey(true),
+	}
+}
@@ -0,0 +1,279 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+var (
+	secret = []byte("shared secret")
+	opts   = Options{Issuer: "https://issuer.test", Audience: "flash_cards_api"}
+)
+
+// Signed token with valid claims changed by modify.
+func newToken(t *testing.T, alg jwa.SignatureAlgorithm, key interface{}, modify func(jwt.Token)) string {
+	t.Helper()
+	tok := jwt.New()
+	_ = tok.Set(jwt.SubjectKey, "12345")
+	_ = tok.Set(jwt.IssuerKey, opts.Issuer)
+	_ = tok.Set(jwt.AudienceKey, []string{opts.Audience})
+	_ = tok.Set(jwt.ExpirationKey, time.Now().Add(time.Hour))
+	_ = tok.Set("role", "admin")
+	if modify != nil {
+		modify(tok)
+	}
+	signed, err := jwt.Sign(tok, alg, key)
+	if err != nil {
+		t.Fatalf("newToken - jwt.Sign: %v", err)
+	}
+	return string(signed)
+}
+
+// Token with alg none header and without signature.
+func unsigned(token string) string {
+	parts := strings.Split(token, ".")
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	return header + "." + parts[1] + "."
+}
+
+// RSA key with kid and JWK set of its public key.
+func newKeySet(t *testing.T) (jwk.Key, []byte) {
+	t.Helper()
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("newKeySet - rsa.GenerateKey: %v", err)
+	}
+	key, err := jwk.New(raw)
+	if err != nil {
+		t.Fatalf("newKeySet - jwk.New: %v", err)
+	}
+	_ = key.Set(jwk.KeyIDKey, "key-1")
+	pub, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("newKeySet - key.PublicKey: %v", err)
+	}
+	set := jwk.NewSet()
+	set.Add(pub)
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("newKeySet - json.Marshal: %v", err)
+	}
+	return key, data
+}
+
+func Test_HS256(t *testing.T) {
+	a, err := NewHS256(secret, opts)
+	if err != nil {
+		t.Fatalf("NewHS256: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "Valid token",
+			token: newToken(t, jwa.HS256, secret, nil),
+		},
+		{
+			name:    "Wrong secret",
+			token:   newToken(t, jwa.HS256, []byte("other secret"), nil),
+			wantErr: true,
+		},
+		{
+			name:    "Other algorithm",
+			token:   newToken(t, jwa.HS512, secret, nil),
+			wantErr: true,
+		},
+		{
+			name:    "Unsigned token",
+			token:   unsigned(newToken(t, jwa.HS256, secret, nil)),
+			wantErr: true,
+		},
+		{
+			name: "Expired token",
+			token: newToken(t, jwa.HS256, secret, func(tok jwt.Token) {
+				_ = tok.Set(jwt.ExpirationKey, time.Now().Add(-time.Hour))
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Without expiration",
+			token: newToken(t, jwa.HS256, secret, func(tok jwt.Token) {
+				_ = tok.Remove(jwt.ExpirationKey)
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Without subject",
+			token: newToken(t, jwa.HS256, secret, func(tok jwt.Token) {
+				_ = tok.Remove(jwt.SubjectKey)
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Other issuer",
+			token: newToken(t, jwa.HS256, secret, func(tok jwt.Token) {
+				_ = tok.Set(jwt.IssuerKey, "https://other.test")
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Other audience",
+			token: newToken(t, jwa.HS256, secret, func(tok jwt.Token) {
+				_ = tok.Set(jwt.AudienceKey, []string{"other"})
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "Not a jwt",
+			token:   "invalid.jwt.bad_jwt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := a.Authenticate(context.Background(), tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("wanted error: %v but got: %v", ErrInvalidToken, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id.Subject != "12345" || id.Claims["role"] != "admin" {
+				t.Fatalf("wanted subject 12345 with role claim but got: %+v", id)
+			}
+		})
+	}
+}
+
+func Test_NewHS256EmptySecret(t *testing.T) {
+	if _, err := NewHS256(nil, opts); err == nil {
+		t.Fatalf("wanted error for empty secret")
+	}
+}
+
+func Test_NewWithoutAudience(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	_, set := newKeySet(t)
+	if err := os.WriteFile(path, set, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	noAudience := Options{Issuer: opts.Issuer}
+
+	if _, err := NewGoogle(noAudience); err == nil {
+		t.Fatalf("wanted error of Google for empty audience")
+	}
+	if _, err := NewJWKSFile(path, noAudience); err == nil {
+		t.Fatalf("wanted error of JWKS file for empty audience")
+	}
+	if _, err := NewJWKSURL(context.Background(), "http://127.0.0.1:1", time.Hour, noAudience); err == nil {
+		t.Fatalf("wanted error of JWKS URL for empty audience")
+	}
+}
+
+func Test_JWKS(t *testing.T) {
+	key, set := newKeySet(t)
+	otherKey, _ := newKeySet(t)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, set, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	fromFile, err := NewJWKSFile(path, opts)
+	if err != nil {
+		t.Fatalf("NewJWKSFile: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(set)
+	}))
+	t.Cleanup(srv.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	fromURL, err := NewJWKSURL(ctx, srv.URL, time.Hour, opts)
+	if err != nil {
+		t.Fatalf("NewJWKSURL: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "Valid token",
+			token: newToken(t, jwa.RS256, key, nil),
+		},
+		{
+			name:    "Unknown key",
+			token:   newToken(t, jwa.RS256, otherKey, nil),
+			wantErr: true,
+		},
+		{
+			name:    "Shared secret token",
+			token:   newToken(t, jwa.HS256, secret, nil),
+			wantErr: true,
+		},
+		{
+			name: "Other audience",
+			token: newToken(t, jwa.RS256, key, func(tok jwt.Token) {
+				_ = tok.Set(jwt.AudienceKey, []string{"other"})
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, a := range []struct {
+		name string
+		a    Authenticator
+	}{{"File", fromFile}, {"URL", fromURL}} {
+		for _, tt := range tests {
+			t.Run(a.name+"/"+tt.name, func(t *testing.T) {
+				id, err := a.a.Authenticate(context.Background(), tt.token)
+				if tt.wantErr {
+					if !errors.Is(err, ErrInvalidToken) {
+						t.Fatalf("wanted error: %v but got: %v", ErrInvalidToken, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if id.Subject != "12345" {
+					t.Fatalf("wanted subject 12345 but got: %+v", id)
+				}
+			})
+		}
+	}
+}
+
+func Test_NewJWKSURLUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	if _, err := NewJWKSURL(context.Background(), srv.URL, time.Hour, opts); err == nil {
+		t.Fatalf("wanted error for unavailable key set")
+	}
+}
@@ -0,0 +1,93 @@
+package googletransclient
+
+import (
+	"sync"
+	"time"
+)
+
+// Circuit breaker opens after threshold consecutive failed requests,
+// when cooldown passes one trial request is let through and its result closes or reopens the breaker.
+// Nil breaker lets all requests through.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	// Trial request is in flight.
+	trial bool
+	now   func() time.Time
+}
+
+// Allow reports whether the request is the trial one, its result must be reported with trial flag.
+func (b *breaker) allow() (trial bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return false, nil
+	}
+	wait := b.cooldown - b.now().Sub(b.openedAt)
+	if wait > 0 || b.trial {
+		if wait < 0 {
+			wait = 0
+		}
+		return false, &CircuitOpenError{RetryAfter: wait}
+	}
+	b.trial = true
+	return true, nil
+}
+
+func (b *breaker) success(trial bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.release(trial)
+}
+
+func (b *breaker) failure(trial bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.release(trial)
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+// Ignore lets the next trial through without changing the state, it is used for canceled requests.
+func (b *breaker) ignore(trial bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.release(trial)
+}
+
+// Must be called with the lock held.
+func (b *breaker) release(trial bool) {
+	if trial {
+		b.trial = false
+	}
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
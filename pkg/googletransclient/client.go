@@ -2,11 +2,19 @@
 package googletransclient
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"golang.org/x/net/http2"
 )
@@ -20,25 +28,166 @@ var queries = url.Values{
 	"bl":           {"boq_translate-webserver_20201207.13_p0"},
 }
 
+// StatusError is returned when translate responds with a status other than 200.
+type StatusError struct {
+	Code int
+	// Delay requested by Retry-After header, zero if there is none.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d %s", e.Code, http.StatusText(e.Code))
+}
+
+// Temporary reports whether the request may succeed later.
+func (e *StatusError) Temporary() bool {
+	return e.Code == http.StatusTooManyRequests || e.Code >= http.StatusInternalServerError
+}
+
+// CircuitOpenError is returned without making a request while translate is considered down.
+type CircuitOpenError struct {
+	// Time left until a trial request is let through.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open, retry after %v", e.RetryAfter)
+}
+
+// Options of the client, zero values disable the corresponding feature.
+type Options struct {
+	// Timeout of one attempt.
+	Timeout time.Duration
+	// Retries of attempts failed with a network error, a timeout, 429 or 5xx.
+	Retries int
+	// Delay before the first retry, it doubles with each next retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Consecutive failed requests which open the circuit breaker for BreakerCooldown.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// PEM file with CA certificates trusted in addition to the system ones.
+	CAFile string
+}
+
 type TranlateClient struct {
-	client *http.Client
-	url    string
+	client  *http.Client
+	url     string
+	opts    Options
+	breaker *breaker
 }
 
 // Translates word from srcLang to trgtLang.
-func (t *TranlateClient) Translate(text, srcLang, trgtLang string) ([]byte, error) {
-	resp, err := t.client.PostForm(t.url, t.getPostForm(text, srcLang, trgtLang))
+func (t *TranlateClient) Translate(ctx context.Context, text, srcLang, trgtLang string) ([]byte, error) {
+	trial, err := t.breaker.allow()
+	if err != nil {
+		return nil, fmt.Errorf("TranlateClient - Translate - allow: %w", err)
+	}
+
+	data, err := t.translate(ctx, t.getPostForm(text, srcLang, trgtLang))
+	t.record(ctx, trial, err)
 	if err != nil {
-		return nil, fmt.Errorf("TranlateClient - Translate - PostForm: %w", err)
+		return nil, fmt.Errorf("TranlateClient - Translate - translate: %w", err)
+	}
+
+	return data, nil
+}
+
+// Makes attempts until one succeeds, fails permanently or retries are exhausted.
+func (t *TranlateClient) translate(ctx context.Context, form url.Values) ([]byte, error) {
+	for retry := 0; ; retry++ {
+		data, err := t.attempt(ctx, form)
+		if err == nil {
+			return data, nil
+		}
+		if retry >= t.opts.Retries || !t.temporary(ctx, err) {
+			return nil, err
+		}
+
+		timer := time.NewTimer(t.backoff(retry, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *TranlateClient) attempt(ctx context.Context, form url.Values) ([]byte, error) {
+	if t.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.opts.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Do: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		// Body is drained so the connection can be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+		return nil, &StatusError{Code: resp.StatusCode, RetryAfter: retryAfter(resp.Header)}
+	}
+
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("TranlateClient - Translate - ReadAll: %w", err)
+		return nil, fmt.Errorf("ReadAll: %w", err)
 	}
+	return data, nil
+}
 
-	return data, err
+// Reports whether the attempt failed because of translate rather than because the caller gave up.
+func (t *TranlateClient) temporary(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Temporary()
+	}
+	// Network errors and attempt timeouts.
+	return true
+}
+
+// Exponential backoff with jitter, Retry-After of the response is respected up to MaxBackoff.
+func (t *TranlateClient) backoff(retry int, err error) time.Duration {
+	d := t.opts.Backoff << retry
+	if d > 0 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > d {
+		d = statusErr.RetryAfter
+	}
+	if t.opts.MaxBackoff > 0 && (d > t.opts.MaxBackoff || d < 0) {
+		d = t.opts.MaxBackoff
+	}
+	return d
+}
+
+// Reports the request result to the circuit breaker.
+func (t *TranlateClient) record(ctx context.Context, trial bool, err error) {
+	var statusErr *StatusError
+	switch {
+	case err == nil, errors.As(err, &statusErr) && !statusErr.Temporary():
+		t.breaker.success(trial)
+	case ctx.Err() != nil:
+		// Canceled request tells nothing about translate.
+		t.breaker.ignore(trial)
+	default:
+		t.breaker.failure(trial)
+	}
 }
 
 func (t *TranlateClient) getPostForm(text, srcLang, trgtLang string) url.Values {
@@ -49,15 +198,52 @@ func (t *TranlateClient) getPostForm(text, srcLang, trgtLang string) url.Values
 	}
 }
 
-func New(apiurl string) (*TranlateClient, error) {
+// Parses Retry-After header given in seconds.
+func retryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// System CA pool with certificates of the PEM file added.
+func rootCAs(caFile string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if caFile == "" {
+		return pool, nil
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("ReadFile: %w", err)
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in %s", caFile)
+	}
+	return pool, nil
+}
+
+func New(apiurl string, opts Options) (*TranlateClient, error) {
 	u, err := url.Parse(apiurl)
 	if err != nil {
 		return nil, err
 	}
 
+	roots, err := rootCAs(opts.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("TranlateClient - New - rootCAs: %w", err)
+	}
+
 	u.RawQuery = queries.Encode()
 	t := &http2.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		TLSClientConfig: &tls.Config{
+			RootCAs:    roots,
+			MinVersion: tls.VersionTLS12,
+		},
 	}
 
 	client := &http.Client{
@@ -67,6 +253,10 @@ func New(apiurl string) (*TranlateClient, error) {
 	transCLient := new(TranlateClient)
 	transCLient.client = client
 	transCLient.url = u.String()
+	transCLient.opts = opts
+	if opts.BreakerThreshold > 0 {
+		transCLient.breaker = newBreaker(opts.BreakerThreshold, opts.BreakerCooldown)
+	}
 
 	return transCLient, nil
 }
@@ -0,0 +1,191 @@
+package googletransclient
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Starts HTTP/2 server which responds with statuses in order, the last one is repeated.
+// Returned CA file trusts the server certificate.
+func setupServer(t *testing.T, delay time.Duration, statuses ...int) (srv *httptest.Server, caFile string, attempts *int32) {
+	t.Helper()
+	attempts = new(int32)
+	srv = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(attempts, 1))
+		if n > len(statuses) {
+			n = len(statuses)
+		}
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		if r.FormValue("f.req") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(statuses[n-1])
+		_, _ = w.Write([]byte("response"))
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	caFile = filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, cert, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	return srv, caFile, attempts
+}
+
+func Test_Translate(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		delay        time.Duration
+		opts         Options
+		untrusted    bool
+		wantAttempts int32
+		wantStatus   int
+		wantErr      bool
+	}{
+		{
+			name:         "Success",
+			statuses:     []int{http.StatusOK},
+			wantAttempts: 1,
+		},
+		{
+			name:         "Retry 429 and 5xx",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK},
+			opts:         Options{Retries: 2, Backoff: time.Millisecond},
+			wantAttempts: 3,
+		},
+		{
+			name:         "Retries exhausted",
+			statuses:     []int{http.StatusServiceUnavailable},
+			opts:         Options{Retries: 2, Backoff: time.Millisecond},
+			wantAttempts: 3,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantErr:      true,
+		},
+		{
+			name:         "Client error isn't retried",
+			statuses:     []int{http.StatusForbidden},
+			opts:         Options{Retries: 2, Backoff: time.Millisecond},
+			wantAttempts: 1,
+			wantStatus:   http.StatusForbidden,
+			wantErr:      true,
+		},
+		{
+			name:         "Attempt timeout",
+			statuses:     []int{http.StatusOK},
+			delay:        time.Second,
+			opts:         Options{Timeout: 50 * time.Millisecond, Retries: 1, Backoff: time.Millisecond},
+			wantAttempts: 2,
+			wantErr:      true,
+		},
+		{
+			name:         "Untrusted certificate",
+			statuses:     []int{http.StatusOK},
+			untrusted:    true,
+			wantAttempts: 0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		srv, caFile, attempts := setupServer(t, tt.delay, tt.statuses...)
+		if !tt.untrusted {
+			tt.opts.CAFile = caFile
+		}
+		client, err := New(srv.URL, tt.opts)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := client.Translate(context.Background(), "gift", "en", "ru")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want err: %v but got: %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && string(data) != "response" {
+				t.Fatalf("want response but got: %s", data)
+			}
+			if tt.wantStatus != 0 {
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.Code != tt.wantStatus {
+					t.Fatalf("want status error %v but got: %v", tt.wantStatus, err)
+				}
+			}
+			// Timed out attempt may be counted by the server after the client gave up.
+			time.Sleep(10 * time.Millisecond)
+			if got := atomic.LoadInt32(attempts); got != tt.wantAttempts {
+				t.Fatalf("want %v attempts but got: %v", tt.wantAttempts, got)
+			}
+		})
+	}
+}
+
+func Test_CircuitBreaker(t *testing.T) {
+	srv, caFile, attempts := setupServer(t, 0, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK)
+	client, err := New(srv.URL, Options{BreakerThreshold: 2, BreakerCooldown: time.Hour, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Translate(ctx, "gift", "en", "ru"); err == nil {
+			t.Fatalf("want failed request %d", i)
+		}
+	}
+
+	var openErr *CircuitOpenError
+	if _, err := client.Translate(ctx, "gift", "en", "ru"); !errors.As(err, &openErr) || openErr.RetryAfter != time.Hour {
+		t.Fatalf("want open circuit error but got: %v", err)
+	}
+	if got := atomic.LoadInt32(attempts); got != 2 {
+		t.Fatalf("open circuit must not make requests but got %v attempts", got)
+	}
+
+	// Trial request after cooldown closes the breaker.
+	now = now.Add(time.Hour)
+	if _, err := client.Translate(ctx, "gift", "en", "ru"); err != nil {
+		t.Fatalf("want trial request to succeed but got: %v", err)
+	}
+	if _, err := client.Translate(ctx, "gift", "en", "ru"); err != nil {
+		t.Fatalf("want closed circuit but got: %v", err)
+	}
+}
+
+func Test_CanceledRequest(t *testing.T) {
+	srv, caFile, attempts := setupServer(t, time.Second, http.StatusOK)
+	client, err := New(srv.URL, Options{Retries: 2, BreakerThreshold: 1, BreakerCooldown: time.Hour, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Translate(ctx, "gift", "en", "ru"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want deadline exceeded but got: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if got := atomic.LoadInt32(attempts); got != 1 {
+		t.Fatalf("canceled request must not be retried but got %v attempts", got)
+	}
+	if _, err := client.breaker.allow(); err != nil {
+		t.Fatalf("canceled request must not open the circuit but got: %v", err)
+	}
+}
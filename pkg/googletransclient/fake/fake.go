@@ -0,0 +1,251 @@
+// Package fake implements HTTP/2 server which replays recorded responses of google.translate.com,
+// it lets tests and development run without network.
+package fake
+
+import (
+	"embed"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// Path of batchexecute endpoint, server responds on any path.
+const Path = "/_/TranslateWebserverUi/data/batchexecute"
+
+//go:embed recordings
+var recordings embed.FS
+
+// Fault injected into a response.
+type Fault int
+
+const (
+	// Body isn't a batchexecute response.
+	Malformed Fault = iota + 1
+	// Status is 429 with Retry-After header.
+	TooManyRequests
+)
+
+type Options struct {
+	// Address to listen on, a random local port is used when it is empty.
+	Addr string
+	// Delay of each response.
+	Latency time.Duration
+	// Shares of requests answered with a fault, from 0 to 1.
+	MalformedRate       float64
+	TooManyRequestsRate float64
+}
+
+// Recorded response of f.req payload.
+type recording struct {
+	FReq     string `json:"f.req"`
+	Response string `json:"response"`
+}
+
+type Server struct {
+	srv    *httptest.Server
+	caDir  string
+	opts   Options
+	mu     sync.Mutex
+	bodies map[string][]byte
+	// Faults of the next responses, they are used before random ones.
+	faults   []Fault
+	requests int
+}
+
+// URL of batchexecute endpoint.
+func (s *Server) URL() string {
+	return s.srv.URL + Path
+}
+
+// CAFile is PEM file which trusts the server certificate.
+func (s *Server) CAFile() string {
+	return filepath.Join(s.caDir, "ca.pem")
+}
+
+// Add replays body for requests with f.req payload.
+func (s *Server) Add(freq string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bodies[freq] = body
+}
+
+// Inject makes the next responses fail with faults in order.
+func (s *Server) Inject(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, faults...)
+}
+
+// Requests returns number of handled requests.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+	os.RemoveAll(s.caDir)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	freq := r.PostFormValue("f.req")
+	body, ok := s.body(freq)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fault := s.fault()
+
+	timer := time.NewTimer(s.opts.Latency)
+	defer timer.Stop()
+	select {
+	case <-r.Context().Done():
+		return
+	case <-timer.C:
+	}
+
+	switch fault {
+	case TooManyRequests:
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	case Malformed:
+		body = body[:len(body)/2]
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write(body)
+}
+
+// Recorded body of f.req, the unknown text is echoed like translate does for unknown words.
+func (s *Server) body(freq string) ([]byte, bool) {
+	s.mu.Lock()
+	s.requests++
+	body, ok := s.bodies[freq]
+	s.mu.Unlock()
+	if ok {
+		return body, true
+	}
+
+	args := gjson.Parse(gjson.Get(freq, "0.0.1").String())
+	text, srcLang, trgtLang := args.Get("0.0"), args.Get("0.1"), args.Get("0.2")
+	if !text.Exists() || !srcLang.Exists() || !trgtLang.Exists() {
+		return nil, false
+	}
+	return echo(text.String(), srcLang.String(), trgtLang.String()), true
+}
+
+func (s *Server) fault() Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.faults) != 0 {
+		fault := s.faults[0]
+		s.faults = s.faults[1:]
+		return fault
+	}
+
+	n := rand.Float64()
+	switch {
+	case n < s.opts.MalformedRate:
+		return Malformed
+	case n < s.opts.MalformedRate+s.opts.TooManyRequestsRate:
+		return TooManyRequests
+	}
+	return 0
+}
+
+// Response without dictionary data, translation is the text itself.
+func echo(text, srcLang, trgtLang string) []byte {
+	payload, _ := json.Marshal([]interface{}{
+		nil,
+		[]interface{}{
+			[]interface{}{[]interface{}{nil, nil, nil, true, nil, [][]string{{text}}}},
+			trgtLang, 1, srcLang,
+			[]interface{}{text, srcLang, trgtLang, true},
+		},
+		srcLang,
+		nil,
+	})
+	envelopes, _ := json.Marshal([][]interface{}{{"wrb.fr", "MkEWBc", string(payload), nil, nil, nil, "generic"}})
+	return []byte(")]}'\n\n" + strconv.Itoa(len(envelopes)+1) + "\n" + string(envelopes) + "\n")
+}
+
+func load() (map[string][]byte, error) {
+	index, err := recordings.ReadFile(path.Join("recordings", "index.json"))
+	if err != nil {
+		return nil, fmt.Errorf("ReadFile: %w", err)
+	}
+	var recs []recording
+	if err := json.Unmarshal(index, &recs); err != nil {
+		return nil, fmt.Errorf("Unmarshal: %w", err)
+	}
+
+	bodies := make(map[string][]byte, len(recs))
+	for _, rec := range recs {
+		body, err := recordings.ReadFile(path.Join("recordings", rec.Response))
+		if err != nil {
+			return nil, fmt.Errorf("ReadFile: %w", err)
+		}
+		bodies[rec.FReq] = body
+	}
+	return bodies, nil
+}
+
+// New starts the server with the recorded responses, it must be closed.
+func New(opts Options) (*Server, error) {
+	bodies, err := load()
+	if err != nil {
+		return nil, fmt.Errorf("fake - New - load: %w", err)
+	}
+
+	s := &Server{
+		opts:   opts,
+		bodies: bodies,
+	}
+	s.srv = httptest.NewUnstartedServer(s)
+	if opts.Addr != "" {
+		l, err := net.Listen("tcp", opts.Addr)
+		if err != nil {
+			s.srv.Close()
+			return nil, fmt.Errorf("fake - New - Listen: %w", err)
+		}
+		s.srv.Listener.Close()
+		s.srv.Listener = l
+	}
+	s.srv.EnableHTTP2 = true
+	s.srv.StartTLS()
+
+	s.caDir, err = os.MkdirTemp("", "fake-translate")
+	if err != nil {
+		s.srv.Close()
+		return nil, fmt.Errorf("fake - New - MkdirTemp: %w", err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.srv.Certificate().Raw})
+	if err := os.WriteFile(s.CAFile(), cert, 0o600); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("fake - New - WriteFile: %w", err)
+	}
+
+	return s, nil
+}
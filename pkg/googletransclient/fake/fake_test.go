@@ -0,0 +1,110 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Kin-dza-dzaa/flash_cards_api/pkg/googletransclient"
+)
+
+func setupServer(t *testing.T, opts Options) (*Server, *googletransclient.TranlateClient) {
+	t.Helper()
+	s, err := New(opts)
+	if err != nil {
+		t.Fatalf("setupServer - New: %v", err)
+	}
+	t.Cleanup(s.Close)
+
+	client, err := googletransclient.New(s.URL(), googletransclient.Options{
+		Timeout: 500 * time.Millisecond,
+		CAFile:  s.CAFile(),
+	})
+	if err != nil {
+		t.Fatalf("setupServer - googletransclient.New: %v", err)
+	}
+	return s, client
+}
+
+func Test_Server(t *testing.T) {
+	recorded, err := recordings.ReadFile("recordings/noun_en_ru.txt")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		text       string
+		opts       Options
+		faults     []Fault
+		wantBody   []byte
+		wantEcho   bool
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:     "Recorded response",
+			text:     "lead",
+			wantBody: recorded,
+		},
+		{
+			name:     "Unknown text is echoed",
+			text:     "flashcard",
+			wantEcho: true,
+		},
+		{
+			name:       "Injected 429",
+			text:       "lead",
+			faults:     []Fault{TooManyRequests},
+			wantStatus: http.StatusTooManyRequests,
+			wantErr:    true,
+		},
+		{
+			name:     "Injected malformed body",
+			text:     "lead",
+			faults:   []Fault{Malformed},
+			wantBody: recorded[:len(recorded)/2],
+		},
+		{
+			name:       "429 by rate",
+			text:       "lead",
+			opts:       Options{TooManyRequestsRate: 1},
+			wantStatus: http.StatusTooManyRequests,
+			wantErr:    true,
+		},
+		{
+			name:    "Latency longer than timeout",
+			text:    "lead",
+			opts:    Options{Latency: time.Second},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s, client := setupServer(t, tt.opts)
+		s.Inject(tt.faults...)
+
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := client.Translate(context.Background(), tt.text, "en", "ru")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wanted error: %v but got: %v", tt.wantErr, err)
+			}
+			var statusErr *googletransclient.StatusError
+			if tt.wantStatus != 0 && (!errors.As(err, &statusErr) || statusErr.Code != tt.wantStatus) {
+				t.Fatalf("wanted status: %d but got: %v", tt.wantStatus, err)
+			}
+			if tt.wantBody != nil && !bytes.Equal(body, tt.wantBody) {
+				t.Fatalf("wanted body:\n%s\nbut got:\n%s", tt.wantBody, body)
+			}
+			if tt.wantEcho && !bytes.Equal(body, echo(tt.text, "en", "ru")) {
+				t.Fatalf("wanted echo of %q but got:\n%s", tt.text, body)
+			}
+			if got := s.Requests(); got != 1 {
+				t.Fatalf("wanted 1 request but got: %d", got)
+			}
+		})
+	}
+}
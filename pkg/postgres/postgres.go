@@ -6,9 +6,12 @@ import (
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+type txCtxKey struct{}
+
 type ConnPool struct {
 	Pool    *pgxpool.Pool
 	Builder sq.StatementBuilderType
@@ -18,6 +21,31 @@ func (p *ConnPool) Close() {
 	p.Pool.Close()
 }
 
+// WithinTx runs fn in a transaction, queries run by BeginFunc with ctx of fn are part of it.
+// Nested call runs in the outer transaction.
+func (p *ConnPool) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	return p.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txCtxKey{}, tx))
+	})
+}
+
+// InTx reports whether ctx is of a transaction started by WithinTx.
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txCtxKey{}).(pgx.Tx)
+	return ok
+}
+
+// BeginFunc runs f in a savepoint of the transaction started by WithinTx or in a new transaction.
+func (p *ConnPool) BeginFunc(ctx context.Context, f func(pgx.Tx) error) error {
+	if tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		return tx.BeginFunc(ctx, f)
+	}
+	return p.Pool.BeginFunc(ctx, f)
+}
+
 func New(ctx context.Context, pgurl string, maxPoolSize int) (*ConnPool, error) {
 	poolConfig, err := pgxpool.ParseConfig(pgurl)
 	if err != nil {